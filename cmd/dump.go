@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+)
+
+// dumpWidth is the width the articles are wrapped to when they are rendered
+const dumpWidth = 80
+
+// RunDump prints the articles of a feed, or of every feed in a category, to stdout without starting the TUI
+func RunDump(b *backend.Backend, colors *theme.Colors, name string, noColor bool) error {
+	var msgs []tea.Msg
+	if feeds, err := b.Rss.GetFeeds(name); err == nil {
+		for _, feed := range feeds {
+			msgs = append(msgs, b.FetchArticles(feed.Name, false)())
+		}
+	} else {
+		msgs = append(msgs, b.FetchArticles(name, false)())
+	}
+
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStyles(colors.MarkdownStyle),
+		glamour.WithWordWrap(dumpWidth),
+	)
+	if err != nil {
+		return fmt.Errorf("cmd.RunDump: %w", err)
+	}
+
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case backend.FetchErrorMsg:
+			if len(msgs) == 1 {
+				return fmt.Errorf("cmd.RunDump: %s: %w", msg.Description, msg.Err)
+			}
+
+			// Don't let a single broken feed stop the whole category
+			fmt.Fprintln(os.Stderr, msg.Description+":", msg.Err)
+
+		case backend.FetchArticleSuccessMsg:
+			for _, item := range msg.Items {
+				content := item.(backend.ArticleItem).MarkdownContent
+				if !noColor {
+					if content, err = renderer.Render(content); err != nil {
+						return fmt.Errorf("cmd.RunDump: %w", err)
+					}
+				}
+
+				fmt.Print(content)
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,11 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"log"
 	"os"
-	"path/filepath"
+	"slices"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/TypicalAM/goread/internal/backend"
 	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/logging"
 	"github.com/TypicalAM/goread/internal/config"
 	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/TypicalAM/goread/internal/ui/browser"
@@ -28,12 +30,22 @@ type options struct {
 	getColors       string
 	loadOPMLFrom    string
 	exportOPMLTo    string
+	exportStateTo   string
+	importStateFrom string
+	dump            string
+	profile         string
+	logFile         string
+	logLevel        string
 	cacheSize       int
 	cacheDuration   int
 	dumpColors      bool
 	testColors      bool
 	resetCache      bool
 	urlsReadOnly    bool
+	deduplicate     bool
+	mergeOPML       bool
+	pruneDownloaded bool
+	noColor         bool
 }
 
 var (
@@ -58,7 +70,12 @@ func init() {
 	rootCmd.PersistentFlags().
 		StringVarP(&opts.colorschemePath, "colorscheme_path", "c", "", "The path to the colorscheme file")
 	rootCmd.PersistentFlags().StringVarP(&opts.urlsPath, "urls_path", "u", "", "The path to the urls file")
+	rootCmd.PersistentFlags().
+		StringVarP(&opts.profile, "profile", "p", "", "The profile to use, every profile has its own urls file and cache")
 	rootCmd.PersistentFlags().StringVarP(&opts.configPath, "config_path", "s", "", "The path to the configuration file")
+	rootCmd.PersistentFlags().StringVarP(&opts.logFile, "log_file", "", "", "The path to the log file")
+	rootCmd.PersistentFlags().
+		StringVarP(&opts.logLevel, "log_level", "", "", "How much is logged: off, info or debug")
 	rootCmd.Flags().BoolVarP(&opts.testColors, "test_colors", "", false, "Test the colorscheme")
 	rootCmd.Flags().
 		BoolVarP(&opts.dumpColors, "dump_colors", "", false, "Dump the colors to the colorscheme file")
@@ -70,8 +87,22 @@ func init() {
 		IntVarP(&opts.cacheDuration, "cache_duration", "", 0, "The duration of the cache in hours")
 	rootCmd.Flags().
 		StringVarP(&opts.loadOPMLFrom, "load_opml", "i", "", "Import the feeds from an OPML file")
+	rootCmd.Flags().
+		BoolVarP(&opts.mergeOPML, "merge_opml", "", false, "Only add the new feeds when importing with --load_opml, the subscribed feeds stay where they are")
 	rootCmd.Flags().
 		StringVarP(&opts.exportOPMLTo, "export_opml", "e", "", "Export the feeds to an OPML file")
+	rootCmd.Flags().
+		StringVarP(&opts.exportStateTo, "export_state", "", "", "Export the read articles and the read later queue to a file")
+	rootCmd.Flags().
+		StringVarP(&opts.importStateFrom, "import_state", "", "", "Merge the read articles and the read later queue from a file")
+	rootCmd.Flags().
+		StringVarP(&opts.dump, "dump", "", "", "Print the articles of a feed or a category to stdout without starting the TUI")
+	rootCmd.Flags().
+		BoolVarP(&opts.noColor, "no_color", "", false, "Print the articles dumped with --dump as plain markdown without colors")
+	rootCmd.Flags().
+		BoolVarP(&opts.deduplicate, "deduplicate", "", false, "Remove feeds with duplicate urls, can be combined with --load_opml")
+	rootCmd.Flags().
+		BoolVarP(&opts.pruneDownloaded, "prune_downloaded", "", false, "Remove the downloaded articles past the retention set in the config")
 	rootCmd.Flags().
 		BoolVarP(&opts.urlsReadOnly, "urls_readonly", "", false, "Feed urls config is read-only, skip saving the feed urls configuration")
 }
@@ -87,14 +118,21 @@ func SetVersion(version string) {
 
 // Run runs the program
 func Run() error {
-	if f, err := tea.LogToFile(filepath.Join(os.TempDir(), "goread.log"), ""); err == nil {
-		defer f.Close()
-	} else {
-		log.Println("Failed to create log file")
-		log.SetOutput(io.Discard)
+	// The log settings are in the config, the messages from loading it are kept until the log is set up
+	var early bytes.Buffer
+	log.SetOutput(&early)
+	log.Println("Starting goread")
+
+	cfg, cfgErr := config.New(opts.configPath)
+	if cfgErr == nil {
+		cfgErr = cfg.Load()
 	}
 
-	log.Println("Starting goread")
+	logFile, err := setupLog(&early)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
 
 	colors, err := theme.New(opts.colorschemePath)
 	if err != nil {
@@ -153,34 +191,60 @@ func Run() error {
 		cache.DefaultCacheDuration = time.Hour * time.Duration(opts.cacheDuration)
 	}
 
-	// Get the config
-	cfg, err := config.New(opts.configPath)
-	if err != nil {
-		log.Println("Failed to initialize config: ", err)
-		return err
-	}
-
-	if err := cfg.Load(); err != nil {
-		log.Println("Failed to load config: ", err)
-		return err
+	// The config was loaded with the log
+	if cfgErr != nil {
+		log.Println("Failed to load config: ", cfgErr)
+		return cfgErr
 	}
 
 	// Initialize the backend
-	backend, err := backend.New(opts.urlsPath, opts.cacheDir, opts.resetCache)
+	backend, err := backend.New(opts.profile, opts.urlsPath, opts.cacheDir, opts.resetCache)
 	if err != nil {
 		log.Println("Failed to initialize backend: ", err)
 		return err
 	}
 
+	backend.URLsReadOnly = opts.urlsReadOnly
+
 	// Load the OPML file
 	if opts.loadOPMLFrom != "" {
 		log.Println("Loading OPML file: ", opts.loadOPMLFrom)
 
-		if err := backend.Rss.LoadOPML(opts.loadOPMLFrom); err != nil {
-			return err
+		if opts.mergeOPML {
+			summary, err := backend.Rss.MergeOPML(opts.loadOPMLFrom)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(msgStyle.Render(fmt.Sprintf(
+				"Merged OPML file: %d feeds added, %d categories updated, %d feeds skipped",
+				summary.Added, summary.Updated, summary.Skipped,
+			)))
+		} else {
+			if err := backend.Rss.LoadOPML(opts.loadOPMLFrom); err != nil {
+				return err
+			}
+
+			fmt.Println(msgStyle.Render("Loaded OPML file successfully"))
 		}
+	}
+
+	// Remove the duplicate feeds
+	if opts.deduplicate {
+		log.Println("Removing duplicate feeds")
+		removed := backend.Rss.Deduplicate()
+		fmt.Println(msgStyle.Render(fmt.Sprintf("Removed %d duplicate feeds", removed)))
+	}
+
+	if opts.loadOPMLFrom != "" || opts.deduplicate {
+		return backend.Close(opts.urlsReadOnly)
+	}
 
-		fmt.Println(msgStyle.Render("Loaded OPML file successfully"))
+	// Remove the downloaded articles past their retention
+	if opts.pruneDownloaded {
+		log.Println("Pruning downloaded articles")
+		removed := backend.PruneDownloaded()
+		fmt.Println(msgStyle.Render(fmt.Sprintf("Removed %d downloaded articles", removed)))
 		return backend.Close(opts.urlsReadOnly)
 	}
 
@@ -196,14 +260,83 @@ func Run() error {
 		return backend.Close(opts.urlsReadOnly)
 	}
 
+	// Export the reading state
+	if opts.exportStateTo != "" {
+		log.Println("Exporting the reading state to: ", opts.exportStateTo)
+
+		if err := backend.Cache.ExportState(opts.exportStateTo, backend.ReadStatus); err != nil {
+			return err
+		}
+
+		fmt.Println(msgStyle.Render("Exported the reading state successfully"))
+		return backend.Close(opts.urlsReadOnly)
+	}
+
+	// Import the reading state
+	if opts.importStateFrom != "" {
+		log.Println("Importing the reading state from: ", opts.importStateFrom)
+
+		if err := backend.Cache.ImportState(opts.importStateFrom, backend.ReadStatus); err != nil {
+			return err
+		}
+
+		fmt.Println(msgStyle.Render("Imported the reading state successfully"))
+		return backend.Close(opts.urlsReadOnly)
+	}
+
+	// Print the articles without the TUI
+	if opts.dump != "" {
+		log.Println("Dumping articles from: ", opts.dump)
+		noColor := opts.noColor || os.Getenv("NO_COLOR") != ""
+		if err := RunDump(backend, colors, opts.dump, noColor); err != nil {
+			return err
+		}
+
+		return backend.Close(opts.urlsReadOnly)
+	}
+
 	// Create the browser
-	browser := browser.New(colors, backend)
-	if _, err = tea.NewProgram(browser).Run(); err != nil {
+	final, err := tea.NewProgram(browser.New(colors, backend)).Run()
+	if err != nil {
 		log.Println("Bubbletea program fail: ", err)
 		return err
 	}
 
-	// Clean up the backend
+	// Clean up the backend, the profile could have been switched in the meantime
 	log.Println("Closing backend")
+	if model, ok := final.(browser.Model); ok {
+		if model.Closed() {
+			return nil
+		}
+
+		backend = model.Backend()
+	}
+
 	return backend.Close(opts.urlsReadOnly)
 }
+
+// setupLog sends the log to the file from the config or the flags and moves the early messages over,
+// nothing is logged if the file can't be opened since the terminal belongs to the interface, so
+// the user is warned before it starts
+func setupLog(early *bytes.Buffer) (io.Closer, error) {
+	options := logging.DefaultOptions
+	if opts.logFile != "" {
+		options.File = opts.logFile
+	}
+
+	if opts.logLevel != "" {
+		options.Level = logging.Level(opts.logLevel)
+		if !slices.Contains(logging.Levels, options.Level) {
+			return nil, fmt.Errorf("unrecognized log level: %s", opts.logLevel)
+		}
+	}
+
+	closer, err := logging.Setup(options)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: nothing will be logged,", err)
+		return io.NopCloser(nil), nil
+	}
+
+	_, _ = log.Writer().Write(early.Bytes())
+	return closer, nil
+}
@@ -0,0 +1,49 @@
+package atomicfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Write writes the data to a temporary file next to the destination and renames it over the
+// destination, so that a crash in the middle of writing never leaves a truncated file behind. The
+// parent directories are created if they don't exist.
+func Write(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("atomicfile.Write: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("atomicfile.Write: %w", err)
+	}
+
+	// The temporary file is only left over if something failed
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("atomicfile.Write: %w", err)
+	}
+
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("atomicfile.Write: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("atomicfile.Write: %w", err)
+	}
+
+	if err = os.Chmod(tmp.Name(), 0600); err != nil {
+		return fmt.Errorf("atomicfile.Write: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("atomicfile.Write: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestWrite if we get an error the file isn't written, replaced or the temporary file is left over
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "state")
+
+	if err := Write(path, []byte("first")); err != nil {
+		t.Fatalf("failed to write the file, %s", err)
+	}
+
+	if err := Write(path, []byte("second")); err != nil {
+		t.Fatalf("failed to replace the file, %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the file, %s", err)
+	}
+
+	if string(data) != "second" {
+		t.Errorf("incorrect content, expected second, got %s", data)
+	}
+
+	files, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("failed to read the directory, %s", err)
+	}
+
+	if len(files) != 1 {
+		t.Errorf("expected only the written file, got %d files", len(files))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat the file, %s", err)
+	}
+
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("incorrect permissions, expected 0600, got %o", info.Mode().Perm())
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,16 +19,68 @@ import (
 	"github.com/TypicalAM/goread/internal/ui/simplelist"
 )
 
+// searchTitlePrefix is the prefix of the titles of the search results tabs
+const searchTitlePrefix = "Search: "
+
+// downloadedMark and readMark are put in front of the titles of the downloaded and the read articles
+const (
+	downloadedMark = "↓ "
+	readMark       = "✓ "
+)
+
+// AlertMark marks the articles with an alert word and the alert notifications
+const AlertMark = "⚑"
+
+// imageMark marks the articles which have a lead image, the image is linked in the article header
+const imageMark = "▣"
+
 // Backend provides a way of fetching data from the cache and the RSS feed.
 type Backend struct {
 	Rss        *rss.Rss
 	Cache      *cache.Cache
 	ReadStatus *cache.ReadStatus
+	Profile    string
+	options    Options
+
+	// URLsReadOnly is true if the urls file shouldn't be saved when the backend is closed by the browser
+	URLsReadOnly bool
+
+	lastRefresh map[string]time.Time
+	refreshMu   *sync.Mutex
+	saves       *saves
+	retries     *retryQueue
+
+	// feedsMu keeps ReloadFeeds from replacing the feeds while the commands read them in their goroutines
+	feedsMu *sync.RWMutex
+}
+
+// saves keeps the background saves from running at the same time or after the backend is closed
+type saves struct {
+	mu     sync.Mutex
+	closed bool
 }
 
-// New creates a new backend and its components.
-func New(urlPath, cacheDir string, resetCache bool) (*Backend, error) {
-	log.Println("Creating new backend")
+// New creates a new backend and its components for the given profile, non-empty paths take precedence
+// over the ones of the profile.
+func New(profile, urlPath, cacheDir string, resetCache bool) (*Backend, error) {
+	log.Println("Creating new backend for profile", profile)
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	profileURLPath, profileCacheDir, err := ProfilePaths(profile)
+	if err != nil {
+		return nil, fmt.Errorf("backend.New: %w", err)
+	}
+
+	if urlPath == "" {
+		urlPath = profileURLPath
+	}
+
+	if cacheDir == "" {
+		cacheDir = profileCacheDir
+	}
+
 	store, err := cache.New(cacheDir)
 	if err != nil {
 		return nil, fmt.Errorf("backend.New: %w", err)
@@ -55,15 +111,72 @@ func New(urlPath, cacheDir string, resetCache bool) (*Backend, error) {
 		return nil, fmt.Errorf("backend.New: %w", err)
 	}
 
-	return &Backend{rss, store, readStatus}, nil
+	return &Backend{
+		Rss:         rss,
+		Cache:       store,
+		ReadStatus:  readStatus,
+		Profile:     profile,
+		options:     DefaultOptions,
+		lastRefresh: make(map[string]time.Time),
+		refreshMu:   &sync.Mutex{},
+		saves:       &saves{},
+		retries:     &retryQueue{},
+		feedsMu:     &sync.RWMutex{},
+	}, nil
+}
+
+// ReloadFeeds reads the urls file again, see rss.Reload. The feeds are replaced while none of the running
+// commands are reading them.
+func (b Backend) ReloadFeeds() error {
+	b.feedsMu.Lock()
+	defer b.feedsMu.Unlock()
+
+	if err := b.Rss.Reload(); err != nil {
+		return fmt.Errorf("backend.ReloadFeeds: %w", err)
+	}
+
+	return nil
 }
 
 // FetchCategories gets the categories.
 func (b Backend) FetchCategories(_ string) tea.Cmd {
 	return func() tea.Msg {
-		items := make([]list.Item, len(b.Rss.Categories))
-		for i, cat := range b.Rss.Categories {
-			items[i] = simplelist.NewItem(cat.Name, cat.Description)
+		b.feedsMu.RLock()
+		categories := b.Rss.Categories
+		combinations := b.Rss.Combinations
+		b.feedsMu.RUnlock()
+
+		names := make([]string, len(categories))
+		for i, cat := range categories {
+			names[i] = cat.Name
+		}
+
+		order := b.sortedIndices(names, func(i int) int { return b.CategoryUnreadCount(names[i]) })
+
+		// The pinned categories come first regardless of the sort order
+		sort.SliceStable(order, func(i, j int) bool {
+			return categories[order[i]].Pinned && !categories[order[j]].Pinned
+		})
+
+		items := make([]list.Item, len(order))
+		for i, index := range order {
+			cat := categories[index]
+			item := simplelist.NewItem(cat.Name, cat.Description)
+			if cat.Pinned {
+				item = item.Pin()
+			}
+
+			items[i] = item
+		}
+
+		// The combinations are listed after the categories they combine
+		for _, combination := range combinations {
+			desc := combination.Description
+			if desc == "" {
+				desc = "Combines " + strings.Join(combination.Categories, ", ")
+			}
+
+			items = append(items, simplelist.NewItem(combination.Name, desc))
 		}
 
 		return FetchSuccessMsg{Items: items}
@@ -73,41 +186,302 @@ func (b Backend) FetchCategories(_ string) tea.Cmd {
 // FetchFeeds gets the feeds from a category.
 func (b Backend) FetchFeeds(catname string) tea.Cmd {
 	return func() tea.Msg {
+		b.feedsMu.RLock()
 		feeds, err := b.Rss.GetFeeds(catname)
+		b.feedsMu.RUnlock()
 		if err != nil {
 			return FetchErrorMsg{err, "Error while trying to get feeds"}
 		}
 
-		items := make([]list.Item, len(feeds))
+		names := make([]string, len(feeds))
 		for i, feed := range feeds {
-			items[i] = simplelist.NewItem(feed.Name, feed.URL)
+			names[i] = feed.Name
+		}
+
+		order := b.sortedIndices(names, func(i int) int { return b.unreadInFeed(feeds[i].URL) })
+		items := make([]list.Item, len(order))
+		for i, index := range order {
+			item := simplelist.NewItem(feeds[index].Name, feeds[index].URL).WithColor(feeds[index].Color)
+			if feeds[index].Muted {
+				item = item.Dim()
+			}
+
+			items[i] = item
 		}
 
 		return FetchSuccessMsg{items}
 	}
 }
 
+// SortedFeedNames returns the names of the feeds in a category in the order in which they are listed.
+func (b Backend) SortedFeedNames(catname string) ([]string, error) {
+	feeds, err := b.Rss.GetFeeds(catname)
+	if err != nil {
+		return nil, fmt.Errorf("backend.SortedFeedNames: %w", err)
+	}
+
+	names := make([]string, len(feeds))
+	for i, feed := range feeds {
+		names[i] = feed.Name
+	}
+
+	order := b.sortedIndices(names, func(i int) int { return b.unreadInFeed(feeds[i].URL) })
+	sorted := make([]string, len(order))
+	for i, index := range order {
+		sorted[i] = names[index]
+	}
+
+	return sorted, nil
+}
+
+// RemoveFeed removes a feed from a category along with its cached articles, the articles are kept if
+// another feed uses the same url.
+func (b Backend) RemoveFeed(catname, name string) error {
+	feeds, err := b.Rss.GetFeeds(catname)
+	if err != nil {
+		return fmt.Errorf("backend.RemoveFeed: %w", err)
+	}
+
+	var url string
+	for _, feed := range feeds {
+		if feed.Name == name {
+			url = feed.URL
+		}
+	}
+
+	if err = b.Rss.RemoveFeed(catname, name); err != nil {
+		return fmt.Errorf("backend.RemoveFeed: %w", err)
+	}
+
+	b.refreshMu.Lock()
+	delete(b.lastRefresh, name)
+	b.refreshMu.Unlock()
+
+	for _, feed := range b.Rss.GetAllFeeds() {
+		if feed.URL == url {
+			return nil
+		}
+	}
+
+	b.Cache.RemoveEntry(url)
+	return nil
+}
+
+// RenameFeed gives a feed in a category a new name, the cached articles are keyed by the url so they are
+// kept and the refresh cooldown moves to the new name.
+func (b Backend) RenameFeed(catname, oldName, newName string) error {
+	if err := b.Rss.RenameFeed(catname, oldName, newName); err != nil {
+		return fmt.Errorf("backend.RenameFeed: %w", err)
+	}
+
+	b.renameRefresh(oldName, newName)
+	return nil
+}
+
+// UpdateFeed changes the name and the url of a feed in a category, the refresh cooldown moves to the new
+// name.
+func (b Backend) UpdateFeed(catname, oldName, newName, url string) error {
+	if err := b.Rss.UpdateFeed(catname, oldName, newName, url); err != nil {
+		return fmt.Errorf("backend.UpdateFeed: %w", err)
+	}
+
+	b.renameRefresh(oldName, newName)
+	return nil
+}
+
 // FetchArticles gets the articles from a feed.
 func (b Backend) FetchArticles(feedname string, refresh bool) tea.Cmd {
 	return func() tea.Msg {
-		feed, err := b.Rss.GetFeed(feedname)
+		feed, filtered, err := b.lookupFeed(feedname)
 		if err != nil {
 			return FetchErrorMsg{err, "Error while trying to get the article url"}
 		}
 
-		items, err := b.Cache.GetArticles(feed, refresh)
+		refresh, notice := b.allowRefresh(feedname, refresh)
+		items, err := b.Cache.GetArticles(filtered, refresh)
 		if err != nil {
 			return FetchErrorMsg{err, "Error while fetching the article"}
 		}
 
-		return b.articlesToSuccessMsg(items)
+		feeds := []*rss.Feed{feed}
+		msg := b.markAlerts(b.stripTitles(b.articlesToSuccessMsg(items), feeds), feeds)
+		if feed.OldestFirst {
+			oldestFirst(msg.Items)
+		}
+
+		msg.Notice = notice
+		if skipped := b.Cache.SkippedItems(feed.URL); skipped > 0 && notice == "" {
+			msg.Notice = fmt.Sprintf("Warning: skipped %d malformed articles of this feed", skipped)
+		}
+
+		if feed.InsecureSkipVerify && msg.Notice == "" {
+			msg.Notice = "Warning: the certificate of this feed isn't verified (insecure_skip_verify)"
+		}
+
+		msg.Description, msg.Link = b.Cache.FeedDetails(feed.URL)
+		msg.Older = b.Cache.HasOlder(feed.URL)
+		if newURL, ok := b.Cache.MovedTo(feed.URL); ok {
+			msg.Moved = &MovedFeed{feed.Name, feed.URL, newURL}
+		}
+
+		return msg
+	}
+}
+
+// LoadOlderArticles loads the next archive page of a paged feed and sends all of its articles.
+func (b Backend) LoadOlderArticles(feedname string) tea.Cmd {
+	return func() tea.Msg {
+		// A failed page shouldn't put the tab in an error state, the loaded articles are still there
+		feed, filtered, err := b.lookupFeed(feedname)
+		if err != nil {
+			return ShowErrorMsg{fmt.Sprintf("Error while trying to get the feed url: %v", err)}
+		}
+
+		items, added, err := b.Cache.LoadOlder(filtered)
+		if err != nil {
+			return ShowErrorMsg{fmt.Sprintf("Error while loading the older articles: %v", err)}
+		}
+
+		feeds := []*rss.Feed{feed}
+		msg := b.markAlerts(b.stripTitles(b.articlesToSuccessMsg(items), feeds), feeds)
+		if feed.OldestFirst {
+			oldestFirst(msg.Items)
+		}
+
+		msg.Notice = fmt.Sprintf("Loaded %d older articles", added)
+		msg.Description, msg.Link = b.Cache.FeedDetails(feed.URL)
+		msg.Older = b.Cache.HasOlder(feed.URL)
+		return msg
 	}
 }
 
 // FetchAllArticles gets all the articles from all the feeds.
 func (b Backend) FetchAllArticles(_ string, refresh bool) tea.Cmd {
 	return func() tea.Msg {
-		return b.articlesToSuccessMsg(b.Cache.GetArticlesBulk(b.Rss.GetAllFeeds(), refresh))
+		refresh, notice := b.allowRefresh(rss.AllFeedsName, refresh)
+		feeds := b.aggregatedFeeds()
+		articles, failed := b.Cache.GetArticlesBulkFailed(feeds, refresh)
+		msg := b.markAlerts(b.stripTitles(b.articlesToSuccessMsg(articles), feeds), feeds)
+		msg.Notice = notice
+		msg.Failed = failed
+		return msg
+	}
+}
+
+// FetchCombinedArticles gets the articles from the feeds in the categories of a combination.
+func (b Backend) FetchCombinedArticles(name string, refresh bool) tea.Cmd {
+	return func() tea.Msg {
+		b.feedsMu.RLock()
+		feeds, err := b.Rss.CombinationFeeds(name)
+		b.feedsMu.RUnlock()
+		if err != nil {
+			return FetchErrorMsg{err, "Error while trying to get the combined categories"}
+		}
+
+		refresh, notice := b.allowRefresh(name, refresh)
+		articles, failed := b.Cache.GetArticlesBulkFailed(feeds, refresh)
+		msg := b.markAlerts(b.stripTitles(b.articlesToSuccessMsg(articles), feeds), feeds)
+		msg.Notice = notice
+		msg.Failed = failed
+		return msg
+	}
+}
+
+// FetchTodayArticles gets the articles from all the feeds which were published today.
+func (b Backend) FetchTodayArticles(_ string, refresh bool) tea.Cmd {
+	return func() tea.Msg {
+		refresh, notice := b.allowRefresh(rss.TodayFeedsName, refresh)
+		feeds := b.aggregatedFeeds()
+		articles, failed := b.Cache.GetArticlesBulkFailed(feeds, refresh)
+		msg := b.markAlerts(b.stripTitles(b.articlesToSuccessMsg(b.filterToday(articles)), feeds), feeds)
+		msg.Notice = notice
+		msg.Failed = failed
+		return msg
+	}
+}
+
+// RefreshInBackground refreshes the expired feeds after the refresh interval and reports the feeds which
+// got new unread articles, nil is returned if the background refreshes are disabled. Outside of the
+// active hours the refresh waits for them to start.
+func (b Backend) RefreshInBackground() tea.Cmd {
+	if b.options.RefreshInterval <= 0 {
+		return nil
+	}
+
+	hours, err := ParseActiveHours(b.options.ActiveHours)
+	if err != nil {
+		log.Println("Ignoring the active hours:", err)
+	}
+
+	delay := b.options.RefreshInterval
+	if due := time.Now().Add(delay); !hours.Contains(due) {
+		delay = time.Until(hours.Next(due))
+	}
+
+	return tea.Tick(delay, func(now time.Time) tea.Msg {
+		// The computer could have been asleep past the end of the window
+		if b.Cache.OfflineMode || !hours.Contains(now) {
+			return BackgroundRefreshMsg{}
+		}
+
+		var msg BackgroundRefreshMsg
+		for _, feed := range b.aggregatedFeeds() {
+			cached := b.Cache.GetCachedArticles(feed.URL)
+			known := make(map[string]bool, len(cached))
+			for i := range cached {
+				known[cached[i].Link] = true
+			}
+
+			before := b.unreadInFeed(feed.URL)
+			articles, err := b.Cache.GetArticles(feed, false)
+			if err != nil {
+				log.Println("Background refresh of", feed.URL, "failed:", err)
+				continue
+			}
+
+			// Without the articles from before every article would look new
+			if len(cached) == 0 {
+				continue
+			}
+
+			if after := b.unreadInFeed(feed.URL); after > before {
+				msg.Updates = append(msg.Updates, FeedUpdate{Name: feed.Name, New: after - before})
+			}
+
+			words := b.alertWords(feed)
+			for i := range articles {
+				if known[articles[i].Link] {
+					continue
+				}
+
+				if keyword := cache.MatchingKeyword(&articles[i], words); keyword != "" {
+					msg.Alerts = append(msg.Alerts, Alert{Feed: feed.Name, Title: articles[i].Title, Keyword: keyword})
+				}
+			}
+		}
+
+		return msg
+	})
+}
+
+// SearchTitle returns the title of the tab with the search results for the query.
+func SearchTitle(query string) string {
+	return searchTitlePrefix + query
+}
+
+// FetchSearchResults gets the cached articles from all the feeds matching the query in the title, the
+// description of every article is prefixed with the name of its feed.
+func (b Backend) FetchSearchResults(title string, _ bool) tea.Cmd {
+	return func() tea.Msg {
+		articles, sources := b.searchArticles(strings.TrimPrefix(title, searchTitlePrefix))
+		msg := b.articlesToSuccessMsg(articles)
+		for i := range msg.Items {
+			item := msg.Items[i].(ArticleItem)
+			item.RawDesc = fmt.Sprintf("[%s] %s", sources[item.FeedURL], item.RawDesc)
+			msg.Items[i] = item
+		}
+
+		return msg
 	}
 }
 
@@ -118,6 +492,26 @@ func (b Backend) FetchDownloadedArticles(_ string, _ bool) tea.Cmd {
 	}
 }
 
+// FetchReadLaterArticles gets the read later queue.
+func (b Backend) FetchReadLaterArticles(_ string, _ bool) tea.Cmd {
+	return func() tea.Msg {
+		return b.itemsToSuccessMsg(b.Cache.GetReadLater())
+	}
+}
+
+// AddToReadLater adds an article to the read later queue.
+func (b Backend) AddToReadLater(feedName string, index int) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return FetchErrorMsg{err, "Error while getting the article"}
+		}
+
+		b.Cache.AddToReadLater(*item)
+		return nil
+	}
+}
+
 // DownloadItem downloads an article.
 func (b Backend) DownloadItem(feedName string, index int) tea.Cmd {
 	return func() tea.Msg {
@@ -131,8 +525,81 @@ func (b Backend) DownloadItem(feedName string, index int) tea.Cmd {
 	}
 }
 
+// UnreadCount returns the number of cached unread articles in a feed, the saved articles are all counted.
+func (b Backend) UnreadCount(feedName string) int {
+	switch feedName {
+	case rss.AllFeedsName:
+		count := 0
+		for _, feed := range b.aggregatedFeeds() {
+			count += b.unreadInFeed(feed.URL)
+		}
+
+		return count
+
+	case rss.TodayFeedsName:
+		count := 0
+		for _, item := range b.filterToday(b.cachedArticles()) {
+			if !b.ReadStatus.IsArticleRead(&item) {
+				count++
+			}
+		}
+
+		return count
+
+	case rss.DownloadedFeedsName:
+		return len(b.Cache.GetDownloaded())
+
+	case rss.ReadLaterFeedsName:
+		return len(b.Cache.GetReadLater())
+	}
+
+	b.feedsMu.RLock()
+	feed, err := b.Rss.GetFeed(feedName)
+	b.feedsMu.RUnlock()
+	if err != nil {
+		return b.CategoryUnreadCount(feedName)
+	}
+
+	return b.unreadInFeed(feed.URL)
+}
+
+// CategoryUnreadCount returns the number of cached unread articles in a category or a combination.
+func (b Backend) CategoryUnreadCount(catName string) int {
+	b.feedsMu.RLock()
+	feeds, err := b.Rss.GetFeeds(catName)
+	combined, combErr := b.Rss.CombinationFeeds(catName)
+	b.feedsMu.RUnlock()
+	if err == nil {
+		count := 0
+		for _, feed := range feeds {
+			count += b.unreadInFeed(feed.URL)
+		}
+
+		return count
+	}
+
+	if combErr != nil {
+		return 0
+	}
+
+	count := 0
+	for _, feed := range combined {
+		count += b.unreadInFeed(feed.URL)
+	}
+
+	return count
+}
+
 // Close closes the backend and saves its components.
 func (b Backend) Close(urlsReadOnly bool) error {
+	// Don't let a background save overwrite the files after they are saved here
+	b.saves.mu.Lock()
+	defer b.saves.mu.Unlock()
+
+	if removed := b.PruneDownloaded(); removed > 0 {
+		log.Println("Removed", removed, "downloaded articles past their retention")
+	}
+
 	if !urlsReadOnly {
 		if err := b.Rss.Save(); err != nil {
 			return fmt.Errorf("backend.Close: %w", err)
@@ -146,12 +613,118 @@ func (b Backend) Close(urlsReadOnly bool) error {
 		return fmt.Errorf("backend.Close: %w", err)
 	}
 
+	b.saves.closed = true
 	return nil
 }
 
-// articlesToSuccessMsg converts a list of items to a FetchArticleSuccessMsg.
+// SaveState saves the urls file, the cache file and the read status in the background. The state is
+// encoded right away so that it doesn't change while being written, the files are replaced atomically.
+func (b Backend) SaveState() tea.Cmd {
+	writers := make([]func() error, 0, 3)
+	var markSaved func()
+	if !b.URLsReadOnly {
+		writeRss, marker, err := b.Rss.Snapshot()
+		if err != nil {
+			return func() tea.Msg { return SaveStateMsg{Err: fmt.Errorf("backend.SaveState: %w", err)} }
+		}
+
+		writers = append(writers, writeRss)
+		markSaved = marker
+	}
+
+	writeCache, err := b.Cache.Snapshot()
+	if err != nil {
+		return func() tea.Msg { return SaveStateMsg{Err: fmt.Errorf("backend.SaveState: %w", err)} }
+	}
+
+	writers = append(writers, writeCache, b.ReadStatus.Snapshot())
+	return func() tea.Msg {
+		b.saves.mu.Lock()
+		defer b.saves.mu.Unlock()
+		if b.saves.closed {
+			return SaveStateMsg{}
+		}
+
+		for _, write := range writers {
+			if err := write(); err != nil {
+				return SaveStateMsg{Err: fmt.Errorf("backend.SaveState: %w", err)}
+			}
+		}
+
+		return SaveStateMsg{saved: markSaved}
+	}
+}
+
+// PruneDownloaded removes the downloaded articles which are past the retention set in the options, it
+// returns the number of removed articles.
+func (b Backend) PruneDownloaded() int {
+	return b.Cache.PruneDownloaded(cache.RetentionPolicy{
+		MaxCount: b.options.DownloadedMaxCount,
+		MaxAge:   b.options.DownloadedMaxAge,
+	})
+}
+
+// stripTitles removes the parts of the titles in the article list which their feeds strip, like the name
+// of the site at the end of every title. The article view keeps the original title.
+func (b Backend) stripTitles(msg FetchArticleSuccessMsg, feeds []*rss.Feed) FetchArticleSuccessMsg {
+	patterns := make(map[string]*regexp.Regexp)
+	for _, feed := range feeds {
+		if feed.TitleStrip == "" {
+			continue
+		}
+
+		pattern, err := regexp.Compile(feed.TitleStrip)
+		if err != nil {
+			log.Println("Not stripping the titles of", feed.Name, err)
+			continue
+		}
+
+		for _, article := range b.Cache.GetCachedArticles(feed.URL) {
+			patterns[article.Link] = pattern
+		}
+	}
+
+	if len(patterns) == 0 {
+		return msg
+	}
+
+	for i := range msg.Items {
+		item, ok := msg.Items[i].(ArticleItem)
+		if !ok {
+			continue
+		}
+
+		pattern, ok := patterns[item.FeedURL]
+		if !ok {
+			continue
+		}
+
+		// The marks aren't a part of the title
+		mark, title := "", item.ArtTitle
+		for _, prefix := range []string{downloadedMark, readMark} {
+			if strings.HasPrefix(title, prefix) {
+				mark, title = prefix, strings.TrimPrefix(title, prefix)
+				break
+			}
+		}
+
+		if stripped := strings.TrimSpace(pattern.ReplaceAllString(title, "")); stripped != "" {
+			item.ArtTitle = mark + stripped
+			msg.Items[i] = item
+		}
+	}
+
+	return msg
+}
+
+// articlesToSuccessMsg sorts a list of items and converts it to a FetchArticleSuccessMsg.
 func (b Backend) articlesToSuccessMsg(items cache.SortableArticles) FetchArticleSuccessMsg {
 	sort.Sort(items)
+	return b.itemsToSuccessMsg(items)
+}
+
+// itemsToSuccessMsg converts a list of items to a FetchArticleSuccessMsg, keeping their order.
+func (b Backend) itemsToSuccessMsg(items cache.SortableArticles) FetchArticleSuccessMsg {
 	result := make([]list.Item, len(items))
 
 	savedArticles := b.Cache.GetDownloaded()
@@ -171,20 +744,238 @@ func (b Backend) articlesToSuccessMsg(items cache.SortableArticles) FetchArticle
 		}
 
 		if alreadySaved {
-			item.Title = "↓ " + item.Title
-		} else if b.ReadStatus.IsRead(item.Link) {
-			item.Title = "✓ " + item.Title
+			item.Title = downloadedMark + item.Title
+		} else if b.ReadStatus.IsArticleRead(&item) {
+			item.Title = readMark + item.Title
+		}
+
+		var published time.Time
+		if item.PublishedParsed != nil {
+			published = *item.PublishedParsed
 		}
 
+		position, _ := b.Cache.Position(cache.ArticleID(&item))
+		words, chars := rss.TextStats(&items[i])
 		result[i] = ArticleItem{
 			ArtTitle:        item.Title,
 			RawDesc:         betterDesc(item.Description),
 			MarkdownContent: rss.YassifyItem(&items[i]),
+			PlainContent:    rss.PlainItem(&items[i]),
+			RawContent:      rss.RawItem(&items[i]),
 			FeedURL:         item.Link,
+			Thumbnail:       rss.LeadImage(&items[i]),
+			Author:          rss.ItemAuthor(&items[i]),
+			Words:           words,
+			Chars:           chars,
+			Index:           i,
+			GUID:            cache.ArticleID(&item),
+			Position:        position,
+			Published:       published,
 		}
 	}
 
-	return FetchArticleSuccessMsg{result}
+	return FetchArticleSuccessMsg{Items: result}
+}
+
+// alertWords returns the alert words of a feed along with the ones for every feed
+func (b Backend) alertWords(feed *rss.Feed) []string {
+	return append(append([]string{}, b.options.AlertWords...), feed.AlertWords...)
+}
+
+// markAlerts marks the articles which contain one of the alert words of their feed
+func (b Backend) markAlerts(msg FetchArticleSuccessMsg, feeds []*rss.Feed) FetchArticleSuccessMsg {
+	alerted := make(map[string]bool)
+	for _, feed := range feeds {
+		words := b.alertWords(feed)
+		if len(words) == 0 {
+			continue
+		}
+
+		for _, article := range b.Cache.GetCachedArticles(feed.URL) {
+			if cache.MatchingKeyword(&article, words) != "" {
+				alerted[article.Link] = true
+			}
+		}
+	}
+
+	for i := range msg.Items {
+		if item, ok := msg.Items[i].(ArticleItem); ok && alerted[item.FeedURL] {
+			item.Alert = true
+			msg.Items[i] = item
+		}
+	}
+
+	return msg
+}
+
+// oldestFirst reverses the articles sorted from the newest one, the undated ones stay at the end. The
+// articles keep their index in the sorted list.
+func oldestFirst(items []list.Item) {
+	dated := len(items)
+	for dated > 0 && items[dated-1].(ArticleItem).Published.IsZero() {
+		dated--
+	}
+
+	for i, j := 0, dated-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+}
+
+// sortedIndices returns the indices of the named items in the order set by the options, the sort is
+// stable so that the items which are equal keep the order from the urls file.
+func (b Backend) sortedIndices(names []string, unread func(i int) int) []int {
+	indices := make([]int, len(names))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	switch b.options.SortOrder {
+	case SortAlphabetical:
+		sort.SliceStable(indices, func(i, j int) bool {
+			return strings.ToLower(names[indices[i]]) < strings.ToLower(names[indices[j]])
+		})
+
+	case SortUnread:
+		counts := make([]int, len(names))
+		for i := range names {
+			counts[i] = unread(i)
+		}
+
+		sort.SliceStable(indices, func(i, j int) bool {
+			return counts[indices[i]] > counts[indices[j]]
+		})
+	}
+
+	return indices
+}
+
+// todayArticles returns the articles from all the feeds which were published today.
+func (b Backend) todayArticles(refresh bool) cache.SortableArticles {
+	return b.filterToday(b.Cache.GetArticlesBulk(b.aggregatedFeeds(), refresh))
+}
+
+// filterToday leaves only the articles published inside the today window.
+func (b Backend) filterToday(articles cache.SortableArticles) cache.SortableArticles {
+	now := time.Now()
+	since := now.Add(-24 * time.Hour)
+	if b.options.TodayWindow == WindowToday {
+		year, month, day := now.Date()
+		since = time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	}
+
+	result := make(cache.SortableArticles, 0)
+	for _, item := range articles {
+		if item.PublishedParsed != nil && item.PublishedParsed.After(since) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// searchArticles returns the articles matching the query and a map of article links to feed names.
+func (b Backend) searchArticles(query string) (cache.SortableArticles, map[string]string) {
+	b.feedsMu.RLock()
+	feeds := b.Rss.GetAllFeeds()
+	b.feedsMu.RUnlock()
+	names := make(map[string]string, len(feeds))
+	for _, feed := range feeds {
+		names[feed.URL] = feed.Name
+	}
+
+	var articles cache.SortableArticles
+	sources := make(map[string]string)
+	for url, items := range b.Cache.Search(feeds, query) {
+		for _, item := range items {
+			sources[item.Link] = names[url]
+		}
+
+		articles = append(articles, items...)
+	}
+
+	return articles, sources
+}
+
+// allowRefresh checks if a manual refresh of the tab is outside of the refresh cooldown and records it.
+// If it isn't, the cached articles should be used and the notice explains why.
+func (b Backend) allowRefresh(name string, refresh bool) (bool, string) {
+	if !refresh || b.options.RefreshCooldown <= 0 {
+		return refresh, ""
+	}
+
+	b.refreshMu.Lock()
+	defer b.refreshMu.Unlock()
+
+	if last, ok := b.lastRefresh[name]; ok && time.Since(last) < b.options.RefreshCooldown {
+		wait := (b.options.RefreshCooldown - time.Since(last)).Round(time.Second)
+		log.Println("Ignoring the refresh of", name, "because of the cooldown")
+		return false, fmt.Sprintf("%s was refreshed recently, try again in %s", name, wait)
+	}
+
+	b.lastRefresh[name] = time.Now()
+	return true, ""
+}
+
+// renameRefresh moves the time of the last manual refresh of a feed to its new name
+func (b Backend) renameRefresh(oldName, newName string) {
+	b.refreshMu.Lock()
+	defer b.refreshMu.Unlock()
+
+	if last, ok := b.lastRefresh[oldName]; ok && oldName != newName {
+		delete(b.lastRefresh, oldName)
+		b.lastRefresh[newName] = last
+	}
+}
+
+// aggregatedFeeds returns the feeds shown in the virtual categories, which are the ones that aren't muted,
+// with the keyword filters of their categories.
+func (b Backend) aggregatedFeeds() []*rss.Feed {
+	b.feedsMu.RLock()
+	defer b.feedsMu.RUnlock()
+
+	var result []*rss.Feed
+	for _, feed := range b.Rss.GetAllFeeds() {
+		if !feed.Muted {
+			result = append(result, b.Rss.WithCategoryFilters(feed))
+		}
+	}
+
+	return result
+}
+
+// lookupFeed returns the feed with the name as it is in the urls file and with the keyword filters of
+// its category merged in
+func (b Backend) lookupFeed(name string) (feed, filtered *rss.Feed, err error) {
+	b.feedsMu.RLock()
+	defer b.feedsMu.RUnlock()
+
+	if feed, err = b.Rss.GetFeed(name); err != nil {
+		return nil, nil, err
+	}
+
+	return feed, b.Rss.WithCategoryFilters(feed), nil
+}
+
+// cachedArticles returns the cached articles from the aggregated feeds without fetching them.
+func (b Backend) cachedArticles() cache.SortableArticles {
+	var result cache.SortableArticles
+	for _, feed := range b.aggregatedFeeds() {
+		result = append(result, b.Cache.GetCachedArticles(feed.URL)...)
+	}
+
+	return result
+}
+
+// unreadInFeed counts the unread articles of a feed without fetching it.
+func (b Backend) unreadInFeed(url string) int {
+	count := 0
+	for _, item := range b.Cache.GetCachedArticles(url) {
+		if !b.ReadStatus.IsArticleRead(&item) {
+			count++
+		}
+	}
+
+	return count
 }
 
 // indexToItem resolves an index to an item.
@@ -193,15 +984,40 @@ func (b Backend) indexToItem(feedName string, index int) (*gofeed.Item, error) {
 
 	switch feedName {
 	case rss.AllFeedsName:
-		articles = b.Cache.GetArticlesBulk(b.Rss.GetAllFeeds(), false)
+		articles = b.Cache.GetArticlesBulk(b.aggregatedFeeds(), false)
+
+	case rss.TodayFeedsName:
+		articles = b.todayArticles(false)
+
+	case rss.ReadLaterFeedsName:
+		// The queue isn't sorted by date
+		queue := b.Cache.GetReadLater()
+		if index < 0 || index >= len(queue) {
+			return nil, errors.New("index out of range")
+		}
+
+		return &queue[index], nil
 
 	case rss.DownloadedFeedsName:
 		articles = b.Cache.GetDownloaded()
 
 	default:
-		feed, err := b.Rss.GetFeed(feedName)
+		if strings.HasPrefix(feedName, searchTitlePrefix) {
+			articles, _ = b.searchArticles(strings.TrimPrefix(feedName, searchTitlePrefix))
+			break
+		}
+
+		_, feed, err := b.lookupFeed(feedName)
 		if err != nil {
-			return nil, errors.New("getting the article url")
+			b.feedsMu.RLock()
+			combined, combErr := b.Rss.CombinationFeeds(feedName)
+			b.feedsMu.RUnlock()
+			if combErr != nil {
+				return nil, errors.New("getting the article url")
+			}
+
+			articles = b.Cache.GetArticlesBulk(combined, false)
+			break
 		}
 
 		articles, err = b.Cache.GetArticles(feed, false)
@@ -1,11 +1,19 @@
 package backend
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/TypicalAM/goread/internal/backend/cache"
 	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
 )
 
 const TestOfflineEnv = "TEST_OFFLINE_ONLY"
@@ -29,7 +37,7 @@ func testOffline() bool {
 
 // getBackend creates a fake backend
 func getBackend() (*Backend, error) {
-	b, err := New("../test/data/urls.yml", "", false)
+	b, err := New("", "../test/data/urls.yml", "", false)
 	if err != nil {
 		return nil, err
 	}
@@ -157,3 +165,714 @@ func TestBackendGetArticles(t *testing.T) {
 		t.Errorf("expected FetchErrorMessage, got %T", msg)
 	}
 }
+
+// TestBackendPrettyPrint if we get an error then the raw feeds aren't indented correctly
+func TestBackendPrettyPrint(t *testing.T) {
+	xmlFeed := `<?xml version="1.0"?><rss><channel><atom:link href="a&amp;b"/><title>Test</title></channel></rss>`
+	expected := `<?xml version="1.0"?>
+<rss>
+  <channel>
+    <atom:link href="a&amp;b">
+    </atom:link>
+    <title>
+      Test
+    </title>
+  </channel>
+</rss>
+`
+	if result := prettyPrint([]byte(xmlFeed)); result != expected {
+		t.Errorf("incorrect xml output, expected %q, got %q", expected, result)
+	}
+
+	jsonFeed := `{"title":"Test","items":[]}`
+	expected = "{\n  \"title\": \"Test\",\n  \"items\": []\n}"
+	if result := prettyPrint([]byte(jsonFeed)); result != expected {
+		t.Errorf("incorrect json output, expected %q, got %q", expected, result)
+	}
+
+	plain := "not a feed"
+	if result := prettyPrint([]byte(plain)); result != plain {
+		t.Errorf("expected unknown content to be left as is, got %q", result)
+	}
+}
+
+// TestBackendSortOrder if we get an error then the feeds aren't sorted correctly
+func TestBackendSortOrder(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the urls from the file")
+	}
+
+	expected := map[SortOrder][]string{
+		SortManual:       {"Chris titus - virtualization", "Ars Technica"},
+		SortAlphabetical: {"Ars Technica", "Chris titus - virtualization"},
+	}
+
+	for order, names := range expected {
+		b.options.SortOrder = order
+		msg, ok := b.FetchFeeds("Technology")().(FetchSuccessMsg)
+		if !ok {
+			t.Fatalf("expected FetchSuccessMessage for sort order %s", order)
+		}
+
+		for i, name := range names {
+			if msg.Items[i].FilterValue() != name {
+				t.Errorf("incorrect order for %s, expected %s at %d, got %s", order, name, i, msg.Items[i].FilterValue())
+			}
+		}
+	}
+}
+
+// TestBackendFilterToday if we get an error then the today window isn't respected
+func TestBackendFilterToday(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the urls from the file")
+	}
+
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	justNow := now.Add(-time.Minute)
+	beforeMidnight := midnight.Add(-time.Minute)
+	lastWeek := now.Add(-7 * 24 * time.Hour)
+	articles := cache.SortableArticles{
+		{Title: "Just now", PublishedParsed: &justNow},
+		{Title: "Before midnight", PublishedParsed: &beforeMidnight},
+		{Title: "Last week", PublishedParsed: &lastWeek},
+		{Title: "No date"},
+	}
+
+	b.options.TodayWindow = WindowToday
+	if filtered := b.filterToday(articles); len(filtered) != 1 || filtered[0].Title != "Just now" {
+		t.Errorf("expected only the article published after midnight, got %d articles", len(filtered))
+	}
+
+	b.options.TodayWindow = WindowDay
+	if filtered := b.filterToday(articles); len(filtered) != 2 {
+		t.Errorf("expected 2 articles published in the last 24 hours, got %d", len(filtered))
+	}
+}
+
+// TestBackendStripTitles if we get an error then the list titles aren't stripped or the article view
+// loses the original title
+func TestBackendStripTitles(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the urls from the file")
+	}
+
+	feed := &rss.Feed{Name: "Site", URL: "https://example.com/feed", TitleStrip: ` [-|] Example Site$`}
+	other := &rss.Feed{Name: "Other", URL: "https://other.com/feed"}
+	articles := cache.SortableArticles{
+		{Title: "First article - Example Site", Link: "https://example.com/1"},
+		{Title: "Second article | Example Site", Link: "https://example.com/2"},
+		{Title: " - Example Site", Link: "https://example.com/3"},
+		{Title: "Unrelated - Example Site", Link: "https://other.com/1"},
+	}
+
+	expire := time.Now().Add(time.Hour)
+	b.Cache.Content[feed.URL] = cache.Entry{Expire: expire, Articles: articles[:3]}
+	b.Cache.Content[other.URL] = cache.Entry{Expire: expire, Articles: articles[3:]}
+	b.ReadStatus.MarkAsRead("https://example.com/2")
+
+	msg := b.stripTitles(b.itemsToSuccessMsg(articles), []*rss.Feed{feed, other})
+	expected := []string{"First article", readMark + "Second article", " - Example Site", "Unrelated - Example Site"}
+	for i, title := range expected {
+		item := msg.Items[i].(ArticleItem)
+		if item.ArtTitle != title {
+			t.Errorf("expected the list title %q, got %q", title, item.ArtTitle)
+		}
+	}
+
+	if item := msg.Items[0].(ArticleItem); !strings.Contains(item.MarkdownContent, "# First article - Example Site") {
+		t.Errorf("expected the article to keep the original title, got %q", item.MarkdownContent)
+	}
+}
+
+// TestBackendMutedFeeds if we get an error then muted feeds are aggregated or hidden from their category
+func TestBackendMutedFeeds(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the urls from the file")
+	}
+
+	muted, err := b.Rss.ToggleMute("Ars Technica")
+	if err != nil || !muted {
+		t.Fatalf("expected the feed to be muted, got %v", err)
+	}
+
+	for _, feed := range b.aggregatedFeeds() {
+		if feed.Name == "Ars Technica" {
+			t.Error("expected the muted feed to be left out of the aggregated feeds")
+		}
+	}
+
+	if len(b.aggregatedFeeds()) != len(b.Rss.GetAllFeeds())-1 {
+		t.Errorf("expected %d aggregated feeds, got %d", len(b.Rss.GetAllFeeds())-1, len(b.aggregatedFeeds()))
+	}
+
+	msg, ok := b.FetchFeeds("Technology")().(FetchSuccessMsg)
+	if !ok || len(msg.Items) != 2 {
+		t.Fatal("expected the muted feed to still be in its category")
+	}
+
+	if muted, err = b.Rss.ToggleMute("Ars Technica"); err != nil || muted {
+		t.Fatalf("expected the feed to be unmuted, got %v", err)
+	}
+
+	if _, err = b.Rss.ToggleMute("Non-existent"); err != rss.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestBackendRefreshCooldown if we get an error then manual refreshes inside the cooldown aren't ignored
+func TestBackendRefreshCooldown(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the urls from the file")
+	}
+
+	b.options.RefreshCooldown = time.Minute
+	if refresh, notice := b.allowRefresh("Ars Technica", true); !refresh || notice != "" {
+		t.Fatal("expected the first refresh to be allowed")
+	}
+
+	if refresh, notice := b.allowRefresh("Ars Technica", true); refresh || notice == "" {
+		t.Fatal("expected the second refresh to be ignored with a notice")
+	}
+
+	if refresh, _ := b.allowRefresh("Primordial soup", true); !refresh {
+		t.Fatal("expected the cooldown to be tracked per feed")
+	}
+
+	if refresh, notice := b.allowRefresh("Ars Technica", false); refresh || notice != "" {
+		t.Fatal("expected loading from the cache not to be affected")
+	}
+
+	b.options.RefreshCooldown = 0
+	if refresh, _ := b.allowRefresh("Ars Technica", true); !refresh {
+		t.Fatal("expected the cooldown to be disabled")
+	}
+}
+
+// TestBackendProfiles if we get an error then the profiles don't use separate urls files and caches
+func TestBackendProfiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+
+	if urlPath, cacheDir, err := ProfilePaths(DefaultProfile); err != nil || urlPath != "" || cacheDir != "" {
+		t.Fatalf("expected the default profile to use the default paths, got %q, %q, %v", urlPath, cacheDir, err)
+	}
+
+	for _, name := range []string{"", "../escape", "with space"} {
+		if err := ValidateProfileName(name); err == nil {
+			t.Errorf("expected the profile name %q to be rejected", name)
+		}
+	}
+
+	b, err := New("work", "", "", false)
+	if err != nil {
+		t.Fatalf("couldn't create the backend for the profile: %v", err)
+	}
+
+	if b.Profile != "work" {
+		t.Errorf("expected the backend to use the work profile, got %q", b.Profile)
+	}
+
+	if err = b.Rss.AddCategory("Work", "Work feeds"); err != nil {
+		t.Fatalf("couldn't add a category: %v", err)
+	}
+
+	if err = b.Close(false); err != nil {
+		t.Fatalf("couldn't close the backend: %v", err)
+	}
+
+	urlPath, cacheDir, err := ProfilePaths("work")
+	if err != nil {
+		t.Fatalf("couldn't get the profile paths: %v", err)
+	}
+
+	if !strings.HasPrefix(urlPath, dir) || !strings.HasPrefix(cacheDir, dir) {
+		t.Fatalf("expected the profile paths to be in %s, got %q and %q", dir, urlPath, cacheDir)
+	}
+
+	if _, err = os.Stat(urlPath); err != nil {
+		t.Errorf("expected the urls file of the profile to be saved: %v", err)
+	}
+
+	if _, err = os.Stat(filepath.Join(cacheDir, "cache.json")); err != nil {
+		t.Errorf("expected the cache of the profile to be saved: %v", err)
+	}
+
+	profiles, err := Profiles()
+	if err != nil {
+		t.Fatalf("couldn't list the profiles: %v", err)
+	}
+
+	if len(profiles) != 2 || profiles[0] != DefaultProfile || profiles[1] != "work" {
+		t.Errorf("expected the default and the work profiles, got %v", profiles)
+	}
+
+	reopened, err := New("work", "", "", false)
+	if err != nil {
+		t.Fatalf("couldn't reopen the profile: %v", err)
+	}
+
+	if _, err = reopened.Rss.GetFeeds("Work"); err != nil {
+		t.Errorf("expected the category to be kept in the profile: %v", err)
+	}
+}
+
+// TestBackendSortedFeedNames if we get an error then the feeds of a category aren't listed in the sort order
+func TestBackendSortedFeedNames(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the urls from the file")
+	}
+
+	names, err := b.SortedFeedNames("Technology")
+	if err != nil {
+		t.Fatalf("couldn't get the feed names: %v", err)
+	}
+
+	if len(names) != 2 || names[0] != "Chris titus - virtualization" || names[1] != "Ars Technica" {
+		t.Errorf("expected the feeds in the manual order, got %v", names)
+	}
+
+	b.options.SortOrder = SortAlphabetical
+	if names, _ = b.SortedFeedNames("Technology"); len(names) != 2 || names[0] != "Ars Technica" {
+		t.Errorf("expected the feeds in the alphabetical order, got %v", names)
+	}
+
+	if _, err = b.SortedFeedNames("Non-existent"); err == nil {
+		t.Error("expected an error for a non-existent category")
+	}
+}
+
+// TestBackendRemoveFeed if we get an error then removing a feed doesn't drop it along with its cache
+func TestBackendRemoveFeed(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the urls from the file")
+	}
+
+	feed, err := b.Rss.GetFeed("Ars Technica")
+	if err != nil {
+		t.Fatalf("couldn't get the feed: %v", err)
+	}
+
+	url := feed.URL
+	b.Cache.Content[url] = cache.Entry{Expire: time.Now().Add(time.Hour)}
+	if err = b.RemoveFeed("Technology", "Ars Technica"); err != nil {
+		t.Fatalf("couldn't remove the feed: %v", err)
+	}
+
+	if _, err = b.Rss.GetFeed("Ars Technica"); err != rss.ErrNotFound {
+		t.Errorf("expected the feed to be removed, got %v", err)
+	}
+
+	if _, ok := b.Cache.Content[url]; ok {
+		t.Error("expected the cache entry of the feed to be removed")
+	}
+
+	if err = b.RemoveFeed("Technology", "Ars Technica"); err == nil {
+		t.Error("expected an error when removing the feed twice")
+	}
+}
+
+// TestBackendRenameFeed if we get an error then the refresh cooldown of a renamed feed stays with its
+// old name
+func TestBackendRenameFeed(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the urls from the file")
+	}
+
+	b.options.RefreshCooldown = time.Hour
+	if refresh, _ := b.allowRefresh("Ars Technica", true); !refresh {
+		t.Fatal("expected the first refresh to be allowed")
+	}
+
+	if err = b.RenameFeed("Technology", "Ars Technica", "Ars"); err != nil {
+		t.Fatalf("couldn't rename the feed: %v", err)
+	}
+
+	if refresh, _ := b.allowRefresh("Ars", true); refresh {
+		t.Error("expected the cooldown to move to the new name")
+	}
+
+	if err = b.RenameFeed("Technology", "Ars Technica", "Ars"); err == nil {
+		t.Error("expected an error when renaming a missing feed")
+	}
+}
+
+// TestBackendPinnedCategories if we get an error then the pinned categories aren't listed first
+func TestBackendPinnedCategories(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the urls from the file")
+	}
+
+	b.options.SortOrder = SortAlphabetical
+	if pinned, err := b.Rss.TogglePin("Technology"); err != nil || !pinned {
+		t.Fatalf("couldn't pin the category: %v", err)
+	}
+
+	msg, ok := b.FetchCategories("")().(FetchSuccessMsg)
+	if !ok {
+		t.Fatal("expected FetchSuccessMsg")
+	}
+
+	if msg.Items[0].FilterValue() != "Technology" {
+		t.Errorf("expected the pinned category to come first, got %s", msg.Items[0].FilterValue())
+	}
+
+	if pinned, _ := b.Rss.TogglePin("Technology"); pinned {
+		t.Error("expected the category to be unpinned")
+	}
+
+	if msg, _ = b.FetchCategories("")().(FetchSuccessMsg); msg.Items[0].FilterValue() != "News" {
+		t.Errorf("expected the alphabetical order after unpinning, got %s", msg.Items[0].FilterValue())
+	}
+
+	if _, err = b.Rss.TogglePin("Non-existent"); err != rss.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestBackendParseCutoff if we get an error the dates and ages for marking the old articles aren't parsed
+func TestBackendParseCutoff(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"2024-01-31": time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		"30d":        now.Add(-30 * 24 * time.Hour),
+		" 2w ":       now.Add(-14 * 24 * time.Hour),
+		"36h":        now.Add(-36 * time.Hour),
+	}
+
+	for value, expected := range cases {
+		cutoff, err := ParseCutoff(value, now)
+		if err != nil || !cutoff.Equal(expected) {
+			t.Errorf("incorrect cutoff for %q, expected %s, got %s (%v)", value, expected, cutoff, err)
+		}
+	}
+
+	for _, value := range []string{"", "d", "soon", "-3d", "-1h"} {
+		if _, err := ParseCutoff(value, now); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+// TestBackendEnclosureName if we get an error the downloaded enclosures get unusable file names
+func TestBackendEnclosureName(t *testing.T) {
+	cases := []struct {
+		title     string
+		enclosure gofeed.Enclosure
+		expected  string
+	}{
+		{"Episode 12: Pipes/Filters", gofeed.Enclosure{URL: "https://cdn.example.com/ep12.mp3?token=abc", Type: "audio/mpeg"}, "Episode 12_ Pipes_Filters.mp3"},
+		{"No extension", gofeed.Enclosure{URL: "https://cdn.example.com/download/42", Type: "application/pdf"}, "No extension.pdf"},
+		{"  ", gofeed.Enclosure{URL: "https://cdn.example.com/a.m4a", Type: "audio/mp4"}, "episode.m4a"},
+	}
+
+	for _, c := range cases {
+		item := &gofeed.Item{Title: c.title, Enclosures: []*gofeed.Enclosure{&c.enclosure}}
+		if name := enclosureName(item, mediaEnclosure(item)); name != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, name)
+		}
+	}
+
+	image := &gofeed.Item{Enclosures: []*gofeed.Enclosure{{URL: "https://example.com/cover.jpg", Type: "image/jpeg"}}}
+	if enclosure := mediaEnclosure(image); enclosure != nil {
+		t.Errorf("expected the image not to be a media enclosure, got %v", enclosure)
+	}
+}
+
+// TestBackendOldestFirst if we get an error then the feeds listed oldest first are in the wrong order or
+// the undated articles move to the top
+func TestBackendOldestFirst(t *testing.T) {
+	day := time.Date(2023, time.March, 3, 12, 0, 0, 0, time.UTC)
+	older, newer := day.Add(-24*time.Hour), day
+	articles := cache.SortableArticles{
+		{Title: "Undated"},
+		{Title: "Older", PublishedParsed: &older},
+		{Title: "Newer", PublishedParsed: &newer},
+	}
+
+	b := Backend{Cache: &cache.Cache{}, ReadStatus: &cache.ReadStatus{}}
+	msg := b.articlesToSuccessMsg(articles)
+	oldestFirst(msg.Items)
+
+	var titles []string
+	for _, item := range msg.Items {
+		titles = append(titles, item.(ArticleItem).ArtTitle)
+	}
+
+	if strings.Join(titles, ",") != "Older,Newer,Undated" {
+		t.Fatalf("expected the oldest article first and the undated one last, got %v", titles)
+	}
+
+	if index := msg.Items[0].(ArticleItem).Index; index != 1 {
+		t.Errorf("expected the articles to keep their index in the newest first order, got %d", index)
+	}
+}
+
+// TestBackendMarkAlerts if we get an error then the articles with an alert word aren't marked
+func TestBackendMarkAlerts(t *testing.T) {
+	store, err := cache.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles := cache.SortableArticles{
+		{Title: "Go 1.21 is released", Link: "https://example.com/go"},
+		{Title: "Rust 1.70 is released", Link: "https://example.com/rust"},
+	}
+	store.Content["https://example.com/feed"] = cache.Entry{Expire: time.Now().Add(time.Hour), Articles: articles}
+
+	b := Backend{Cache: store, ReadStatus: &cache.ReadStatus{}, options: Options{AlertWords: []string{"rust"}}}
+	feeds := []*rss.Feed{{Name: "News", URL: "https://example.com/feed", AlertWords: []string{"GO 1.21"}}}
+	msg := b.markAlerts(b.itemsToSuccessMsg(articles), feeds)
+	for _, item := range msg.Items {
+		if article := item.(ArticleItem); !article.Alert || !strings.HasSuffix(article.Title(), AlertMark) {
+			t.Errorf("expected %s to be marked, got %q", article.ArtTitle, article.Title())
+		}
+	}
+
+	msg = b.markAlerts(b.itemsToSuccessMsg(articles), []*rss.Feed{{Name: "News", URL: "https://example.com/feed"}})
+	if msg.Items[0].(ArticleItem).Alert || !msg.Items[1].(ArticleItem).Alert {
+		t.Error("expected only the global alert words to be used for a feed without its own")
+	}
+}
+
+// TestBackendImageMark if we get an error then the articles with a lead image aren't marked in the list
+func TestBackendImageMark(t *testing.T) {
+	articles := cache.SortableArticles{
+		{Title: "Photos", Link: "https://example.com/photos", Image: &gofeed.Image{URL: "https://example.com/a.png"}},
+		{Title: "Text", Link: "https://example.com/text"},
+	}
+
+	b := Backend{Cache: &cache.Cache{}, ReadStatus: &cache.ReadStatus{}}
+	msg := b.itemsToSuccessMsg(articles)
+	photos, text := msg.Items[0].(ArticleItem), msg.Items[1].(ArticleItem)
+	if photos.Thumbnail != "https://example.com/a.png" || photos.Title() != "Photos "+imageMark {
+		t.Errorf("expected the article with an image to be marked, got %q", photos.Title())
+	}
+
+	if text.Title() != "Text" {
+		t.Errorf("expected the article without an image not to be marked, got %q", text.Title())
+	}
+
+	photos.Alert = true
+	if photos.Title() != "Photos "+imageMark+" "+AlertMark {
+		t.Errorf("expected the alert mark to go last, got %q", photos.Title())
+	}
+}
+
+// TestBackendActiveHours if we get an error then the background refreshes run outside of the active
+// hours or wait for the wrong time
+func TestBackendActiveHours(t *testing.T) {
+	for _, value := range []string{"7-23", "07:00", "07:00-07:00", "25:00-07:00", "07:60-08:00"} {
+		if _, err := ParseActiveHours(value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+
+	day := func(hour, minute int) time.Time {
+		return time.Date(2023, time.March, 3, hour, minute, 0, 0, time.Local)
+	}
+
+	hours, err := ParseActiveHours("07:00-23:00")
+	if err != nil {
+		t.Fatalf("couldn't parse the active hours %v", err)
+	}
+
+	if !hours.Contains(day(7, 0)) || !hours.Contains(day(22, 59)) || hours.Contains(day(23, 0)) {
+		t.Errorf("expected the window to include its start and exclude its end")
+	}
+
+	if next := hours.Next(day(23, 30)); !next.Equal(time.Date(2023, time.March, 4, 7, 0, 0, 0, time.Local)) {
+		t.Errorf("expected the refresh to wait until the next morning, got %s", next)
+	}
+
+	if next := hours.Next(day(3, 0)); !next.Equal(day(7, 0)) {
+		t.Errorf("expected the refresh to wait until the morning, got %s", next)
+	}
+
+	overnight, err := ParseActiveHours("22:00-02:00")
+	if err != nil {
+		t.Fatalf("couldn't parse the active hours %v", err)
+	}
+
+	if !overnight.Contains(day(1, 0)) || overnight.Contains(day(12, 0)) {
+		t.Errorf("expected the window to go past midnight")
+	}
+
+	if whole, _ := ParseActiveHours(""); !whole.Contains(day(3, 0)) || !whole.Next(day(3, 0)).Equal(day(3, 0)) {
+		t.Errorf("expected no active hours to be the whole day")
+	}
+}
+
+// TestBackendCapabilities if we get an error then a backend with a read-only urls file claims the feeds
+// can be edited
+func TestBackendCapabilities(t *testing.T) {
+	b, err := getBackend()
+	if err != nil {
+		t.Fatalf("couldn't get the backend: %v", err)
+	}
+
+	if !b.Capabilities().EditFeeds {
+		t.Error("expected the feeds to be editable")
+	}
+
+	b.URLsReadOnly = true
+	if b.Capabilities().EditFeeds {
+		t.Error("expected the feeds of a read-only urls file not to be editable")
+	}
+}
+
+// TestBackendRefreshWhileReading if we get an error (with -race) the background refresh touches the cache
+// and the read status while the interface marks the articles as read
+func TestBackendRefreshWhileReading(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+			`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	urls := "categories:\n  - name: News\n    desc: News\n    subscriptions:\n" +
+		"      - name: Test\n        desc: Test\n        url: " + server.URL + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "urls.yml"), []byte(urls), 0600); err != nil {
+		t.Fatalf("couldn't write the urls file: %v", err)
+	}
+
+	b, err := New("", filepath.Join(dir, "urls.yml"), filepath.Join(dir, "cache"), true)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.options.RefreshInterval = time.Millisecond
+	if _, err = b.Cache.GetArticles(&b.Rss.Categories[0].Subscriptions[0], true); err != nil {
+		t.Fatalf("couldn't fetch the feed: %v", err)
+	}
+
+	// The expired feed is fetched again by the refresh
+	entry := b.Cache.Content[server.URL]
+	entry.Expire = time.Now().Add(-time.Minute)
+	b.Cache.Content[server.URL] = entry
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.RefreshInBackground()()
+	}()
+
+	for i := 0; ; i++ {
+		select {
+		case <-done:
+			return
+		default:
+			b.ReadStatus.MarkAsRead(fmt.Sprint(i))
+			b.Cache.SavePosition(fmt.Sprint(i), i+1)
+			b.unreadInFeed(server.URL)
+		}
+	}
+}
+
+// TestBackendReloadWhileFetching if we get an error (with -race) reloading the urls file replaces the
+// feeds while the fetch commands read them
+func TestBackendReloadWhileFetching(t *testing.T) {
+	dir := t.TempDir()
+	b, err := New("", filepath.Join(dir, "urls.yml"), filepath.Join(dir, "cache"), true)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.Cache.OfflineMode = true
+	if err = b.Rss.Save(); err != nil {
+		t.Fatalf("couldn't save the urls file: %v", err)
+	}
+
+	feed := b.Rss.Categories[0].Subscriptions[0].Name
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			b.FetchCategories("")()
+			b.FetchArticles(feed, false)()
+			b.FetchAllArticles(rss.AllFeedsName, false)()
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+			if err = b.ReloadFeeds(); err != nil {
+				t.Fatalf("couldn't reload the feeds: %v", err)
+			}
+		}
+	}
+}
+
+// TestBackendRetryQueue if we get an error the failures of overlapping bulk fetches start their own
+// retries, a feed is retried twice in a round or the queue doesn't empty
+func TestBackendRetryQueue(t *testing.T) {
+	var mu sync.Mutex
+	failing := true
+	hits := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		hits[r.URL.Path]++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+			`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	b, err := New("", filepath.Join(dir, "urls.yml"), filepath.Join(dir, "cache"), true)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	b.options.RetryRounds = 2
+	b.options.RetryDelay = time.Millisecond
+	first, second := &rss.Feed{URL: server.URL + "/first"}, &rss.Feed{URL: server.URL + "/second"}
+	cmd := b.RetryFailed([]*rss.Feed{first})
+	if cmd == nil {
+		t.Fatal("expected the retries to start")
+	}
+
+	if b.RetryFailed([]*rss.Feed{first, second}) != nil {
+		t.Fatal("expected the second failure to join the running retries")
+	}
+
+	msg := cmd().(RetryProgressMsg)
+	mu.Lock()
+	if msg.Retried != 2 || msg.Final || hits["/first"] != 1 {
+		t.Fatalf("expected both feeds to be retried once, got %+v and %v", msg, hits)
+	}
+
+	failing = false
+	mu.Unlock()
+
+	msg = b.RetryNext()().(RetryProgressMsg)
+	if msg.Recovered != 2 || !msg.Final {
+		t.Fatalf("expected both feeds to recover, got %+v", msg)
+	}
+
+	if b.RetryNext() != nil {
+		t.Error("expected the retries to stop with an empty queue")
+	}
+}
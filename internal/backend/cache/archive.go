@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
+	"github.com/mmcdole/gofeed/atom"
+)
+
+// ErrNoOlderArticles is returned when there is no older page of a feed which can be loaded
+var ErrNoOlderArticles = errors.New("there are no older articles to load")
+
+// HasOlder returns true if an older page of a cached feed can be loaded, the archive pages have to be
+// enabled and neither the page limit nor the article limit can be reached
+func (c *Cache) HasOlder(url string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entry(url)
+	if !ok || entry.Next == "" || entry.Pages >= c.options.ArchivePages {
+		return false
+	}
+
+	return c.options.MaxItems == 0 || len(entry.Articles) < c.options.MaxItems
+}
+
+// LoadOlder follows the next link of a paged feed (RFC 5005) and adds the older articles to the cached
+// ones, the articles of the feed and the number of added articles are returned
+func (c *Cache) LoadOlder(feed *rss.Feed) (SortableArticles, int, error) {
+	if !c.HasOlder(feed.URL) {
+		return nil, 0, fmt.Errorf("cache.LoadOlder: %w", ErrNoOlderArticles)
+	}
+
+	if c.OfflineMode {
+		return nil, 0, errors.New("offline mode")
+	}
+
+	c.mu.RLock()
+	entry := c.Content[feed.URL]
+	c.mu.RUnlock()
+
+	log.Println("Loading the older articles of", feed.URL, "from", entry.Next)
+	articles, info, err := c.fetchArticles(entry.Next, feed.InsecureSkipVerify, feed.Headers)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cache.LoadOlder: %w", err)
+	}
+
+	known := make(map[string]bool, len(entry.Articles))
+	for i := range entry.Articles {
+		known[articleKey(&entry.Articles[i])] = true
+	}
+
+	added := 0
+	merged := append(SortableArticles{}, entry.Articles...)
+	for _, article := range c.filterArticles(feed, articles) {
+		if c.options.MaxItems != 0 && len(merged) >= c.options.MaxItems {
+			break
+		}
+
+		if key := articleKey(&article); !known[key] {
+			known[key] = true
+			merged = append(merged, article)
+			added++
+		}
+	}
+
+	// A page pointing back at itself or at the first page would be loaded again and again
+	if info.next == entry.Next || info.next == feed.URL {
+		info.next = ""
+	}
+
+	assignIDs(merged)
+	entry.Next = info.next
+	entry.Articles = merged
+	entry.Pages++
+	c.mu.Lock()
+	c.Content[feed.URL] = entry
+	c.mu.Unlock()
+	return merged, added, nil
+}
+
+// articleKey identifies an article when merging the pages of a feed
+func articleKey(item *gofeed.Item) string {
+	if item.GUID != "" {
+		return item.GUID
+	}
+
+	return item.Link
+}
+
+// nextPage returns the url of the next page of a paged feed, atom feeds have it in their links and the
+// other ones in an atom:link extension, a relative url is resolved against the url of the page
+func nextPage(data []byte, feed *gofeed.Feed, pageURL string) string {
+	var next string
+	if feed.FeedType == "atom" {
+		parsed, err := (&atom.Parser{}).Parse(bytes.NewReader(data))
+		if err != nil {
+			return ""
+		}
+
+		for _, link := range parsed.Links {
+			if link.Rel == "next" {
+				next = link.Href
+				break
+			}
+		}
+	} else {
+		for _, ext := range feed.Extensions["atom"]["link"] {
+			if ext.Attrs["rel"] == "next" {
+				next = ext.Attrs["href"]
+				break
+			}
+		}
+	}
+
+	if next == "" {
+		return ""
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return next
+	}
+
+	ref, err := url.Parse(next)
+	if err != nil {
+		return ""
+	}
+
+	return base.ResolveReference(ref).String()
+}
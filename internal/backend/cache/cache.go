@@ -1,18 +1,27 @@
 package cache
 
 import (
-	"context"
+	"bytes"
+	"crypto/sha1"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/TypicalAM/goread/internal/backend/atomicfile"
+	"github.com/TypicalAM/goread/internal/backend/fulltext"
+	"github.com/TypicalAM/goread/internal/backend/logging"
 	"github.com/TypicalAM/goread/internal/backend/rss"
 	"github.com/mmcdole/gofeed"
 )
@@ -23,6 +32,34 @@ var DefaultCacheDuration = 24 * time.Hour
 // DefaultCacheSize is the default size of the cache
 var DefaultCacheSize = 100
 
+// DefaultRedirectThreshold is the number of fetches in a row which have to be permanently redirected
+// to the same url before the feed is considered moved
+var DefaultRedirectThreshold = 2
+
+// DefaultFullTextDuration is the default duration for which an extracted article body is cached
+var DefaultFullTextDuration = 7 * 24 * time.Hour
+
+// InMemoryEnv is the environment variable which keeps the cache in memory like the InMemory option
+const InMemoryEnv = "GOREAD_IN_MEMORY"
+
+// ErrFeedTooLarge is returned when the body of a feed is larger than the maximum response size
+var ErrFeedTooLarge = errors.New("feed too large")
+
+// Fetcher downloads the body of a feed for the url schemes which aren't handled over http
+type Fetcher interface {
+	Fetch(url string, options Options) ([]byte, error)
+}
+
+// fetchers contains the fetchers for the url schemes other than http and https
+var fetchers = map[string]Fetcher{
+	"gemini": geminiFetcher{},
+}
+
+// RegisterFetcher makes the feeds with the given url scheme use the fetcher
+func RegisterFetcher(scheme string, fetcher Fetcher) {
+	fetchers[strings.ToLower(scheme)] = fetcher
+}
+
 // SortableArticles is a sortable list of articles
 type SortableArticles []gofeed.Item
 
@@ -31,8 +68,13 @@ func (sa SortableArticles) Len() int {
 	return len(sa)
 }
 
-// Less returns true if the item at index i is less than the item at index j, needed for sorting
+// Less returns true if the item at index i is less than the item at index j, needed for sorting. The
+// newest items come first and the ones without a date last.
 func (sa SortableArticles) Less(a, b int) bool {
+	if sa[a].PublishedParsed == nil || sa[b].PublishedParsed == nil {
+		return sa[a].PublishedParsed != nil && sa[b].PublishedParsed == nil
+	}
+
 	return sa[a].PublishedParsed.After(*sa[b].PublishedParsed)
 }
 
@@ -43,20 +85,173 @@ func (sa SortableArticles) Swap(a, b int) {
 
 // Cache handles the caching of feeds and storing downloaded articles
 type Cache struct {
-	Content     map[string]Entry `json:"content"`
+	Content     map[string]Entry         `json:"content"`
+	FullText    map[string]FullTextEntry `json:"full_text"`
 	filePath    string
 	Downloaded  SortableArticles `json:"downloaded"`
+	ReadLater   []ReadLaterEntry `json:"read_later"`
 	OfflineMode bool             `json:"-"`
+	options     Options
+
+	// DownloadedAt contains the time each downloaded article was downloaded at, keyed by the article link
+	DownloadedAt map[string]time.Time `json:"downloaded_at,omitempty"`
+
+	// Positions contains the reading positions of the articles, keyed by the article guid
+	Positions map[string]PositionEntry `json:"positions,omitempty"`
+
+	// History contains the names of the recently opened feeds, the most recent one first
+	History []string `json:"history,omitempty"`
+
+	// unknown contains the fields of the cache file written by a newer version
+	unknown map[string]json.RawMessage
+
+	// mu guards the maps and the lists above, the feeds are fetched in the background while the
+	// interface reads and changes them
+	mu sync.RWMutex
+}
+
+// cacheJSON is the format of the cache file, it doesn't have the methods of the cache
+type cacheJSON Cache
+
+// UnmarshalJSON decodes the cache and keeps the fields it doesn't know
+func (c *Cache) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*cacheJSON)(c)); err != nil {
+		return err
+	}
+
+	unknown, err := unknownFields(data, cacheJSON{})
+	if err != nil {
+		return err
+	}
+
+	c.unknown = unknown
+	return nil
+}
+
+// MarshalJSON encodes the cache along with the fields it didn't know when it was loaded
+func (c *Cache) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal((*cacheJSON)(c))
+	if err != nil {
+		return nil, err
+	}
+
+	return withUnknownFields(data, c.unknown)
+}
+
+// RetentionPolicy limits how many downloaded articles are kept, zero values mean no limit
+type RetentionPolicy struct {
+	MaxCount int
+	MaxAge   time.Duration
 }
 
-// Entry is a cache entry
+// Entry is a cache entry, the articles of a loaded entry are only read when they are first needed
 type Entry struct {
-	Expire   time.Time        `json:"expire"`
-	Articles SortableArticles `json:"articles"`
+	Expire    time.Time        `json:"expire"`
+	Articles  SortableArticles `json:"articles"`
+	MovedTo   string           `json:"moved_to,omitempty"`
+	Redirects int              `json:"redirects,omitempty"`
+	Encoding  string           `json:"encoding,omitempty"`
+	FeedDesc  string           `json:"feed_desc,omitempty"`
+	FeedLink  string           `json:"feed_link,omitempty"`
+	Next      string           `json:"next,omitempty"`
+	Pages     int              `json:"pages,omitempty"`
+	Skipped   int              `json:"skipped,omitempty"`
+	Metrics   []FetchMetric    `json:"metrics,omitempty"`
+	raw       json.RawMessage
+	stored    bool
+	unknown   map[string]json.RawMessage
+}
+
+// entryJSON is the format of an entry in the cache file, older cache files have the articles inline
+type entryJSON struct {
+	Expire    time.Time       `json:"expire"`
+	Articles  json.RawMessage `json:"articles,omitempty"`
+	MovedTo   string          `json:"moved_to,omitempty"`
+	Redirects int             `json:"redirects,omitempty"`
+	Encoding  string          `json:"encoding,omitempty"`
+	FeedDesc  string          `json:"feed_desc,omitempty"`
+	FeedLink  string          `json:"feed_link,omitempty"`
+	Next      string          `json:"next,omitempty"`
+	Pages     int             `json:"pages,omitempty"`
+	Skipped   int             `json:"skipped,omitempty"`
+	Metrics   []FetchMetric   `json:"metrics,omitempty"`
+}
+
+// UnmarshalJSON decodes everything except for the articles, which are decoded on first access
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	var decoded entryJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*e = Entry{
+		Expire:    decoded.Expire,
+		MovedTo:   decoded.MovedTo,
+		Redirects: decoded.Redirects,
+		Encoding:  decoded.Encoding,
+		FeedDesc:  decoded.FeedDesc,
+		FeedLink:  decoded.FeedLink,
+		Next:      decoded.Next,
+		Pages:     decoded.Pages,
+		Skipped:   decoded.Skipped,
+		Metrics:   decoded.Metrics,
+	}
+
+	if decoded.Articles != nil {
+		e.raw = decoded.Articles
+	} else {
+		e.stored = true
+	}
+
+	unknown, err := unknownFields(data, entryJSON{})
+	if err != nil {
+		return err
+	}
+
+	e.unknown = unknown
+	return nil
 }
 
-// New creates a new cache store.
+// MarshalJSON encodes the entry without its articles, they are saved in a separate file
+func (e Entry) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(entryJSON{
+		Expire:    e.Expire,
+		MovedTo:   e.MovedTo,
+		Redirects: e.Redirects,
+		Encoding:  e.Encoding,
+		FeedDesc:  e.FeedDesc,
+		FeedLink:  e.FeedLink,
+		Next:      e.Next,
+		Pages:     e.Pages,
+		Skipped:   e.Skipped,
+		Metrics:   e.Metrics,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return withUnknownFields(data, e.unknown)
+}
+
+// ReadLaterEntry is an article queued to be read later
+type ReadLaterEntry struct {
+	Added time.Time   `json:"added"`
+	Item  gofeed.Item `json:"item"`
+}
+
+// FullTextEntry is an article body extracted from the article's page, keyed by the article link
+type FullTextEntry struct {
+	Expire  time.Time `json:"expire"`
+	Content string    `json:"content"`
+}
+
+// New creates a new cache store which uses the default fetch options.
 func New(dir string) (*Cache, error) {
+	return NewWithOptions(dir, DefaultOptions)
+}
+
+// NewWithOptions creates a new cache store which uses the given fetch options.
+func NewWithOptions(dir string, options Options) (*Cache, error) {
 	log.Println("Creating new cache store")
 	if dir == "" {
 		defaultDir, err := getDefaultDir()
@@ -67,15 +262,43 @@ func New(dir string) (*Cache, error) {
 		dir = defaultDir
 	}
 
+	options.InMemory = keepInMemory(options)
+	if options.InMemory {
+		log.Println("The cache is kept in memory, nothing is written to", dir)
+	}
+
 	return &Cache{
 		filePath:   filepath.Join(dir, "cache.json"),
 		Content:    make(map[string]Entry),
+		FullText:   make(map[string]FullTextEntry),
 		Downloaded: make(SortableArticles, 0),
+		ReadLater:  make([]ReadLaterEntry, 0),
+		options:    options,
+
+		DownloadedAt: make(map[string]time.Time),
+		Positions:    make(map[string]PositionEntry),
 	}, nil
 }
 
-// Load reads the cache from disk
+// keepInMemory checks if the state should be kept in memory because of the options or the environment
+func keepInMemory(options Options) bool {
+	if inMemory, err := strconv.ParseBool(os.Getenv(InMemoryEnv)); err == nil && inMemory {
+		return true
+	}
+
+	return options.InMemory
+}
+
+// Load reads the cache from disk, the articles of each feed are read when they are first needed. An
+// in-memory cache starts out empty.
 func (c *Cache) Load() error {
+	if c.options.InMemory {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	log.Println("Loading cache from", c.filePath)
 	data, err := os.ReadFile(c.filePath)
 	if err != nil {
@@ -86,16 +309,35 @@ func (c *Cache) Load() error {
 		return fmt.Errorf("cache.Load: %w", err)
 	}
 
-	if err = json.Unmarshal(data, &c); err != nil {
+	if err = json.Unmarshal(data, c); err != nil {
 		return fmt.Errorf("cache.Load: %w", err)
 	}
 
+	if c.FullText == nil {
+		c.FullText = make(map[string]FullTextEntry)
+	}
+
+	if c.DownloadedAt == nil {
+		c.DownloadedAt = make(map[string]time.Time)
+	}
+
+	if c.Positions == nil {
+		c.Positions = make(map[string]PositionEntry)
+	}
+
 	log.Println("Loaded cache entries: ", len(c.Content))
 	return nil
 }
 
-// Save writes the cache to disk
+// Save writes the cache to disk, an in-memory cache isn't written
 func (c *Cache) Save() error {
+	if c.options.InMemory {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Iterate over the cache and remove any expired items
 	for key, value := range c.Content {
 		if value.Expire.Before(time.Now()) {
@@ -103,6 +345,18 @@ func (c *Cache) Save() error {
 		}
 	}
 
+	for key, value := range c.FullText {
+		if value.Expire.Before(time.Now()) {
+			delete(c.FullText, key)
+		}
+	}
+
+	for key, value := range c.Positions {
+		if value.Expire.Before(time.Now()) {
+			delete(c.Positions, key)
+		}
+	}
+
 	cacheData, err := json.Marshal(c)
 	if err != nil {
 		return fmt.Errorf("cache.Save: %w", err)
@@ -119,6 +373,154 @@ func (c *Cache) Save() error {
 		}
 	}
 
+	if err = c.saveArticles(); err != nil {
+		return fmt.Errorf("cache.Save: %w", err)
+	}
+
+	return nil
+}
+
+// Snapshot encodes the cache file and returns a function which writes it to disk atomically, the
+// writing doesn't touch the cache so it can happen in the background. Unlike Save it doesn't write the
+// articles, so only the entries whose article files are up to date are kept and the other feeds are
+// fetched again if the app doesn't get to save them on exit.
+func (c *Cache) Snapshot() (func() error, error) {
+	if c.options.InMemory {
+		return func() error { return nil }, nil
+	}
+
+	snapshot := c.copyState()
+	cacheData, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("cache.Snapshot: %w", err)
+	}
+
+	path := c.filePath
+	return func() error { return atomicfile.Write(path, cacheData) }, nil
+}
+
+// copyState copies the cache for saving it in the background, only the entries whose article files are
+// up to date are kept
+func (c *Cache) copyState() *Cache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	snapshot := &Cache{
+		Content:      make(map[string]Entry, len(c.Content)),
+		FullText:     make(map[string]FullTextEntry, len(c.FullText)),
+		filePath:     c.filePath,
+		Downloaded:   append(SortableArticles{}, c.Downloaded...),
+		ReadLater:    append([]ReadLaterEntry{}, c.ReadLater...),
+		DownloadedAt: make(map[string]time.Time, len(c.DownloadedAt)),
+		Positions:    make(map[string]PositionEntry, len(c.Positions)),
+		History:      append([]string(nil), c.History...),
+		unknown:      c.unknown,
+	}
+
+	for url, entry := range c.Content {
+		if entry.stored {
+			snapshot.Content[url] = entry
+		}
+	}
+
+	for link, entry := range c.FullText {
+		snapshot.FullText[link] = entry
+	}
+
+	for link, at := range c.DownloadedAt {
+		snapshot.DownloadedAt[link] = at
+	}
+
+	for guid, entry := range c.Positions {
+		snapshot.Positions[guid] = entry
+	}
+
+	return snapshot
+}
+
+// entry returns the cache entry of a feed, decoding its articles if that didn't happen yet. The caller
+// has to hold the lock for writing since the decoded articles are stored.
+func (c *Cache) entry(url string) (Entry, bool) {
+	entry, ok := c.Content[url]
+	if !ok || (entry.raw == nil && !entry.stored) {
+		return entry, ok
+	}
+
+	data := entry.raw
+	if entry.stored {
+		var err error
+		if data, err = os.ReadFile(c.articlesPath(url)); err != nil {
+			log.Println("Failed to read the cached articles of", url, err)
+			delete(c.Content, url)
+			return Entry{}, false
+		}
+	}
+
+	if err := json.Unmarshal(data, &entry.Articles); err != nil {
+		log.Println("Failed to decode the cached articles of", url, err)
+		delete(c.Content, url)
+		return Entry{}, false
+	}
+
+	// The articles cached by the older versions don't have an identity yet
+	assignIDs(entry.Articles)
+	entry.raw = nil
+	entry.stored = false
+	c.Content[url] = entry
+	return entry, true
+}
+
+// articlesDir returns the directory in which the articles of the cached feeds are saved
+func (c *Cache) articlesDir() string {
+	return filepath.Join(filepath.Dir(c.filePath), "articles")
+}
+
+// articlesPath returns the path of the file with the cached articles of a feed
+func (c *Cache) articlesPath(url string) string {
+	return filepath.Join(c.articlesDir(), fmt.Sprintf("%x.json", sha1.Sum([]byte(url))))
+}
+
+// saveArticles writes the articles which were read or fetched to their files and removes the files
+// of the feeds which are no longer cached
+func (c *Cache) saveArticles() error {
+	if err := os.MkdirAll(c.articlesDir(), 0755); err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool, len(c.Content))
+	for url, entry := range c.Content {
+		path := c.articlesPath(url)
+		keep[filepath.Base(path)] = true
+		if entry.stored {
+			continue
+		}
+
+		data := entry.raw
+		if data == nil {
+			var err error
+			if data, err = json.Marshal(entry.Articles); err != nil {
+				return err
+			}
+		}
+
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			return err
+		}
+	}
+
+	files, err := os.ReadDir(c.articlesDir())
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if !keep[file.Name()] {
+			if err = os.Remove(filepath.Join(c.articlesDir(), file.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -127,28 +529,69 @@ func (c *Cache) GetArticles(feed *rss.Feed, ignoreCache bool) (SortableArticles,
 	log.Println("Getting articles for", feed.URL, " from cache: ", !ignoreCache)
 
 	// Delete entry if expired
-	if item, ok := c.Content[feed.URL]; ok && !ignoreCache {
-		if item.Expire.After(time.Now()) {
-			return item.Articles, nil
+	c.mu.Lock()
+	prev, ok := c.entry(feed.URL)
+	if ok && !ignoreCache {
+		if prev.Expire.After(time.Now()) {
+			c.mu.Unlock()
+			logging.Debug("Cache hit for", feed.URL, "expires at", prev.Expire.Format(time.RFC3339))
+			return prev.Articles, nil
 		}
 
+		logging.Debug("Cache entry of", feed.URL, "expired at", prev.Expire.Format(time.RFC3339))
 		delete(c.Content, feed.URL)
+	} else if !ok {
+		logging.Debug("Cache miss for", feed.URL)
 	}
+	c.mu.Unlock()
 
 	if c.OfflineMode {
 		return nil, errors.New("offline mode")
 	}
 
-	articles, err := fetchArticles(feed.URL)
+	articles, info, err := c.fetchArticles(feed.URL, feed.InsecureSkipVerify, feed.Headers)
 	if err != nil {
 		return nil, fmt.Errorf("cache.GetArticles: %w", err)
 	}
 
-	if len(feed.BlacklistWords) != 0 {
-		log.Println("Using keyword blacklist for feed", feed.Name, ":", feed.BlacklistWords)
+	articles = c.filterArticles(feed, articles)
+	assignIDs(articles)
+	entry := Entry{
+		Expire:   c.expiry(info),
+		Articles: articles,
+		Encoding: info.encoding,
+		FeedDesc: info.description,
+		FeedLink: info.link,
+		Next:     info.next,
+		Skipped:  info.skipped,
+		Metrics:  addMetric(prev.Metrics, FetchMetric{time.Now(), info.duration, info.size}),
+		unknown:  prev.unknown,
+	}
+
+	if info.movedTo != "" {
+		log.Println("Feed", feed.URL, "was permanently redirected to", info.movedTo)
+		entry.MovedTo = info.movedTo
+		entry.Redirects = 1
+		if prev.MovedTo == info.movedTo {
+			entry.Redirects = prev.Redirects + 1
+		}
+	}
+
+	c.mu.Lock()
+	c.Content[feed.URL] = entry
+	c.mu.Unlock()
+	return articles, nil
+}
+
+// filterArticles applies the keyword filters, the full text extraction, the boilerplate stripping and
+// the minimum word count of a feed to freshly fetched articles
+func (c *Cache) filterArticles(feed *rss.Feed, articles SortableArticles) SortableArticles {
+	whitelist, blacklist := c.keywordFilters(feed)
+	if len(blacklist) != 0 {
+		log.Println("Using keyword blacklist for feed", feed.Name, ":", blacklist)
 		remaining := make([]gofeed.Item, 0)
 		for _, article := range articles {
-			if !includesKeywords(&article, feed.BlacklistWords) {
+			if !includesKeywords(&article, blacklist, feed.CaseSensitive) {
 				remaining = append(remaining, article)
 			}
 		}
@@ -156,11 +599,11 @@ func (c *Cache) GetArticles(feed *rss.Feed, ignoreCache bool) (SortableArticles,
 		articles = remaining
 	}
 
-	if len(feed.WhitelistWords) != 0 {
-		log.Println("Using keyword whitelist for feed", feed.Name, ":", feed.WhitelistWords)
+	if len(whitelist) != 0 {
+		log.Println("Using keyword whitelist for feed", feed.Name, ":", whitelist)
 		remaining := make([]gofeed.Item, 0)
 		for _, article := range articles {
-			if includesKeywords(&article, feed.WhitelistWords) {
+			if includesKeywords(&article, whitelist, feed.CaseSensitive) {
 				remaining = append(remaining, article)
 			}
 		}
@@ -168,105 +611,663 @@ func (c *Cache) GetArticles(feed *rss.Feed, ignoreCache bool) (SortableArticles,
 		articles = remaining
 	}
 
-	c.Content[feed.URL] = Entry{time.Now().Add(DefaultCacheDuration), articles}
-	return articles, nil
+	if feed.FullText {
+		log.Println("Extracting the full text for feed", feed.Name)
+		c.fillFullText(articles, feed.InsecureSkipVerify)
+	}
+
+	if boilerplate := c.boilerplate(feed); !boilerplate.Empty() {
+		log.Println("Stripping the leading boilerplate for feed", feed.Name)
+		for i := range articles {
+			articles[i].Description = boilerplate.Strip(articles[i].Description)
+			articles[i].Content = boilerplate.Strip(articles[i].Content)
+		}
+	}
+
+	// The full text is extracted first, otherwise the summaries of full text feeds would be filtered out
+	if feed.MinWords > 0 {
+		log.Println("Using minimum word count for feed", feed.Name, ":", feed.MinWords)
+		remaining := make([]gofeed.Item, 0)
+		for _, article := range articles {
+			if len(article.Enclosures) != 0 {
+				remaining = append(remaining, article)
+				continue
+			}
+
+			if words, _ := rss.TextStats(&article); words >= feed.MinWords {
+				remaining = append(remaining, article)
+			}
+		}
+
+		articles = remaining
+	}
+
+	return articles
+}
+
+// MovedTo returns the url the feed has consistently been permanently redirected to, if any
+func (c *Cache) MovedTo(url string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.Content[url]
+	if !ok || entry.MovedTo == "" || entry.Redirects < DefaultRedirectThreshold {
+		return "", false
+	}
+
+	return entry.MovedTo, true
+}
+
+// FeedDetails returns the self-description and the homepage link of a cached feed, both of them can be empty
+func (c *Cache) FeedDetails(url string) (description, link string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.Content[url]
+	if !ok {
+		return "", ""
+	}
+
+	return entry.FeedDesc, entry.FeedLink
+}
+
+// SkippedItems returns how many malformed items were left out when the feed was last parsed
+func (c *Cache) SkippedItems(url string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Content[url].Skipped
+}
+
+// RemoveEntry forgets the cached articles of a feed, their file is removed on the next save
+func (c *Cache) RemoveEntry(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.Content, url)
+}
+
+// ResetRedirects forgets the redirects of a feed, used when the user doesn't want to follow them
+func (c *Cache) ResetRedirects(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.Content[url]; ok {
+		entry.MovedTo = ""
+		entry.Redirects = 0
+		c.Content[url] = entry
+	}
+}
+
+// GetCachedArticles returns the articles of a feed which are already in the cache, it never fetches
+func (c *Cache) GetCachedArticles(url string) SortableArticles {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, _ := c.entry(url)
+	return entry.Articles
+}
+
+// MarkReadBefore marks the cached articles published before the given time as read and returns how
+// many of them weren't read yet, only the given feeds are used if there are any. The articles without
+// a publication date are left alone.
+func (c *Cache) MarkReadBefore(before time.Time, feeds []*rss.Feed, readStatus *ReadStatus) int {
+	var urls []string
+	if feeds == nil {
+		c.mu.RLock()
+		for url := range c.Content {
+			urls = append(urls, url)
+		}
+		c.mu.RUnlock()
+	} else {
+		for _, feed := range feeds {
+			urls = append(urls, feed.URL)
+		}
+	}
+
+	marked := 0
+	for _, url := range urls {
+		for _, item := range c.GetCachedArticles(url) {
+			published := item.PublishedParsed
+			if published == nil {
+				published = item.UpdatedParsed
+			}
+
+			if published == nil || !published.Before(before) || readStatus.IsArticleRead(&item) {
+				continue
+			}
+
+			readStatus.MarkAsRead(ArticleID(&item))
+			marked++
+		}
+	}
+
+	return marked
+}
+
+// Search returns the cached articles of the feeds which contain the query in their title or content,
+// the results are grouped by the feed url. The search is case-insensitive and never fetches.
+func (c *Cache) Search(feeds []*rss.Feed, query string) map[string]SortableArticles {
+	query = strings.ToLower(query)
+	result := make(map[string]SortableArticles)
+
+	for _, feed := range feeds {
+		for _, item := range c.GetCachedArticles(feed.URL) {
+			if strings.Contains(strings.ToLower(item.Title), query) ||
+				strings.Contains(strings.ToLower(item.Description), query) ||
+				strings.Contains(strings.ToLower(item.Content), query) {
+				result[feed.URL] = append(result[feed.URL], item)
+			}
+		}
+	}
+
+	return result
 }
 
 // GetArticlesBulk returns a sorted list of articles from all the given urls, ignoring any errors
 func (c *Cache) GetArticlesBulk(feeds []*rss.Feed, ignoreCache bool) SortableArticles {
-	var result SortableArticles
+	result, _ := c.GetArticlesBulkFailed(feeds, ignoreCache)
+	return result
+}
 
+// GetArticlesBulkFailed returns a sorted list of articles from all the given urls like GetArticlesBulk
+// and the feeds which couldn't be fetched. The feeds are fetched by as many workers as the fetch
+// concurrency allows.
+func (c *Cache) GetArticlesBulkFailed(feeds []*rss.Feed, ignoreCache bool) (SortableArticles, []*rss.Feed) {
+	articles := make([]SortableArticles, len(feeds))
+	errs := make([]error, len(feeds))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < c.workers() && w < len(feeds); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				articles[i], errs[i] = c.GetArticles(feeds[i], ignoreCache)
+			}
+		}()
+	}
+
+	for i := range feeds {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	var result SortableArticles
+	var failed []*rss.Feed
 	for i, feed := range feeds {
-		if items, err := c.GetArticles(feeds[i], ignoreCache); err == nil {
-			result = append(result, items...)
-		} else {
-			// NOTE: Let's say you have 50 feeds and 5 fail, we don't want to keep trying failed feeds
-			// so we just fill the cache with an empty item. That way load for bulk feeds is faster next time.
-			log.Println("Error getting articles for", feed.URL, err, "filling with empty item")
-			c.Content[feed.URL] = Entry{time.Now().Add(DefaultCacheDuration), SortableArticles{}}
+		if errs[i] == nil {
+			result = append(result, articles[i]...)
+			continue
 		}
+
+		// NOTE: Let's say you have 50 feeds and 5 fail, we don't want to keep trying failed feeds
+		// so we just fill the cache with an empty item. That way load for bulk feeds is faster next time.
+		log.Println("Error getting articles for", feed.URL, errs[i], "filling with empty item")
+		c.mu.Lock()
+		c.Content[feed.URL] = Entry{Expire: time.Now().Add(DefaultCacheDuration), Articles: SortableArticles{}}
+		c.mu.Unlock()
+		failed = append(failed, feed)
 	}
 
-	return result
+	return result, failed
+}
+
+// RetryFeeds fetches the feeds again ignoring the cache and returns the ones which still fail
+func (c *Cache) RetryFeeds(feeds []*rss.Feed) []*rss.Feed {
+	var failed []*rss.Feed
+	for _, feed := range feeds {
+		if _, err := c.GetArticles(feed, true); err != nil {
+			log.Println("Retrying", feed.URL, "failed:", err)
+			failed = append(failed, feed)
+		}
+	}
+
+	return failed
 }
 
 // GetDownloaded returns a list of downloaded items
 func (c *Cache) GetDownloaded() SortableArticles {
-	return c.Downloaded
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append(SortableArticles{}, c.Downloaded...)
 }
 
 // AddToDownloaded adds an item to the downloaded list
 func (c *Cache) AddToDownloaded(item gofeed.Item) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.Downloaded = append(c.Downloaded, item)
+	c.DownloadedAt[item.Link] = time.Now()
 }
 
 // RemoveFromDownloaded removes an item from the downloaded list
 func (c *Cache) RemoveFromDownloaded(index int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if index < 0 || index >= len(c.Downloaded) {
 		return errors.New("index out of range")
 	}
 
+	delete(c.DownloadedAt, c.Downloaded[index].Link)
 	c.Downloaded = append(c.Downloaded[:index], c.Downloaded[index+1:]...)
 	return nil
 }
 
-// fetchArticles fetches articles from the internet and returns them
-func fetchArticles(url string) (SortableArticles, error) {
+// PruneDownloaded removes the downloaded articles which the policy doesn't allow to keep, the oldest
+// downloads are removed first. Articles in the read later queue are always kept. Articles downloaded
+// before the download times were recorded start aging from the first prune. It returns the number
+// of removed articles.
+func (c *Cache) PruneDownloaded(policy RetentionPolicy) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	queued := make(map[string]bool, len(c.ReadLater))
+	for _, entry := range c.ReadLater {
+		queued[entry.Item.Link] = true
+	}
+
+	// Newest downloads first, so that the ones over the limit are at the end
+	kept := make(SortableArticles, 0, len(c.Downloaded))
+	for _, item := range c.Downloaded {
+		if _, ok := c.DownloadedAt[item.Link]; !ok {
+			c.DownloadedAt[item.Link] = now
+		}
+
+		kept = append(kept, item)
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		return c.DownloadedAt[kept[i].Link].After(c.DownloadedAt[kept[j].Link])
+	})
+
+	result := make(SortableArticles, 0, len(kept))
+	for _, item := range kept {
+		tooOld := policy.MaxAge > 0 && now.Sub(c.DownloadedAt[item.Link]) > policy.MaxAge
+		tooMany := policy.MaxCount > 0 && len(result) >= policy.MaxCount
+		if (tooOld || tooMany) && !queued[item.Link] {
+			delete(c.DownloadedAt, item.Link)
+			continue
+		}
+
+		result = append(result, item)
+	}
+
+	removed := len(c.Downloaded) - len(result)
+	c.Downloaded = result
+	return removed
+}
+
+// GetReadLater returns the read later queue in the order in which the items were added
+func (c *Cache) GetReadLater() SortableArticles {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	items := make(SortableArticles, len(c.ReadLater))
+	for i, entry := range c.ReadLater {
+		items[i] = entry.Item
+	}
+
+	return items
+}
+
+// AddToReadLater adds an item to the end of the read later queue, items which are already queued are skipped
+func (c *Cache) AddToReadLater(item gofeed.Item) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, entry := range c.ReadLater {
+		if entry.Item.Link == item.Link {
+			return false
+		}
+	}
+
+	c.ReadLater = append(c.ReadLater, ReadLaterEntry{time.Now(), item})
+	return true
+}
+
+// RemoveFromReadLater removes an item from the read later queue
+func (c *Cache) RemoveFromReadLater(index int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if index < 0 || index >= len(c.ReadLater) {
+		return errors.New("index out of range")
+	}
+
+	c.ReadLater = append(c.ReadLater[:index], c.ReadLater[index+1:]...)
+	return nil
+}
+
+// workers returns the number of feeds or pages fetched at once, at least one so that zero options still fetch
+func (c *Cache) workers() int {
+	if c.options.Concurrency < 1 {
+		return 1
+	}
+
+	return c.options.Concurrency
+}
+
+// fillFullText replaces the truncated descriptions of the articles with the content extracted
+// from the article pages, the extracted bodies are cached so that they aren't refetched on every refresh.
+// The headers of the feed aren't sent, the pages are usually on other hosts which shouldn't see its keys
+func (c *Cache) fillFullText(articles SortableArticles, insecure bool) {
+	bodies := make([]string, len(articles))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	client := c.newClient(insecure)
+	for w := 0; w < c.workers(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				content, err := fulltext.FetchWith(client, c.options.UserAgent, articles[i].Link, c.options.MaxResponseSize)
+				if err != nil {
+					log.Println("Failed to extract the full text:", err)
+					continue
+				}
+
+				bodies[i] = content
+			}
+		}()
+	}
+
+	for i := range articles {
+		if articles[i].Link == "" {
+			continue
+		}
+
+		c.mu.RLock()
+		entry, ok := c.FullText[articles[i].Link]
+		c.mu.RUnlock()
+		if ok && entry.Expire.After(time.Now()) {
+			bodies[i] = entry.Content
+			continue
+		}
+
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range articles {
+		if bodies[i] == "" {
+			continue
+		}
+
+		c.FullText[articles[i].Link] = FullTextEntry{time.Now().Add(DefaultFullTextDuration), bodies[i]}
+		articles[i].Description = bodies[i]
+		articles[i].Content = ""
+	}
+}
+
+// feedInfo describes how a feed was fetched
+type feedInfo struct {
+	// movedTo is set if the feed was permanently redirected
+	movedTo string
+	// encoding is the encoding the feed was decoded from
+	encoding string
+	// description is the feed's description of itself
+	description string
+	// link is the homepage of the feed
+	link string
+	// next is the url of the page with the older articles of a paged feed
+	next string
+	// skipped is the number of malformed items which were left out of the feed
+	skipped int
+	// duration is how long fetching and parsing the feed took and size is the size of its body
+	duration time.Duration
+	size     int
+	// freshness is how long the server suggested to cache the feed for, if hasFreshness is set
+	freshness    time.Duration
+	hasFreshness bool
+}
+
+// fetchArticles fetches articles from the internet and returns them, insecure skips the verification
+// of the tls certificate and the headers are added to the request
+func (c *Cache) fetchArticles(url string, insecure bool, headers map[string]string) (articles SortableArticles, info feedInfo, err error) {
 	log.Println("Fetching articles from", url)
-	feed, err := parseFeed(url)
+	start := time.Now()
+	feed, info, err := c.parseFeed(url, insecure, headers)
 	if err != nil {
-		return nil, fmt.Errorf("cache.fetchArticles: %w", err)
+		log.Println("Fetching", url, "failed after", time.Since(start).Round(time.Millisecond), err)
+		return nil, feedInfo{}, fmt.Errorf("cache.fetchArticles: %w", err)
 	}
 
+	info.duration = time.Since(start)
+	logging.Debug("Fetched", len(feed.Items), "articles from", url, "in", info.duration.Round(time.Millisecond))
+
 	items := make(SortableArticles, len(feed.Items))
 	for i, item := range feed.Items {
 		items[i] = *item
+		// Some feeds only name their author once for the whole feed
+		if len(items[i].Authors) == 0 && items[i].Author == nil {
+			items[i].Authors = feed.Authors
+		}
+
+		if c.options.ResolveLinks {
+			base := rss.LinkBase(url, feed.Link, item.Link)
+			items[i].Description = rss.ResolveLinks(item.Description, base)
+			items[i].Content = rss.ResolveLinks(item.Content, base)
+		}
 	}
 
-	return items, nil
+	return items, info, nil
 }
 
 // parseFeed parses a url and attempts to return a parsed feed
 // authors note: this is was because the gofeed parser did not support reddit
-func parseFeed(url string) (*gofeed.Feed, error) {
+func (c *Cache) parseFeed(url string, insecure bool, headers map[string]string) (*gofeed.Feed, feedInfo, error) {
+	data, movedTo, header, err := c.fetchFeed(url, insecure, headers)
+	if err != nil {
+		return nil, feedInfo{}, fmt.Errorf("cache.parseFeed: %w", err)
+	}
+
+	if isHTML(data) {
+		return nil, feedInfo{}, fmt.Errorf("cache.parseFeed: %w", newNotFeedError(data, url))
+	}
+
+	size := len(data)
+	data, encoding := fixEncoding(data)
+	if encoding != "utf-8" {
+		log.Println("Feed", url, "was decoded as", encoding)
+	}
+
+	feed, err := gofeed.NewParser().Parse(bytes.NewReader(data))
+	skipped := 0
+	if err != nil && c.options.LenientParse {
+		if salvaged, n, lenientErr := parseLenient(data); lenientErr == nil {
+			log.Println("Feed", url, "is malformed, skipped", n, "of its items:", err)
+			feed, skipped, err = salvaged, n, nil
+		}
+	}
+
+	if err != nil {
+		return nil, feedInfo{}, fmt.Errorf("cache.parseFeed: %w", err)
+	}
+
+	info := feedInfo{
+		movedTo:     movedTo,
+		encoding:    encoding,
+		description: strings.TrimSpace(feed.Description),
+		link:        feed.Link,
+		next:        nextPage(data, feed, url),
+		skipped:     skipped,
+		size:        size,
+	}
+
+	info.freshness, info.hasFreshness = serverFreshness(header, time.Now())
+	return feed, info, nil
+}
+
+// FetchRaw downloads the unparsed body of a feed
+func (c *Cache) FetchRaw(feed *rss.Feed) ([]byte, error) {
+	data, _, _, err := c.fetchFeed(feed.URL, feed.InsecureSkipVerify, feed.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("cache.FetchRaw: %w", err)
+	}
+
+	return data, nil
+}
+
+// fetchFeed downloads the body of a feed using the fetcher registered for its scheme or over http,
+// movedTo is the final url if a http request was redirected and every redirect on the way was permanent.
+// The header is empty if the feed wasn't fetched over http. The headers are only sent to the host of the url,
+// they are dropped if the feed redirects somewhere else since they can carry the keys of the feed.
+func (c *Cache) fetchFeed(url string, insecure bool, headers map[string]string) (data []byte, movedTo string, header http.Header, err error) {
+	if scheme, _, ok := strings.Cut(url, "://"); ok {
+		if fetcher, ok := fetchers[strings.ToLower(scheme)]; ok {
+			if data, err = fetcher.Fetch(url, c.options); err != nil {
+				return nil, "", nil, fmt.Errorf("cache.fetchFeed: %w", err)
+			}
+
+			return data, "", http.Header{}, nil
+		}
+	}
+
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("cache.parseFeed: %w", err)
+		return nil, "", nil, fmt.Errorf("cache.fetchFeed: %w", err)
+	}
+	req.Header.Set("User-Agent", c.options.UserAgent)
+	for name, value := range headers {
+		req.Header.Set(name, expandHeader(value))
 	}
-	req.Header.Set("User-Agent", "goread (by /u/TypicalAM)")
 
-	client := http.Client{
-		Transport: &http.Transport{
-			Proxy:        http.ProxyFromEnvironment,
-			TLSNextProto: map[string]func(authority string, c *tls.Conn) http.RoundTripper{},
-		},
+	if insecure {
+		log.Println("WARNING: not verifying the tls certificate of", url, "the connection can be intercepted")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	permanent := true
+	client := c.newClient(insecure)
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
 
-	resp, err := client.Do(req.WithContext(ctx))
+		status := req.Response.StatusCode
+		if status != http.StatusMovedPermanently && status != http.StatusPermanentRedirect {
+			permanent = false
+		}
+
+		if req.URL.Host != via[0].URL.Host {
+			for name := range headers {
+				req.Header.Del(name)
+			}
+
+			req.Header.Set("User-Agent", c.options.UserAgent)
+		}
+
+		return nil
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("cache.parseFeed: %w", err)
+		return nil, "", nil, fmt.Errorf("cache.fetchFeed: %w", err)
 	}
+	defer resp.Body.Close()
 
+	logging.Debug("GET", url, resp.Status, "after", time.Since(start).Round(time.Millisecond))
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, gofeed.HTTPError{
+		// Bot protection challenges are usually sent with a 403 or a 503 status
+		if body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024)); err == nil && isHTML(body) && isChallenge(body) {
+			return nil, "", nil, fmt.Errorf("cache.fetchFeed: %w", NotFeedError{Blocked: true})
+		}
+
+		return nil, "", nil, gofeed.HTTPError{
 			StatusCode: resp.StatusCode,
 			Status:     resp.Status,
 		}
 	}
 
-	feed, err := gofeed.NewParser().Parse(resp.Body)
+	data, err = readLimited(resp.Body, c.options.MaxResponseSize)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("cache.fetchFeed: %w", err)
+	}
+
+	if finalURL := resp.Request.URL.String(); permanent && finalURL != url {
+		movedTo = finalURL
+	}
+
+	return data, movedTo, resp.Header, nil
+}
+
+// expandHeader replaces a header value which is entirely $NAME or ${NAME} with the environment variable,
+// any other value is sent as it is so a literal $ in a key isn't lost
+func expandHeader(value string) string {
+	if !strings.HasPrefix(value, "$") {
+		return value
+	}
+
+	name := value[1:]
+	if strings.HasPrefix(name, "{") {
+		if !strings.HasSuffix(name, "}") {
+			return value
+		}
+
+		name = name[1 : len(name)-1]
+	}
+
+	if name == "" || strings.IndexFunc(name, func(r rune) bool {
+		return r != '_' && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') && !('0' <= r && r <= '9')
+	}) != -1 {
+		return value
+	}
+
+	return os.Getenv(name)
+}
+
+// readLimited reads the whole body unless it's larger than limit bytes, a limit of zero reads everything
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
 	if err != nil {
-		return nil, fmt.Errorf("cache.parseFeed: %w", err)
+		return nil, err
 	}
 
-	if err := resp.Body.Close(); err != nil {
-		return nil, fmt.Errorf("cache.parseFeed: %w", err)
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w, it's larger than the limit of %d bytes", ErrFeedTooLarge, limit)
 	}
 
-	return feed, nil
+	return data, nil
+}
+
+// newClient creates a http client which respects the fetch options, insecure turns off the verification
+// of the tls certificates and is only ever set for the feeds which ask for it
+func (c *Cache) newClient(insecure bool) *http.Client {
+	proxy := http.ProxyFromEnvironment
+	if c.options.Proxy != "" {
+		if proxyURL, err := url.Parse(c.options.Proxy); err == nil {
+			proxy = http.ProxyURL(proxyURL)
+		} else {
+			log.Println("Ignoring the invalid proxy url", c.options.Proxy, err)
+		}
+	}
+
+	transport := &http.Transport{
+		Proxy:        proxy,
+		TLSNextProto: map[string]func(authority string, c *tls.Conn) http.RoundTripper{},
+	}
+
+	if insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	}
+
+	return &http.Client{
+		Timeout:   c.options.Timeout,
+		Transport: transport,
+	}
 }
 
 // getDefaultDir returns the default cache directory
@@ -279,16 +1280,60 @@ func getDefaultDir() (string, error) {
 	return filepath.Join(dir, "goread"), nil
 }
 
-// includesKeywords checks if an article contains any specified keyword from a slice
-func includesKeywords(feed *gofeed.Item, keywords []string) bool {
+// keywordFilters merges the global keyword filters with the ones of the feed, the blacklists add up
+// while the whitelist of the feed replaces the global one
+func (c *Cache) keywordFilters(feed *rss.Feed) (whitelist, blacklist []string) {
+	whitelist = feed.WhitelistWords
+	if len(whitelist) == 0 {
+		whitelist = c.options.WhitelistWords
+	}
+
+	blacklist = append(append([]string{}, c.options.BlacklistWords...), feed.BlacklistWords...)
+	return whitelist, blacklist
+}
+
+// boilerplate returns the boilerplate stripped from the articles of the feed, the global selectors and
+// patterns are used together with the ones of the feed
+func (c *Cache) boilerplate(feed *rss.Feed) *rss.Boilerplate {
+	selectors := append(append([]string{}, c.options.StripSelectors...), feed.StripSelectors...)
+	patterns := append(append([]string{}, c.options.StripPatterns...), feed.StripPatterns...)
+	boilerplate, err := rss.NewBoilerplate(selectors, patterns)
+	if err != nil {
+		log.Println("Not stripping the boilerplate for feed", feed.Name, err)
+		return nil
+	}
+
+	return boilerplate
+}
+
+// includesKeywords checks if an article contains any specified keyword from a slice, the case is
+// ignored unless caseSensitive is set
+func includesKeywords(item *gofeed.Item, keywords []string, caseSensitive bool) bool {
+	if !caseSensitive {
+		return MatchingKeyword(item, keywords) != ""
+	}
+
 	for _, keyword := range keywords {
-		lowerKeyword := strings.ToLower(keyword)
-		if strings.Contains(strings.ToLower(feed.Title), lowerKeyword) ||
-			strings.Contains(strings.ToLower(feed.Description), lowerKeyword) ||
-			strings.Contains(strings.ToLower(feed.Content), lowerKeyword) {
+		if strings.Contains(item.Title, keyword) || strings.Contains(item.Description, keyword) ||
+			strings.Contains(item.Content, keyword) {
 			return true
 		}
 	}
 
 	return false
 }
+
+// MatchingKeyword returns the first keyword found in the title, the description or the content of the
+// article, ignoring the case. An empty string is returned if none of them is there.
+func MatchingKeyword(item *gofeed.Item, keywords []string) string {
+	for _, keyword := range keywords {
+		lowerKeyword := strings.ToLower(keyword)
+		if strings.Contains(strings.ToLower(item.Title), lowerKeyword) ||
+			strings.Contains(strings.ToLower(item.Description), lowerKeyword) ||
+			strings.Contains(strings.ToLower(item.Content), lowerKeyword) {
+			return keyword
+		}
+	}
+
+	return ""
+}
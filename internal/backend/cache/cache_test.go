@@ -1,12 +1,23 @@
 package cache
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/mmcdole/gofeed"
 )
 
 const TestOfflineDev = "TEST_OFFLINE_ONLY"
@@ -240,3 +251,1818 @@ func TestCacheGetArticleExpired(t *testing.T) {
 		t.Fatal("expected the data to be refreshed and the expire to be updated")
 	}
 }
+
+// TestCacheGetCachedArticles if we get an error then the cached articles aren't returned without fetching
+func TestCacheGetCachedArticles(t *testing.T) {
+	cache, err := getCache()
+	if err != nil {
+		t.Fatalf("couldn't load the cache %v", err)
+	}
+
+	cache.OfflineMode = true
+	if len(cache.GetCachedArticles("https://primordialsoup.info/feed")) == 0 {
+		t.Fatal("expected cached articles for https://primordialsoup.info/feed")
+	}
+
+	if len(cache.GetCachedArticles("https://example.com/feed")) != 0 {
+		t.Fatal("expected no articles for a feed which isn't cached")
+	}
+}
+
+// TestCacheSearch if we get an error then the search doesn't find the cached articles
+func TestCacheSearch(t *testing.T) {
+	cache, err := getCache()
+	if err != nil {
+		t.Fatalf("couldn't load the cache %v", err)
+	}
+
+	cache.OfflineMode = true
+	feeds := []*rss.Feed{{URL: "https://primordialsoup.info/feed"}, {URL: "https://example.com/feed"}}
+	articles := cache.GetCachedArticles(feeds[0].URL)
+	if len(articles) == 0 {
+		t.Fatal("expected cached articles for https://primordialsoup.info/feed")
+	}
+
+	results := cache.Search(feeds, strings.ToUpper(articles[0].Title))
+	if len(results[feeds[0].URL]) == 0 {
+		t.Fatalf("expected the search to find %q", articles[0].Title)
+	}
+
+	if len(results[feeds[1].URL]) != 0 {
+		t.Fatal("expected no results for a feed which isn't cached")
+	}
+
+	if results = cache.Search(feeds, "there is no article like this"); len(results) != 0 {
+		t.Fatalf("expected no results, got %d feeds", len(results))
+	}
+}
+
+// TestCacheRedirect if we get an error then the permanent redirects aren't recorded
+func TestCacheRedirect(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+		`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/new", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(feed))
+	})
+	mux.Handle("/old", http.RedirectHandler("/new", http.StatusMovedPermanently))
+	mux.Handle("/temporary", http.RedirectHandler("/new", http.StatusFound))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	oldFeed := &rss.Feed{URL: server.URL + "/old"}
+	for i := 0; i < DefaultRedirectThreshold; i++ {
+		if _, ok := cache.MovedTo(oldFeed.URL); ok {
+			t.Fatalf("expected the feed not to be moved after %d fetches", i)
+		}
+
+		if _, err = cache.GetArticles(oldFeed, true); err != nil {
+			t.Fatalf("couldn't get articles: %v", err)
+		}
+	}
+
+	if movedTo, ok := cache.MovedTo(oldFeed.URL); !ok || movedTo != server.URL+"/new" {
+		t.Fatalf("expected the feed to be moved to %s, got %q", server.URL+"/new", movedTo)
+	}
+
+	cache.ResetRedirects(oldFeed.URL)
+	if _, ok := cache.MovedTo(oldFeed.URL); ok {
+		t.Fatal("expected the redirects to be reset")
+	}
+
+	temporaryFeed := &rss.Feed{URL: server.URL + "/temporary"}
+	for i := 0; i < DefaultRedirectThreshold; i++ {
+		if _, err = cache.GetArticles(temporaryFeed, true); err != nil {
+			t.Fatalf("couldn't get articles: %v", err)
+		}
+	}
+
+	if _, ok := cache.MovedTo(temporaryFeed.URL); ok {
+		t.Fatal("expected temporary redirects to be ignored")
+	}
+}
+
+// TestCacheOptions if we get an error then the fetch options aren't used for the requests
+func TestCacheOptions(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+		`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feed", func(w http.ResponseWriter, r *http.Request) {
+		if r.UserAgent() != "test-agent" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		_, _ = w.Write([]byte(feed))
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(feed))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := Options{Timeout: 50 * time.Millisecond, Concurrency: 1, UserAgent: "test-agent"}
+	cache, err := NewWithOptions(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if _, err = cache.GetArticles(&rss.Feed{URL: server.URL + "/feed"}, true); err != nil {
+		t.Fatalf("expected the user agent to be sent, got %v", err)
+	}
+
+	if _, err = cache.GetArticles(&rss.Feed{URL: server.URL + "/slow"}, true); err == nil {
+		t.Fatal("expected the request to time out")
+	}
+}
+
+// TestCacheMaxResponseSize if we get an error then the feeds larger than the limit are downloaded
+func TestCacheMaxResponseSize(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+		`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(feed))
+	}))
+	defer server.Close()
+
+	options := DefaultOptions
+	options.MaxResponseSize = int64(len(feed))
+	cache, err := NewWithOptions(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if _, err = cache.GetArticles(&rss.Feed{URL: server.URL}, true); err != nil {
+		t.Fatalf("expected a feed at the limit to be downloaded, got %v", err)
+	}
+
+	cache.options.MaxResponseSize = int64(len(feed)) - 1
+	if _, err = cache.GetArticles(&rss.Feed{URL: server.URL}, true); !errors.Is(err, ErrFeedTooLarge) {
+		t.Fatalf("expected a feed too large error, got %v", err)
+	}
+}
+
+// TestCacheInsecureSkipVerify if we get an error then the self-signed certificates are trusted without the
+// feed asking for it or the feeds which ask for it can't be fetched
+func TestCacheInsecureSkipVerify(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+		`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(feed))
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if _, err = cache.GetArticles(&rss.Feed{URL: server.URL}, true); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected")
+	}
+
+	items, err := cache.GetArticles(&rss.Feed{URL: server.URL, InsecureSkipVerify: true}, true)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("expected the feed to be fetched without verifying the certificate, got %v", err)
+	}
+
+	if _, err = cache.FetchRaw(&rss.Feed{URL: server.URL}); err == nil {
+		t.Fatal("expected the raw feed to verify the certificate")
+	}
+}
+
+// fakeFetcher returns the same body for every url
+type fakeFetcher []byte
+
+// Fetch fulfills the Fetcher interface
+func (f fakeFetcher) Fetch(_ string, _ Options) ([]byte, error) {
+	return f, nil
+}
+
+// TestCacheRegisterFetcher if we get an error then the feeds with a custom scheme don't use their fetcher
+func TestCacheRegisterFetcher(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+		`<item><title>Article</title><link>test://example.com/article</link></item></channel></rss>`
+	RegisterFetcher("test", fakeFetcher(feed))
+	defer delete(fetchers, "test")
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles, err := cache.GetArticles(&rss.Feed{URL: "test://example.com/feed"}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if len(articles) != 1 || articles[0].Title != "Article" {
+		t.Fatalf("expected the article from the fetcher, got %v", articles)
+	}
+}
+
+// TestCacheGemtext if we get an error then a subscribed gemtext page isn't converted to a feed
+func TestCacheGemtext(t *testing.T) {
+	page, err := url.Parse("gemini://example.com/gemlog/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gemtext := "# My gemlog\n\nSome text\n=> about.gmi About me\n" +
+		"=> 2024-01-02-second.gmi 2024-01-02 - Second post\n=> gemini://other.org/first.gmi 2023-12-30 First post\n"
+	data, err := gemtextToAtom(page, []byte(gemtext))
+	if err != nil {
+		t.Fatalf("couldn't convert the gemtext: %v", err)
+	}
+
+	feed, err := gofeed.NewParser().Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("couldn't parse the converted feed: %v", err)
+	}
+
+	if feed.Title != "My gemlog" {
+		t.Errorf("incorrect title, expected My gemlog, got %s", feed.Title)
+	}
+
+	if len(feed.Items) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(feed.Items))
+	}
+
+	if feed.Items[0].Title != "Second post" || feed.Items[0].Link != "gemini://example.com/gemlog/2024-01-02-second.gmi" {
+		t.Errorf("incorrect first entry, got %q %q", feed.Items[0].Title, feed.Items[0].Link)
+	}
+
+	if feed.Items[1].PublishedParsed == nil || feed.Items[1].PublishedParsed.Day() != 30 {
+		t.Errorf("incorrect date of the second entry, got %v", feed.Items[1].PublishedParsed)
+	}
+}
+
+// TestCacheMislabeledEncoding if we get an error then a feed with a wrong encoding declaration isn't recovered
+func TestCacheMislabeledEncoding(t *testing.T) {
+	feed, err := os.ReadFile("../../test/data/mislabeled_encoding.xml")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(feed)
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles, err := cache.GetArticles(&rss.Feed{URL: server.URL}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if len(articles) != 1 {
+		t.Fatalf("expected 1 article, got %d", len(articles))
+	}
+
+	if articles[0].Title != "Crème brûlée – the recipe" {
+		t.Errorf("incorrect title, got %q", articles[0].Title)
+	}
+
+	if articles[0].Description != "Naïve “quotes” and a price of 5€" {
+		t.Errorf("incorrect description, got %q", articles[0].Description)
+	}
+
+	if encoding := cache.Content[server.URL].Encoding; encoding != "windows-1252" {
+		t.Errorf("expected the entry to be decoded as windows-1252, got %q", encoding)
+	}
+
+	if _, encoding := fixEncoding([]byte(`<?xml version="1.0" encoding="UTF-8"?><rss></rss>`)); encoding != "utf-8" {
+		t.Errorf("expected valid utf-8 to be kept, got %q", encoding)
+	}
+}
+
+// TestCacheMinWords if we get an error then the link-only posts aren't filtered out
+func TestCacheMinWords(t *testing.T) {
+	feed, err := os.ReadFile("../../test/data/short_items.xml")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(feed)
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles, err := cache.GetArticles(&rss.Feed{URL: server.URL}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if len(articles) != 3 {
+		t.Fatalf("expected every article without a minimum word count, got %d", len(articles))
+	}
+
+	articles, err = cache.GetArticles(&rss.Feed{URL: server.URL, MinWords: 20, BlacklistWords: []string{"episode"}}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if len(articles) != 1 || articles[0].Title != "A proper article" {
+		t.Fatalf("expected only the proper article to be kept, got %v", articles)
+	}
+
+	articles, err = cache.GetArticles(&rss.Feed{URL: server.URL, MinWords: 20}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if len(articles) != 2 || articles[1].Title != "Episode 12" {
+		t.Errorf("expected the short item with an enclosure to be kept, got %d articles", len(articles))
+	}
+}
+
+// TestCacheArticleID if we get an error then the articles of a feed with missing or duplicate guids
+// and links don't get a stable identity of their own, or the identity depends on the other articles
+func TestCacheArticleID(t *testing.T) {
+	feed, err := os.ReadFile("../../test/data/duplicate_guids.xml")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture %v", err)
+	}
+
+	body := feed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles, err := cache.GetArticles(&rss.Feed{URL: server.URL}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	ids := make(map[string]string, len(articles))
+	owners := make(map[string]string, len(articles))
+	for i := range articles {
+		id := ArticleID(&articles[i])
+		if other, ok := owners[id]; ok {
+			t.Fatalf("expected %q and %q to have different identities, both got %q", other, articles[i].Title, id)
+		}
+
+		owners[id] = articles[i].Title
+		ids[articles[i].Title] = id
+	}
+
+	if len(ids) != 6 {
+		t.Fatalf("expected every article of the fixture to be identified, got %v", ids)
+	}
+
+	if ids["Well-behaved article"] != "guid:https://example.com/well-behaved" {
+		t.Errorf("expected an article with a guid to be identified by it, got %v", ids)
+	}
+
+	if ids["Same page, own guid"] != "guid:own-guid" {
+		t.Errorf("expected an article with a shared link to be identified by its guid, got %v", ids)
+	}
+
+	if id := ids["No link or guid"]; !strings.HasPrefix(id, "item:") || id == ids["No link or guid either"] {
+		t.Errorf("expected the articles without a guid to be told apart by their title and date, got %v", ids)
+	}
+
+	if id := ids["Monday update"]; !strings.HasPrefix(id, "item:") || id == ids["Tuesday update"] {
+		t.Errorf("expected the articles with a repeated guid to be told apart by their title and date, got %v", ids)
+	}
+
+	again, err := cache.GetArticles(&rss.Feed{URL: server.URL}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	for i := range again {
+		if id := ArticleID(&again[i]); id != ids[again[i].Title] {
+			t.Errorf("expected the identity of %q to be stable, got %q", again[i].Title, id)
+		}
+	}
+
+	updates, err := NewReadStatus(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the read status %v", err)
+	}
+
+	updates.MarkAsRead(ids["Monday update"])
+	for i := range again {
+		if read := updates.IsArticleRead(&again[i]); read != (again[i].Title == "Monday update") {
+			t.Errorf("expected only the Monday update to be read, %q is read: %v", again[i].Title, read)
+		}
+	}
+
+	// Another article with the same link doesn't change the identity of the well-behaved one
+	body = []byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Careless feed</title>` +
+		`<item><title>Well-behaved article</title><link>https://example.com/well-behaved</link>` +
+		`<guid>https://example.com/well-behaved</guid></item><item><title>Copy</title>` +
+		`<link>https://example.com/well-behaved</link></item></channel></rss>`)
+	refetched, err := cache.GetArticles(&rss.Feed{URL: server.URL}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	for i := range refetched {
+		if refetched[i].Title == "Well-behaved article" && ArticleID(&refetched[i]) != ids["Well-behaved article"] {
+			t.Errorf("expected the identity to be stable, got %q", ArticleID(&refetched[i]))
+		}
+	}
+
+	readStatus, err := NewReadStatus(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the read status %v", err)
+	}
+
+	// The read status from before the articles were identified by their guid
+	readStatus.MarkAsRead("https://example.com/well-behaved")
+	for i := range articles {
+		read := readStatus.IsArticleRead(&articles[i])
+		if read != (articles[i].Title == "Well-behaved article") {
+			t.Errorf("expected only the well-behaved article to be read, %q is read: %v", articles[i].Title, read)
+		}
+	}
+
+	if readStatus.IsRead("https://example.com/well-behaved") || !readStatus.IsRead(ids["Well-behaved article"]) {
+		t.Error("expected the read status to be moved from the link to the guid")
+	}
+
+	readStatus.MarkAsUnread(ids["Well-behaved article"])
+	for i := range refetched {
+		if readStatus.IsArticleRead(&refetched[i]) {
+			t.Errorf("expected the link to be looked up only once, %q is read", refetched[i].Title)
+		}
+	}
+}
+
+// TestCacheStripBoilerplate if we get an error then the global and the feed boilerplate aren't both
+// stripped from the articles
+func TestCacheStripBoilerplate(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title><item><title>Article</title>` +
+		`<link>https://example.com/article</link><description><![CDATA[<p><img src="logo.png"></p>` +
+		`<p>Read on our site</p><p>The article.</p>]]></description></item></channel></rss>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(feed))
+	}))
+	defer server.Close()
+
+	options := DefaultOptions
+	options.StripSelectors = []string{"img"}
+	cache, err := NewWithOptions(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles, err := cache.GetArticles(&rss.Feed{URL: server.URL}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if len(articles) != 1 || !strings.HasPrefix(articles[0].Description, "<p>Read on our site</p>") {
+		t.Fatalf("expected only the logo to be stripped, got %v", articles)
+	}
+
+	articles, err = cache.GetArticles(&rss.Feed{URL: server.URL, StripPatterns: []string{"^Read on"}}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if len(articles) != 1 || articles[0].Description != "<p>The article.</p>" {
+		t.Errorf("expected the logo and the link to be stripped, got %v", articles)
+	}
+}
+
+// TestCacheFeedAuthor if we get an error then the articles without an author don't get the author of
+// the feed
+func TestCacheFeedAuthor(t *testing.T) {
+	feed := `<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom"><title>Test</title>` +
+		`<author><name>Feed Author</name></author>` +
+		`<entry><title>Own</title><id>1</id><author><name>Guest</name></author></entry>` +
+		`<entry><title>Inherited</title><id>2</id></entry></feed>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(feed))
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles, err := cache.GetArticles(&rss.Feed{URL: server.URL}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	authors := make(map[string]string, len(articles))
+	for i := range articles {
+		authors[articles[i].Title] = rss.ItemAuthor(&articles[i])
+	}
+
+	if authors["Own"] != "Guest" || authors["Inherited"] != "Feed Author" {
+		t.Errorf("expected the feed author only for the article without one, got %v", authors)
+	}
+}
+
+// TestCacheInMemory if we get an error then the in-memory cache touches the disk or doesn't cache the
+// feeds within the session
+func TestCacheInMemory(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+			`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "cache.json"), []byte("not json"), 0600); err != nil {
+		t.Fatalf("couldn't write the cache file %v", err)
+	}
+
+	t.Setenv(InMemoryEnv, "1")
+	cache, err := New(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if err = cache.Load(); err != nil {
+		t.Fatalf("expected the cache file to be ignored, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err = cache.GetArticles(&rss.Feed{URL: server.URL}, false); err != nil {
+			t.Fatalf("couldn't get articles: %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected the feed to be cached within the session, fetched %d times", requests)
+	}
+
+	write, err := cache.Snapshot()
+	if err != nil || write() != nil || cache.Save() != nil {
+		t.Fatalf("expected saving to do nothing, got %v", err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dir, "cache.json")); string(data) != "not json" {
+		t.Errorf("expected the cache file to be left alone, got %q", data)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "articles")); !os.IsNotExist(err) {
+		t.Errorf("expected no article files, got %v", err)
+	}
+
+	readStatus, err := NewReadStatus(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the read status %v", err)
+	}
+
+	readStatus.MarkAsRead("https://example.com/article")
+	if err = readStatus.Save(); err != nil || readStatus.Snapshot()() != nil {
+		t.Fatalf("expected saving the read status to do nothing, got %v", err)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "read_status")); !os.IsNotExist(err) {
+		t.Errorf("expected no read status file, got %v", err)
+	}
+}
+
+// TestCacheInMemoryOptions if we get an error then the read status is written to disk when the in-memory
+// mode is selected through the options instead of the environment
+func TestCacheInMemoryOptions(t *testing.T) {
+	dir := t.TempDir()
+	options := DefaultOptions
+	options.InMemory = true
+	readStatus, err := NewReadStatusWithOptions(dir, options)
+	if err != nil {
+		t.Fatalf("couldn't create the read status %v", err)
+	}
+
+	readStatus.MarkAsRead("https://example.com/article")
+	if err = readStatus.Save(); err != nil || readStatus.Snapshot()() != nil {
+		t.Fatalf("expected saving the read status to do nothing, got %v", err)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "read_status")); !os.IsNotExist(err) {
+		t.Errorf("expected no read status file, got %v", err)
+	}
+
+	onDisk, err := NewReadStatusWithOptions(dir, DefaultOptions)
+	if err != nil {
+		t.Fatalf("couldn't create the read status %v", err)
+	}
+
+	onDisk.MarkAsRead("https://example.com/article")
+	if err = onDisk.Save(); err != nil {
+		t.Fatalf("couldn't save the read status %v", err)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "read_status")); err != nil {
+		t.Errorf("expected the read status file without the in-memory option, got %v", err)
+	}
+}
+
+// TestCacheReadLater if we get an error then the read later queue doesn't keep its order or persist
+func TestCacheReadLater(t *testing.T) {
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	cache.AddToReadLater(gofeed.Item{Title: "second", Link: "https://example.com/2"})
+	cache.AddToReadLater(gofeed.Item{Title: "first", Link: "https://example.com/1"})
+	if cache.AddToReadLater(gofeed.Item{Title: "second", Link: "https://example.com/2"}) {
+		t.Fatal("expected a queued article not to be added twice")
+	}
+
+	if err = cache.Save(); err != nil {
+		t.Fatalf("couldn't save the cache %v", err)
+	}
+
+	if err = cache.Load(); err != nil {
+		t.Fatalf("couldn't load the cache %v", err)
+	}
+
+	queue := cache.GetReadLater()
+	if len(queue) != 2 || queue[0].Title != "second" || queue[1].Title != "first" {
+		t.Fatalf("expected the queue in the order the articles were added, got %v", queue)
+	}
+
+	if err = cache.RemoveFromReadLater(0); err != nil {
+		t.Fatalf("couldn't remove from the queue %v", err)
+	}
+
+	if queue = cache.GetReadLater(); len(queue) != 1 || queue[0].Title != "first" {
+		t.Fatalf("expected only the first article to remain, got %v", queue)
+	}
+
+	if err = cache.RemoveFromReadLater(1); err == nil {
+		t.Fatal("expected an error when removing an index out of range")
+	}
+}
+
+// TestCacheHistory if we get an error the recently opened feeds aren't ordered, capped or kept between saves
+func TestCacheHistory(t *testing.T) {
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	for _, name := range []string{"first", "second", "third", "first"} {
+		cache.AddToHistory(name, 3)
+	}
+
+	cache.AddToHistory("fourth", 3)
+	if err = cache.Save(); err != nil {
+		t.Fatalf("couldn't save the cache %v", err)
+	}
+
+	if err = cache.Load(); err != nil {
+		t.Fatalf("couldn't load the cache %v", err)
+	}
+
+	recent := cache.RecentFeeds()
+	if strings.Join(recent, ",") != "fourth,first,third" {
+		t.Fatalf("expected the most recent feeds first without duplicates, got %v", recent)
+	}
+
+	cache.AddToHistory("fifth", 0)
+	if len(cache.RecentFeeds()) != 0 {
+		t.Fatalf("expected a limit of 0 to disable the history, got %v", cache.RecentFeeds())
+	}
+}
+
+// TestCacheLazyLoad if we get an error then the articles aren't read on first access or aren't kept between saves
+func TestCacheLazyLoad(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := New(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles := SortableArticles{{Title: "Lazy", Link: "https://example.com/lazy"}}
+	cache.Content["https://example.com/feed"] = Entry{Expire: time.Now().Add(time.Hour), Articles: articles}
+	cache.Content["https://example.com/gone"] = Entry{Expire: time.Now().Add(time.Hour), Articles: articles}
+	if err = cache.Save(); err != nil {
+		t.Fatalf("couldn't save the cache %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		loaded, err := New(dir)
+		if err != nil {
+			t.Fatalf("couldn't create the cache %v", err)
+		}
+
+		if err = loaded.Load(); err != nil {
+			t.Fatalf("couldn't load the cache %v", err)
+		}
+
+		if loaded.Content["https://example.com/feed"].Articles != nil {
+			t.Fatal("expected the articles not to be read before they are needed")
+		}
+
+		if items := loaded.GetCachedArticles("https://example.com/feed"); len(items) != 1 || items[0].Title != "Lazy" {
+			t.Fatalf("expected the cached articles to be read on first access, got %v", items)
+		}
+
+		// Saving again removes the articles of the feeds which are no longer cached
+		delete(loaded.Content, "https://example.com/gone")
+		if err = loaded.Save(); err != nil {
+			t.Fatalf("couldn't save the cache %v", err)
+		}
+	}
+
+	files, err := os.ReadDir(filepath.Join(dir, "articles"))
+	if err != nil {
+		t.Fatalf("couldn't read the articles directory %v", err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("expected the articles of the removed feed to be deleted, got %d files", len(files))
+	}
+}
+
+// TestCacheUnknownFields if we get an error then the fields written by a newer version are lost on save
+func TestCacheUnknownFields(t *testing.T) {
+	dir := t.TempDir()
+	expire := time.Now().Add(time.Hour).Format(time.RFC3339)
+	data := `{"content":{"https://example.com/feed":{"expire":"` + expire + `","articles":[],"starred":true}},` +
+		`"full_text":{},"downloaded":[],"read_later":[],"sync":{"device":"laptop"}}`
+	if err := os.WriteFile(filepath.Join(dir, "cache.json"), []byte(data), 0600); err != nil {
+		t.Fatalf("couldn't write the cache file %v", err)
+	}
+
+	cache, err := New(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if err = cache.Load(); err != nil {
+		t.Fatalf("couldn't load the cache %v", err)
+	}
+
+	if err = cache.Save(); err != nil {
+		t.Fatalf("couldn't save the cache %v", err)
+	}
+
+	saved, err := os.ReadFile(filepath.Join(dir, "cache.json"))
+	if err != nil {
+		t.Fatalf("couldn't read the cache file %v", err)
+	}
+
+	var decoded struct {
+		Content map[string]map[string]json.RawMessage `json:"content"`
+		Sync    json.RawMessage                       `json:"sync"`
+	}
+	if err = json.Unmarshal(saved, &decoded); err != nil {
+		t.Fatalf("couldn't decode the saved cache %v", err)
+	}
+
+	if string(decoded.Sync) != `{"device":"laptop"}` {
+		t.Errorf("expected the unknown cache field to be kept, got %s", decoded.Sync)
+	}
+
+	entry := decoded.Content["https://example.com/feed"]
+	if string(entry["starred"]) != "true" {
+		t.Errorf("expected the unknown entry field to be kept, got %v", entry)
+	}
+
+	if _, ok := entry["articles"]; ok {
+		t.Error("expected the articles to be saved in their own file")
+	}
+}
+
+// TestCacheSnapshot if we get an error then the snapshot loses the reading state or keeps the entries
+// whose articles aren't saved
+func TestCacheSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := New(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles := SortableArticles{{Title: "Saved", Link: "https://example.com/saved"}}
+	cache.Content["https://example.com/saved"] = Entry{Expire: time.Now().Add(time.Hour), Articles: articles}
+	if err = cache.Save(); err != nil {
+		t.Fatalf("couldn't save the cache %v", err)
+	}
+
+	if err = cache.Load(); err != nil {
+		t.Fatalf("couldn't load the cache %v", err)
+	}
+
+	cache.Content["https://example.com/fresh"] = Entry{Expire: time.Now().Add(time.Hour), Articles: articles}
+	cache.AddToReadLater(gofeed.Item{Title: "Later", Link: "https://example.com/later"})
+	write, err := cache.Snapshot()
+	if err != nil {
+		t.Fatalf("couldn't take the snapshot %v", err)
+	}
+
+	// Changes after taking the snapshot aren't written
+	cache.AddToReadLater(gofeed.Item{Title: "Too late", Link: "https://example.com/too-late"})
+	if err = write(); err != nil {
+		t.Fatalf("couldn't write the snapshot %v", err)
+	}
+
+	loaded, err := New(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if err = loaded.Load(); err != nil {
+		t.Fatalf("couldn't load the cache %v", err)
+	}
+
+	if _, ok := loaded.Content["https://example.com/fresh"]; ok {
+		t.Error("expected the entry without saved articles to be left out")
+	}
+
+	if items := loaded.GetCachedArticles("https://example.com/saved"); len(items) != 1 {
+		t.Errorf("expected the saved entry to be kept, got %v", items)
+	}
+
+	if queue := loaded.GetReadLater(); len(queue) != 1 || queue[0].Title != "Later" {
+		t.Errorf("expected the read later queue from the snapshot, got %v", queue)
+	}
+}
+
+// BenchmarkCacheLoad measures the startup cost of loading a large cache
+func BenchmarkCacheLoad(b *testing.B) {
+	dir := b.TempDir()
+	cache, err := New(dir)
+	if err != nil {
+		b.Fatalf("couldn't create the cache %v", err)
+	}
+
+	published := time.Now()
+	for i := 0; i < 200; i++ {
+		articles := make(SortableArticles, 50)
+		for j := range articles {
+			articles[j] = gofeed.Item{
+				Title:           fmt.Sprintf("Article %d", j),
+				Link:            fmt.Sprintf("https://example.com/%d/%d", i, j),
+				Description:     strings.Repeat("A short description. ", 20),
+				Content:         strings.Repeat("<p>Some article content.</p>", 100),
+				PublishedParsed: &published,
+			}
+		}
+
+		url := fmt.Sprintf("https://example.com/%d/feed", i)
+		cache.Content[url] = Entry{Expire: time.Now().Add(time.Hour), Articles: articles}
+	}
+
+	if err = cache.Save(); err != nil {
+		b.Fatalf("couldn't save the cache %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		loaded, err := New(dir)
+		if err != nil {
+			b.Fatalf("couldn't create the cache %v", err)
+		}
+
+		if err = loaded.Load(); err != nil {
+			b.Fatalf("couldn't load the cache %v", err)
+		}
+
+		// The first feed opened after startup
+		if len(loaded.GetCachedArticles("https://example.com/0/feed")) != 50 {
+			b.Fatal("expected the articles of the first feed")
+		}
+	}
+}
+
+// TestCacheFeedDetails if we get an error then the description and the link of a feed aren't remembered
+func TestCacheFeedDetails(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/described", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+			`<link>https://example.com</link><description> News about examples </description>` +
+			`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`))
+	})
+	mux.HandleFunc("/bare", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+			`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	dir := t.TempDir()
+	cache, err := New(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	for _, path := range []string{"/described", "/bare"} {
+		if _, err = cache.GetArticles(&rss.Feed{URL: server.URL + path}, true); err != nil {
+			t.Fatalf("couldn't get articles: %v", err)
+		}
+	}
+
+	if err = cache.Save(); err != nil {
+		t.Fatalf("couldn't save the cache %v", err)
+	}
+
+	loaded, err := New(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if err = loaded.Load(); err != nil {
+		t.Fatalf("couldn't load the cache %v", err)
+	}
+
+	desc, link := loaded.FeedDetails(server.URL + "/described")
+	if desc != "News about examples" || link != "https://example.com" {
+		t.Errorf("incorrect feed details, got %q and %q", desc, link)
+	}
+
+	if desc, link = loaded.FeedDetails(server.URL + "/bare"); desc != "" || link != "" {
+		t.Errorf("expected no feed details, got %q and %q", desc, link)
+	}
+
+	if desc, link = loaded.FeedDetails("https://example.com/unknown"); desc != "" || link != "" {
+		t.Errorf("expected no feed details for an unknown feed, got %q and %q", desc, link)
+	}
+}
+
+// TestCacheNotFeed if we get an error then html pages returned instead of feeds aren't recognized
+func TestCacheNotFeed(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/blog", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<!DOCTYPE html><html><head><title>Blog</title>` +
+			`<link rel="alternate" type="application/rss+xml" href="/blog/rss.xml">` +
+			`<link rel="stylesheet" href="/style.css"></head><body>Posts</body></html>`))
+	})
+	mux.HandleFunc("/challenge", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`<!DOCTYPE html><html><head><title>Just a moment...</title></head>` +
+			`<body><div id="cf-browser-verification"></div></body></html>`))
+	})
+	mux.HandleFunc("/html-feed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<!-- generated --><rss version="2.0"><channel><title>Test</title>` +
+			`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	var notFeed NotFeedError
+	_, err = cache.GetArticles(&rss.Feed{URL: server.URL + "/blog"}, true)
+	if !errors.As(err, &notFeed) || notFeed.Blocked {
+		t.Fatalf("expected a not a feed error, got %v", err)
+	}
+
+	if len(notFeed.Discovered) != 1 || notFeed.Discovered[0] != server.URL+"/blog/rss.xml" {
+		t.Errorf("expected the linked feed to be discovered, got %v", notFeed.Discovered)
+	}
+
+	_, err = cache.GetArticles(&rss.Feed{URL: server.URL + "/challenge"}, true)
+	if !errors.As(err, &notFeed) || !notFeed.Blocked {
+		t.Errorf("expected a blocked error, got %v", err)
+	}
+
+	if _, err = cache.GetArticles(&rss.Feed{URL: server.URL + "/html-feed"}, true); err != nil {
+		t.Errorf("expected a feed served as text/html to be parsed, got %v", err)
+	}
+}
+
+// TestCachePruneDownloaded if we get an error then the retention policy isn't applied to the downloaded articles
+func TestCachePruneDownloaded(t *testing.T) {
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	for i := 1; i <= 4; i++ {
+		cache.AddToDownloaded(gofeed.Item{Title: fmt.Sprint(i), Link: fmt.Sprintf("https://example.com/%d", i)})
+		cache.DownloadedAt[fmt.Sprintf("https://example.com/%d", i)] = time.Now().Add(-time.Duration(5-i) * 24 * time.Hour)
+	}
+
+	// Articles downloaded before the times were recorded are treated as new
+	cache.Downloaded = append(cache.Downloaded, gofeed.Item{Title: "legacy", Link: "https://example.com/legacy"})
+	cache.AddToReadLater(gofeed.Item{Title: "1", Link: "https://example.com/1"})
+
+	if removed := cache.PruneDownloaded(RetentionPolicy{}); removed != 0 {
+		t.Fatalf("expected an empty policy to keep everything, removed %d", removed)
+	}
+
+	if removed := cache.PruneDownloaded(RetentionPolicy{MaxAge: 60 * time.Hour}); removed != 1 {
+		t.Fatalf("expected the article downloaded 3 days ago to be removed, removed %d", removed)
+	}
+
+	if removed := cache.PruneDownloaded(RetentionPolicy{MaxCount: 2}); removed != 1 {
+		t.Fatalf("expected one article over the limit to be removed, removed %d", removed)
+	}
+
+	titles := make([]string, len(cache.Downloaded))
+	for i, item := range cache.Downloaded {
+		titles[i] = item.Title
+	}
+
+	if strings.Join(titles, ",") != "legacy,4,1" {
+		t.Errorf("expected the newest and the queued articles to be kept, got %v", titles)
+	}
+
+	if _, ok := cache.DownloadedAt["https://example.com/3"]; ok {
+		t.Error("expected the download time of a removed article to be forgotten")
+	}
+}
+
+// TestCacheState if we get an error then the reading state isn't exported or merged correctly
+func TestCacheState(t *testing.T) {
+	dir := t.TempDir()
+	first, err := New(filepath.Join(dir, "first"))
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	firstStatus, err := NewReadStatus(filepath.Join(dir, "first"))
+	if err != nil {
+		t.Fatalf("couldn't create the read status %v", err)
+	}
+
+	firstStatus.MarkAsRead("https://example.com/1")
+	first.AddToReadLater(gofeed.Item{Title: "shared", Link: "https://example.com/shared"})
+	first.AddToReadLater(gofeed.Item{Title: "first", Link: "https://example.com/first"})
+
+	path := filepath.Join(dir, "state.json")
+	if err = first.ExportState(path, firstStatus); err != nil {
+		t.Fatalf("couldn't export the state %v", err)
+	}
+
+	second, err := New(filepath.Join(dir, "second"))
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	secondStatus, err := NewReadStatus(filepath.Join(dir, "second"))
+	if err != nil {
+		t.Fatalf("couldn't create the read status %v", err)
+	}
+
+	secondStatus.MarkAsRead("https://example.com/2")
+	second.AddToReadLater(gofeed.Item{Title: "second", Link: "https://example.com/second"})
+	second.AddToReadLater(gofeed.Item{Title: "shared", Link: "https://example.com/shared"})
+
+	if err = second.ImportState(path, secondStatus); err != nil {
+		t.Fatalf("couldn't import the state %v", err)
+	}
+
+	if !secondStatus.IsRead("https://example.com/1") || !secondStatus.IsRead("https://example.com/2") {
+		t.Error("expected the read articles of both machines to be read")
+	}
+
+	queue := second.GetReadLater()
+	titles := make([]string, len(queue))
+	for i, item := range queue {
+		titles[i] = item.Title
+	}
+
+	if strings.Join(titles, ",") != "second,shared,first" {
+		t.Errorf("expected the queues to be merged without duplicates, got %v", titles)
+	}
+
+	if err = second.ImportState(filepath.Join(dir, "non-existent.json"), secondStatus); err == nil {
+		t.Error("expected an error for a non-existent state file")
+	}
+}
+
+// TestCachePositions if we get an error then the reading positions aren't kept between sessions
+func TestCachePositions(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := New(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if _, ok := cache.Position("guid"); ok {
+		t.Fatal("expected no position for an article which wasn't read")
+	}
+
+	cache.SavePosition("guid", 42)
+	cache.SavePosition("top", 10)
+	cache.SavePosition("top", 0)
+	cache.Positions["old"] = PositionEntry{time.Now().Add(-time.Hour), 7}
+	if err = cache.Save(); err != nil {
+		t.Fatalf("couldn't save the cache %v", err)
+	}
+
+	loaded, err := New(dir)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if err = loaded.Load(); err != nil {
+		t.Fatalf("couldn't load the cache %v", err)
+	}
+
+	if offset, ok := loaded.Position("guid"); !ok || offset != 42 {
+		t.Errorf("expected the position 42 to be restored, got %d", offset)
+	}
+
+	if _, ok := loaded.Position("top"); ok {
+		t.Error("expected scrolling back to the top to forget the position")
+	}
+
+	if _, ok := loaded.Positions["old"]; ok {
+		t.Error("expected the expired position to be removed")
+	}
+}
+
+// TestCacheFreshness if we get an error then the caching headers sent by the server aren't respected
+func TestCacheFreshness(t *testing.T) {
+	feed, err := os.ReadFile("../../test/data/short_items.xml")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture %v", err)
+	}
+
+	mux := http.NewServeMux()
+	handle := func(path, name, value string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+			if name != "" {
+				w.Header().Set(name, value)
+			}
+
+			_, _ = w.Write(feed)
+		})
+	}
+
+	handle("/hour", "Cache-Control", "public, max-age=3600")
+	handle("/seconds", "Cache-Control", "max-age=5")
+	handle("/years", "Cache-Control", "max-age=315360000")
+	handle("/expires", "Expires", time.Now().Add(2*time.Hour).UTC().Format(http.TimeFormat))
+	handle("/none", "", "")
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := DefaultOptions
+	options.MinFreshness = 15 * time.Minute
+	options.MaxFreshness = 48 * time.Hour
+	cache, err := NewWithOptions(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	expected := map[string]time.Duration{
+		"/hour":    time.Hour,
+		"/seconds": 15 * time.Minute,
+		"/years":   48 * time.Hour,
+		"/expires": 2 * time.Hour,
+		"/none":    DefaultCacheDuration,
+	}
+
+	for path, freshness := range expected {
+		url := server.URL + path
+		if _, err = cache.GetArticles(&rss.Feed{URL: url}, true); err != nil {
+			t.Fatalf("couldn't get articles: %v", err)
+		}
+
+		if diff := time.Until(cache.Content[url].Expire) - freshness; diff > time.Minute || diff < -time.Minute {
+			t.Errorf("expected %s to expire in %s, expires in %s", path, freshness, time.Until(cache.Content[url].Expire))
+		}
+	}
+}
+
+// TestCacheLoadOlder if we get the older pages of a paged feed until the page or the article limit is reached
+func TestCacheLoadOlder(t *testing.T) {
+	page := func(next string, ids ...string) string {
+		var b strings.Builder
+		b.WriteString(`<?xml version="1.0" encoding="utf-8"?><feed xmlns="http://www.w3.org/2005/Atom"><title>Paged</title>`)
+		if next != "" {
+			fmt.Fprintf(&b, `<link rel="next" href="%s"/>`, next)
+		}
+
+		for _, id := range ids {
+			fmt.Fprintf(&b, `<entry><id>%s</id><title>Article %s</title><link href="https://example.com/%s"/></entry>`, id, id, id)
+		}
+
+		b.WriteString("</feed>")
+		return b.String()
+	}
+
+	pages := map[string]string{
+		"/feed":  page("/page2", "6", "5"),
+		"/page2": page("page3", "5", "4"),
+		"/page3": page("", "3", "2", "1"),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	options := DefaultOptions
+	options.ArchivePages = 5
+	options.MaxItems = 5
+	cache, err := NewWithOptions(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	feed := &rss.Feed{URL: server.URL + "/feed"}
+	if _, err = cache.GetArticles(feed, true); err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if !cache.HasOlder(feed.URL) {
+		t.Fatal("expected the feed to have older articles")
+	}
+
+	if next := cache.Content[feed.URL].Next; next != server.URL+"/page2" {
+		t.Errorf("expected the next page to be resolved against the feed url, got %q", next)
+	}
+
+	articles, added, err := cache.LoadOlder(feed)
+	if err != nil {
+		t.Fatalf("couldn't load the older articles: %v", err)
+	}
+
+	if added != 1 || len(articles) != 3 {
+		t.Errorf("expected the duplicate article to be skipped, added %d of %d", added, len(articles))
+	}
+
+	articles, added, err = cache.LoadOlder(feed)
+	if err != nil {
+		t.Fatalf("couldn't load the older articles: %v", err)
+	}
+
+	if added != 2 || len(articles) != 5 {
+		t.Errorf("expected the item limit to stop the page, added %d of %d", added, len(articles))
+	}
+
+	if cache.HasOlder(feed.URL) {
+		t.Error("expected the last page to have no older articles")
+	}
+
+	if _, _, err = cache.LoadOlder(feed); !errors.Is(err, ErrNoOlderArticles) {
+		t.Errorf("expected ErrNoOlderArticles, got %v", err)
+	}
+
+	// The archive pages are opt-in
+	cache.options.ArchivePages = 0
+	if _, err = cache.GetArticles(feed, true); err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if cache.HasOlder(feed.URL) {
+		t.Error("expected the older articles to be disabled")
+	}
+}
+
+// TestCacheDownloadFile if we get an error the files aren't downloaded, resumed or protected from being overwritten
+func TestCacheDownloadFile(t *testing.T) {
+	episode := bytes.Repeat([]byte("podcast "), 4096)
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "episode.mp3", time.Time{}, bytes.NewReader(episode))
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "podcasts", "Episode 1.mp3")
+	var written, total int64
+	progress := func(w, tot int64) { written, total = w, tot }
+	if err = cache.DownloadFile(server.URL, path, false, progress); err != nil {
+		t.Fatalf("couldn't download the file %v", err)
+	}
+
+	if data, _ := os.ReadFile(path); !bytes.Equal(data, episode) {
+		t.Errorf("expected the whole file to be downloaded, got %d bytes", len(data))
+	}
+
+	if written != int64(len(episode)) || total != int64(len(episode)) {
+		t.Errorf("expected the progress to reach %d bytes, got %d of %d", len(episode), written, total)
+	}
+
+	if err = cache.DownloadFile(server.URL, path, false, nil); !errors.Is(err, ErrAlreadyDownloaded) {
+		t.Errorf("expected ErrAlreadyDownloaded, got %v", err)
+	}
+
+	// A partial file left by an interrupted download is resumed
+	if err = os.Remove(path); err != nil {
+		t.Fatalf("couldn't remove the file %v", err)
+	}
+
+	if err = os.WriteFile(path+partSuffix, episode[:1000], 0644); err != nil {
+		t.Fatalf("couldn't write the partial file %v", err)
+	}
+
+	if err = cache.DownloadFile(server.URL, path, true, nil); err != nil {
+		t.Fatalf("couldn't resume the download %v", err)
+	}
+
+	if data, _ := os.ReadFile(path); !bytes.Equal(data, episode) {
+		t.Errorf("expected the resumed file to be complete, got %d bytes", len(data))
+	}
+
+	if last := ranges[len(ranges)-1]; last != "bytes=1000-" {
+		t.Errorf("expected the download to be resumed with a range, got %q", last)
+	}
+
+	if _, err = os.Stat(path + partSuffix); !os.IsNotExist(err) {
+		t.Errorf("expected the partial file to be gone, got %v", err)
+	}
+}
+
+// TestCacheRetryFeeds if we get an error the feeds which failed in a bulk fetch aren't reported or retried
+func TestCacheRetryFeeds(t *testing.T) {
+	feed, err := os.ReadFile("../../test/data/short_items.xml")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture %v", err)
+	}
+
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing && r.URL.Path == "/flaky" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write(feed)
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	feeds := []*rss.Feed{{URL: server.URL + "/stable"}, {URL: server.URL + "/flaky"}}
+	articles, failed := cache.GetArticlesBulkFailed(feeds, true)
+	if len(articles) != 3 || len(failed) != 1 || failed[0] != feeds[1] {
+		t.Fatalf("expected the articles of the stable feed and the flaky feed to fail, got %d articles and %v", len(articles), failed)
+	}
+
+	if failed = cache.RetryFeeds(failed); len(failed) != 1 {
+		t.Fatalf("expected the flaky feed to still fail, got %v", failed)
+	}
+
+	failing = false
+	if failed = cache.RetryFeeds(failed); len(failed) != 0 {
+		t.Fatalf("expected the flaky feed to recover, got %v", failed)
+	}
+
+	if cached := cache.GetCachedArticles(server.URL + "/flaky"); len(cached) != 3 {
+		t.Errorf("expected the recovered feed to be cached, got %d articles", len(cached))
+	}
+}
+
+// TestCacheGlobalFilters if we get an error the global keyword filters aren't merged with the ones of the feed
+func TestCacheGlobalFilters(t *testing.T) {
+	feed, err := os.ReadFile("../../test/data/short_items.xml")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(feed)
+	}))
+	defer server.Close()
+
+	options := DefaultOptions
+	options.BlacklistWords = []string{"episode"}
+	options.WhitelistWords = []string{"article", "link"}
+	cache, err := NewWithOptions(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	titles := func(feed *rss.Feed) []string {
+		articles, err := cache.GetArticles(feed, true)
+		if err != nil {
+			t.Fatalf("couldn't get articles: %v", err)
+		}
+
+		result := make([]string, len(articles))
+		for i := range articles {
+			result[i] = articles[i].Title
+		}
+
+		sort.Strings(result)
+		return result
+	}
+
+	if got := titles(&rss.Feed{URL: server.URL}); len(got) != 2 || got[0] != "A proper article" || got[1] != "Interesting link" {
+		t.Errorf("expected the global filters to apply, got %v", got)
+	}
+
+	// The blacklists add up
+	if got := titles(&rss.Feed{URL: server.URL, BlacklistWords: []string{"interesting"}}); len(got) != 1 || got[0] != "A proper article" {
+		t.Errorf("expected the feed blacklist to be added to the global one, got %v", got)
+	}
+
+	// The whitelist of the feed replaces the global one, the global blacklist still applies
+	if got := titles(&rss.Feed{URL: server.URL, WhitelistWords: []string{"listen"}}); len(got) != 0 {
+		t.Errorf("expected the feed whitelist to replace the global one, got %v", got)
+	}
+
+	if got := titles(&rss.Feed{URL: server.URL, WhitelistWords: []string{"worth"}}); len(got) != 1 || got[0] != "Interesting link" {
+		t.Errorf("expected only the feed whitelist to be used, got %v", got)
+	}
+}
+
+// TestCacheMarkReadBefore if we get an error the articles older than the cutoff aren't marked as read
+func TestCacheMarkReadBefore(t *testing.T) {
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	readStatus, err := NewReadStatus(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the read status %v", err)
+	}
+
+	now := time.Now()
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Hour)
+	expire := now.Add(time.Hour)
+	cache.Content["first"] = Entry{Expire: expire, Articles: SortableArticles{
+		{Link: "old", PublishedParsed: &old},
+		{Link: "recent", PublishedParsed: &recent},
+		{Link: "undated"},
+	}}
+	cache.Content["second"] = Entry{Expire: expire, Articles: SortableArticles{
+		{Link: "old updated", UpdatedParsed: &old},
+	}}
+
+	cutoff := now.Add(-24 * time.Hour)
+	if marked := cache.MarkReadBefore(cutoff, []*rss.Feed{{URL: "first"}}, readStatus); marked != 1 {
+		t.Errorf("expected 1 article to be marked in the first feed, got %d", marked)
+	}
+
+	if !readStatus.IsRead("old") || readStatus.IsRead("recent") || readStatus.IsRead("undated") || readStatus.IsRead("old updated") {
+		t.Error("expected only the old article of the first feed to be read")
+	}
+
+	if marked := cache.MarkReadBefore(cutoff, nil, readStatus); marked != 1 || !readStatus.IsRead("old updated") {
+		t.Errorf("expected only the old article of the second feed to be newly marked, got %d", marked)
+	}
+}
+
+// TestCacheLenientParse if we get an error then a feed with one malformed item loses its valid items or the
+// skipped item isn't counted
+func TestCacheLenientParse(t *testing.T) {
+	feed, err := os.ReadFile("../../test/data/broken_item.xml")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(feed)
+	}))
+	defer server.Close()
+
+	options := DefaultOptions
+	options.LenientParse = false
+	strict, err := NewWithOptions(t.TempDir(), options)
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if _, err = strict.GetArticles(&rss.Feed{URL: server.URL}, true); err == nil {
+		t.Fatal("expected the malformed feed to fail without the lenient parse")
+	}
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles, err := cache.GetArticles(&rss.Feed{URL: server.URL}, true)
+	if err != nil {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	if len(articles) != 2 || cache.SkippedItems(server.URL) != 1 {
+		t.Errorf("expected 2 articles and 1 skipped item, got %d and %d", len(articles), cache.SkippedItems(server.URL))
+	}
+
+	if desc, _ := cache.FeedDetails(server.URL); desc == "" {
+		t.Error("expected the details of the feed to be kept")
+	}
+}
+
+// TestCacheFetchStats if we get an error then the duration and the size of the fetches aren't recorded or
+// the metrics grow past the recent fetches
+func TestCacheFetchStats(t *testing.T) {
+	body := `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+		`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	if _, ok := cache.FetchStats(server.URL); ok {
+		t.Error("expected no stats before the first fetch")
+	}
+
+	for i := 0; i < maxMetrics+2; i++ {
+		if _, err = cache.GetArticles(&rss.Feed{URL: server.URL}, true); err != nil {
+			t.Fatalf("couldn't get articles: %v", err)
+		}
+	}
+
+	stats, ok := cache.FetchStats(server.URL)
+	if !ok || stats.Fetches != maxMetrics {
+		t.Fatalf("expected the last %d fetches to be kept, got %d", maxMetrics, stats.Fetches)
+	}
+
+	if stats.Last.Size != len(body) || stats.AvgSize != len(body) || stats.AvgDuration <= 0 {
+		t.Errorf("expected the size and the duration of the fetches, got %+v", stats)
+	}
+}
+
+// TestCacheCaseSensitiveFilters if we get an error then the filters of a case sensitive feed ignore the case
+// or the filters of the other feeds don't
+func TestCacheCaseSensitiveFilters(t *testing.T) {
+	feed, err := os.ReadFile("../../test/data/short_items.xml")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(feed)
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	count := func(feed *rss.Feed) int {
+		articles, err := cache.GetArticles(feed, true)
+		if err != nil {
+			t.Fatalf("couldn't get articles: %v", err)
+		}
+
+		return len(articles)
+	}
+
+	if got := count(&rss.Feed{URL: server.URL, WhitelistWords: []string{"episode"}}); got != 1 {
+		t.Errorf("expected the whitelist to ignore the case by default, got %d articles", got)
+	}
+
+	if got := count(&rss.Feed{URL: server.URL, WhitelistWords: []string{"episode"}, CaseSensitive: true}); got != 0 {
+		t.Errorf("expected the case sensitive whitelist to skip the capitalized word, got %d articles", got)
+	}
+
+	if got := count(&rss.Feed{URL: server.URL, WhitelistWords: []string{"Episode"}, CaseSensitive: true}); got != 1 {
+		t.Errorf("expected the case sensitive whitelist to match the exact word, got %d articles", got)
+	}
+
+	if got := count(&rss.Feed{URL: server.URL, BlacklistWords: []string{"INTERESTING"}}); got != 2 {
+		t.Errorf("expected the blacklist to ignore the case by default, got %d articles", got)
+	}
+
+	if got := count(&rss.Feed{URL: server.URL, BlacklistWords: []string{"INTERESTING"}, CaseSensitive: true}); got != 3 {
+		t.Errorf("expected the case sensitive blacklist to keep the article, got %d articles", got)
+	}
+}
+
+// TestCacheResolveLinks if we get an error then the relative links in the articles aren't made absolute
+// using the link of the article
+func TestCacheResolveLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+			`<link>https://example.com/blog/</link><item><title>Article</title><link>posts/1</link>` +
+			`<description><![CDATA[<p>Read <a href="../about">about me</a>.</p><img src="//cdn.example.com/a.png"/>]]>` +
+			`</description></item></channel></rss>`))
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles, err := cache.GetArticles(&rss.Feed{URL: server.URL}, true)
+	if err != nil || len(articles) != 1 {
+		t.Fatalf("couldn't get articles: %v", err)
+	}
+
+	markdown := rss.YassifyItem(&articles[0])
+	for _, want := range []string{"https://example.com/blog/about", "https://cdn.example.com/a.png"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected %s in the article, got %q", want, markdown)
+		}
+	}
+}
+
+// TestCacheFeedHeaders if we get an error the headers of a feed aren't sent, the environment variables
+// in them aren't expanded or they follow a redirect to another host
+func TestCacheFeedHeaders(t *testing.T) {
+	t.Setenv("GOREAD_TEST_KEY", "secret")
+	feedXML := `<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+		`<item><title>Article</title><link>https://example.com/1</link></item></channel></rss>`
+
+	var leaked http.Header
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Clone()
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, other.URL, http.StatusFound)
+			return
+		}
+
+		if r.Header.Get("X-Api-Key") != "secret" || r.Header.Get("Accept") != "application/rss+xml" ||
+			r.Header.Get("X-Token") != "abc$def" || r.Header.Get("X-Braced") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(feedXML))
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	headers := map[string]string{
+		"X-Api-Key": "$GOREAD_TEST_KEY",
+		"X-Braced":  "${GOREAD_TEST_KEY}",
+		"X-Token":   "abc$def",
+		"Accept":    "application/rss+xml",
+	}
+	articles, err := cache.GetArticles(&rss.Feed{URL: server.URL, Headers: headers}, true)
+	if err != nil || len(articles) != 1 {
+		t.Fatalf("expected the headers to be sent, got %v", err)
+	}
+
+	if _, err = cache.GetArticles(&rss.Feed{URL: server.URL + "/moved", Headers: headers}, true); err != nil {
+		t.Fatalf("couldn't follow the redirect: %v", err)
+	}
+
+	if leaked.Get("X-Api-Key") != "" || leaked.Get("User-Agent") != DefaultOptions.UserAgent {
+		t.Errorf("expected the headers not to be sent to another host, got %v", leaked)
+	}
+}
+
+// TestCacheConcurrentSnapshot if we get an error (with -race) the snapshot reads the maps while a fetch
+// in the background writes them
+func TestCacheConcurrentSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+			`<item><title>Article</title><link>https://example.com/article</link></item></channel></rss>`))
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			_, _ = cache.GetArticles(&rss.Feed{URL: fmt.Sprintf("%s/%d", server.URL, i)}, true)
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		cache.SavePosition(fmt.Sprint(i), i+1)
+		if _, err = cache.Snapshot(); err != nil {
+			t.Fatalf("couldn't take the snapshot %v", err)
+		}
+	}
+
+	<-done
+	if len(cache.Content) != 20 {
+		t.Errorf("expected every fetch to be cached, got %d entries", len(cache.Content))
+	}
+}
+
+// TestCacheBulkConcurrency if we get an error then the bulk fetch downloads more feeds at once than the
+// fetch concurrency allows or loses the articles and the failures of some feeds
+func TestCacheBulkConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	running, most := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		running++
+		if running > most {
+			most = running
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Test</title>` +
+			`<item><title>Article</title><link>https://example.com` + r.URL.Path + `</link></item></channel></rss>`))
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	cache.options.Concurrency = 3
+	feeds := []*rss.Feed{{URL: server.URL + "/broken"}}
+	for i := 0; i < 9; i++ {
+		feeds = append(feeds, &rss.Feed{URL: fmt.Sprintf("%s/%d", server.URL, i)})
+	}
+
+	articles, failed := cache.GetArticlesBulkFailed(feeds, true)
+	if len(articles) != 9 {
+		t.Errorf("expected an article from every working feed, got %d", len(articles))
+	}
+
+	if len(failed) != 1 || failed[0] != feeds[0] {
+		t.Errorf("expected only the broken feed to fail, got %v", failed)
+	}
+
+	if most > 3 || most < 2 {
+		t.Errorf("expected up to 3 feeds to be fetched at once, got %d", most)
+	}
+}
+
+// TestCacheFullTextZeroConcurrency if we get an error then the full text extraction hangs when the options
+// don't set the concurrency
+func TestCacheFullTextZeroConcurrency(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<html><body><article><p>The full article.</p></article></body></html>"))
+	}))
+	defer server.Close()
+
+	cache, err := NewWithOptions(t.TempDir(), Options{})
+	if err != nil {
+		t.Fatalf("couldn't create the cache %v", err)
+	}
+
+	articles := SortableArticles{{Title: "Article", Link: server.URL}}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cache.fillFullText(articles, false)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the full text to be extracted without any workers set")
+	}
+
+	if !strings.Contains(articles[0].Description, "The full article.") {
+		t.Errorf("expected the full text in the description, got %q", articles[0].Description)
+	}
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// partSuffix is added to the name of a file while it's being downloaded
+const partSuffix = ".part"
+
+// ErrAlreadyDownloaded is returned when the file was downloaded before and can't be overwritten
+var ErrAlreadyDownloaded = errors.New("the file was already downloaded")
+
+// Progress is called while a file is downloaded, total is -1 if the server didn't send the size
+type Progress func(written, total int64)
+
+// DownloadFile downloads a url to a file. The download goes to a partial file first, which is resumed
+// the next time if the server supports range requests. A finished file is only replaced if overwrite
+// is set. Unlike the feeds the download doesn't time out, podcast episodes can take a while.
+func (c *Cache) DownloadFile(url, path string, overwrite bool, progress Progress) error {
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return fmt.Errorf("cache.DownloadFile: %w", ErrAlreadyDownloaded)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("cache.DownloadFile: %w", err)
+	}
+
+	part := path + partSuffix
+	var offset int64
+	if info, err := os.Stat(part); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("cache.DownloadFile: %w", err)
+	}
+
+	req.Header.Set("User-Agent", c.options.UserAgent)
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	client := c.newClient(false)
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cache.DownloadFile: %w", err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// The partial file is already complete
+		return finishDownload(part, path)
+
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return gofeed.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+
+	default:
+		// The server ignored the range, start over
+		offset = 0
+	}
+
+	file, err := os.OpenFile(part, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("cache.DownloadFile: %w", err)
+	}
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+
+	_, err = io.Copy(file, &progressReader{resp.Body, offset, total, progress})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("cache.DownloadFile: %w", err)
+	}
+
+	return finishDownload(part, path)
+}
+
+// finishDownload moves the finished partial file to its place
+func finishDownload(part, path string) error {
+	if err := os.Rename(part, path); err != nil {
+		return fmt.Errorf("cache.DownloadFile: %w", err)
+	}
+
+	return nil
+}
+
+// progressReader reports the progress of a download as it's read
+type progressReader struct {
+	reader   io.Reader
+	written  int64
+	total    int64
+	progress Progress
+}
+
+// Read fulfills the io.Reader interface
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	p.written += int64(n)
+	if p.progress != nil && n > 0 {
+		p.progress(p.written, p.total)
+	}
+
+	return n, err
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// xmlEncoding matches the encoding from the xml declaration of a document
+var xmlEncoding = regexp.MustCompile(`^\s*<\?xml[^>]*encoding=["']([^"']+)["']`)
+
+// fallbackEncodings are tried in order when a feed which should be utf-8 isn't
+var fallbackEncodings = []struct {
+	name     string
+	encoding encoding.Encoding
+}{
+	{"windows-1252", charmap.Windows1252},
+	{"iso-8859-1", charmap.ISO8859_1},
+	{"iso-8859-15", charmap.ISO8859_15},
+}
+
+// fixEncoding makes sure that a feed which declares utf-8 (or nothing, which means utf-8) is valid utf-8,
+// it returns the fixed data and the name of the encoding which was used to decode it. Feeds which declare
+// a different encoding are left for the parser to decode.
+func fixEncoding(data []byte) ([]byte, string) {
+	declared := "utf-8"
+	if match := xmlEncoding.FindSubmatch(data); match != nil {
+		declared = strings.ToLower(string(match[1]))
+	}
+
+	if (declared != "utf-8" && declared != "utf8") || utf8.Valid(data) {
+		return data, declared
+	}
+
+	for _, fallback := range fallbackEncodings {
+		decoded, err := fallback.encoding.NewDecoder().Bytes(data)
+		if err == nil && utf8.Valid(decoded) && !bytes.ContainsRune(decoded, utf8.RuneError) {
+			return decoded, fallback.name
+		}
+	}
+
+	return bytes.ToValidUTF8(data, []byte(string(utf8.RuneError))), "invalid utf-8"
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// serverFreshness returns how long the response stays fresh according to the Cache-Control max-age
+// directive or the Expires header, the max-age takes precedence like in the http caches. False is
+// returned if the server doesn't say.
+func serverFreshness(header http.Header, now time.Time) (time.Duration, bool) {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache", "no-store":
+			return 0, true
+
+		case "max-age":
+			seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err != nil || seconds < 0 {
+				continue
+			}
+
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	expires := header.Get("Expires")
+	if expires == "" {
+		return 0, false
+	}
+
+	// An invalid date like "0" means that the response has already expired
+	expiresAt, err := http.ParseTime(expires)
+	if err != nil {
+		return 0, true
+	}
+
+	// The server clock can be off, the difference between its own headers is more reliable
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		now = date
+	}
+
+	if freshness := expiresAt.Sub(now); freshness > 0 {
+		return freshness, true
+	}
+
+	return 0, true
+}
+
+// expiry returns when a feed fetched now expires, the freshness suggested by the server is clamped to
+// the limits in the options and the default cache duration is used if the server didn't suggest any.
+// Zero limits aren't applied.
+func (c *Cache) expiry(info feedInfo) time.Time {
+	if !info.hasFreshness {
+		return time.Now().Add(DefaultCacheDuration)
+	}
+
+	freshness := info.freshness
+	if freshness < c.options.MinFreshness {
+		freshness = c.options.MinFreshness
+	}
+
+	if c.options.MaxFreshness > 0 && freshness > c.options.MaxFreshness {
+		freshness = c.options.MaxFreshness
+	}
+
+	return time.Now().Add(freshness)
+}
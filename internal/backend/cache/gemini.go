@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/xml"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// geminiMaxRedirects is the number of redirects a gemini request follows before giving up
+const geminiMaxRedirects = 5
+
+// geminiFetcher downloads feeds over the gemini protocol, subscribed gemtext pages are converted to atom
+type geminiFetcher struct{}
+
+// Fetch downloads the body behind a gemini url
+func (geminiFetcher) Fetch(rawURL string, options Options) ([]byte, error) {
+	for i := 0; i <= geminiMaxRedirects; i++ {
+		parsed, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("cache.geminiFetcher.Fetch: %w", err)
+		}
+
+		status, meta, body, err := geminiRequest(parsed, options)
+		if err != nil {
+			return nil, fmt.Errorf("cache.geminiFetcher.Fetch: %w", err)
+		}
+
+		switch status[0] {
+		case '2':
+			if strings.HasPrefix(meta, "text/gemini") {
+				return gemtextToAtom(parsed, body)
+			}
+
+			return body, nil
+
+		case '3':
+			target, err := parsed.Parse(meta)
+			if err != nil {
+				return nil, fmt.Errorf("cache.geminiFetcher.Fetch: %w", err)
+			}
+
+			rawURL = target.String()
+
+		default:
+			return nil, fmt.Errorf("cache.geminiFetcher.Fetch: status %s: %s", status, meta)
+		}
+	}
+
+	return nil, fmt.Errorf("cache.geminiFetcher.Fetch: stopped after %d redirects", geminiMaxRedirects)
+}
+
+// geminiRequest sends a single gemini request and returns the response status, meta and body
+func geminiRequest(target *url.URL, options Options) (status, meta string, body []byte, err error) {
+	host := target.Host
+	if target.Port() == "" {
+		host = net.JoinHostPort(target.Hostname(), "1965")
+	}
+
+	// Gemini servers usually have self-signed certificates, which are trusted on first use by the clients.
+	// We don't keep the certificates around, so we can't verify them.
+	dialer := &net.Dialer{Timeout: options.Timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", host, &tls.Config{
+		ServerName:         target.Hostname(),
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true, //nolint:gosec
+	})
+	if err != nil {
+		return "", "", nil, err
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(options.Timeout)); err != nil {
+		return "", "", nil, err
+	}
+
+	if _, err = fmt.Fprintf(conn, "%s\r\n", target.String()); err != nil {
+		return "", "", nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	status, meta, _ = strings.Cut(strings.TrimRight(header, "\r\n"), " ")
+	if len(status) != 2 || status[0] < '1' || status[0] > '6' {
+		return "", "", nil, fmt.Errorf("malformed response header %q", header)
+	}
+
+	if status[0] != '2' {
+		return status, meta, nil, nil
+	}
+
+	body, err = readLimited(reader, options.MaxResponseSize)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	return status, meta, body, nil
+}
+
+// atomFeed is the minimal atom document a gemtext page is converted to
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomEntry is a single entry of the atom document
+type atomEntry struct {
+	Title     string   `xml:"title"`
+	ID        string   `xml:"id"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+	Link      atomLink `xml:"link"`
+}
+
+// atomLink is the link of an atom entry
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+// gemtextToAtom converts a gemtext page to an atom feed, following the gemini subscription convention:
+// the first level one heading is the title and every link whose label starts with a date is an entry
+func gemtextToAtom(page *url.URL, body []byte) ([]byte, error) {
+	feed := atomFeed{Title: page.String(), ID: page.String()}
+	titleSet := false
+
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !titleSet && strings.HasPrefix(line, "# ") {
+			feed.Title = strings.TrimSpace(line[2:])
+			titleSet = true
+			continue
+		}
+
+		if !strings.HasPrefix(line, "=>") {
+			continue
+		}
+
+		fields := strings.Fields(line[2:])
+		if len(fields) < 2 || len(fields[1]) < 10 {
+			continue
+		}
+
+		published, err := time.Parse("2006-01-02", fields[1][:10])
+		if err != nil {
+			continue
+		}
+
+		link, err := page.Parse(fields[0])
+		if err != nil {
+			continue
+		}
+
+		title := strings.TrimSpace(strings.TrimLeft(strings.Join(fields[1:], " ")[10:], " -:"))
+		if title == "" {
+			title = link.String()
+		}
+
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:     title,
+			ID:        link.String(),
+			Published: published.Format(time.RFC3339),
+			Updated:   published.Format(time.RFC3339),
+			Link:      atomLink{link.String()},
+		})
+	}
+
+	data, err := xml.Marshal(feed)
+	if err != nil {
+		return nil, fmt.Errorf("cache.gemtextToAtom: %w", err)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
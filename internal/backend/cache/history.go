@@ -0,0 +1,29 @@
+package cache
+
+// AddToHistory moves a feed to the front of the recently opened feeds, the feeds over the limit are
+// forgotten and a limit of 0 disables the history
+func (c *Cache) AddToHistory(name string, limit int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	history := make([]string, 0, len(c.History)+1)
+	history = append(history, name)
+	for _, visited := range c.History {
+		if visited != name {
+			history = append(history, visited)
+		}
+	}
+
+	if len(history) > limit {
+		history = history[:limit]
+	}
+
+	c.History = history
+}
+
+// RecentFeeds returns the names of the recently opened feeds, the most recent one first
+func (c *Cache) RecentFeeds() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.History...)
+}
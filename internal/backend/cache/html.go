@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// challengeMarkers are found in the pages bot protection services show instead of the requested content
+var challengeMarkers = [][]byte{
+	[]byte("cf-browser-verification"),
+	[]byte("challenge-platform"),
+	[]byte("cf_chl_"),
+	[]byte("<title>just a moment...</title>"),
+	[]byte("ddos-guard"),
+}
+
+// feedTypes are the link types which point to a feed in the head of a html page
+var feedTypes = []string{"application/rss+xml", "application/atom+xml", "application/feed+json", "application/json"}
+
+// NotFeedError is returned when the feed url responds with a html page instead of a feed.
+type NotFeedError struct {
+	// Blocked is true if the page is a bot protection challenge
+	Blocked bool
+	// Discovered contains the urls of the feeds the page links to
+	Discovered []string
+}
+
+// Error describes why the page isn't a feed and where the feed might be.
+func (e NotFeedError) Error() string {
+	if e.Blocked {
+		return "blocked by a bot protection challenge, the server sent a html page instead of the feed"
+	}
+
+	if len(e.Discovered) > 0 {
+		return fmt.Sprintf("not a feed, the url points to a html page which links to: %s", strings.Join(e.Discovered, ", "))
+	}
+
+	return "not a feed, the url points to a html page"
+}
+
+// isHTML returns true if the response body is a html page, the content type header isn't checked since
+// plenty of servers send valid feeds as text/html
+func isHTML(data []byte) bool {
+	if !strings.HasPrefix(http.DetectContentType(data), "text/html") {
+		return false
+	}
+
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+
+	// Feeds can start with a comment, which is sniffed as html too
+	head = bytes.ToLower(head)
+	return !bytes.Contains(head, []byte("<rss")) &&
+		!bytes.Contains(head, []byte("<feed")) &&
+		!bytes.Contains(head, []byte("<rdf:rdf"))
+}
+
+// isChallenge returns true if the html page looks like a bot protection challenge
+func isChallenge(data []byte) bool {
+	lower := bytes.ToLower(data)
+	for _, marker := range challengeMarkers {
+		if bytes.Contains(lower, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newNotFeedError inspects the html page returned instead of a feed, feeds linked from the page are
+// resolved against the page url
+func newNotFeedError(data []byte, pageURL string) NotFeedError {
+	if isChallenge(data) {
+		return NotFeedError{Blocked: true}
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return NotFeedError{}
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		base = &url.URL{}
+	}
+
+	var discovered []string
+	doc.Find(`link[rel~="alternate"][href]`).Each(func(_ int, link *goquery.Selection) {
+		linkType, _ := link.Attr("type")
+		if !isFeedType(linkType) {
+			return
+		}
+
+		href, _ := link.Attr("href")
+		if ref, err := url.Parse(strings.TrimSpace(href)); err == nil {
+			discovered = append(discovered, base.ResolveReference(ref).String())
+		}
+	})
+
+	return NotFeedError{Discovered: discovered}
+}
+
+// isFeedType returns true if the mime type of a link is one of the feed types
+func isFeedType(linkType string) bool {
+	linkType = strings.ToLower(strings.TrimSpace(linkType))
+	for _, feedType := range feedTypes {
+		if linkType == feedType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"crypto/sha1"
+	"fmt"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// idKey is the custom field of an article which holds its identity
+const idKey = "goread_id"
+
+// ArticleID returns the identity of an article, which is used to track its read status and its reading
+// position. The articles which weren't given one by the cache are identified by their link.
+func ArticleID(item *gofeed.Item) string {
+	if id := item.Custom[idKey]; id != "" {
+		return id
+	}
+
+	return item.Link
+}
+
+// assignIDs gives the articles of a feed their identity. A guid which no other article of the feed uses
+// is the identity, the articles with a missing or a repeated guid are told apart by a hash of the guid,
+// their link, title and date. The identity of an article with a unique guid doesn't depend on its link
+// or on the other articles, so it doesn't change when they come and go.
+func assignIDs(articles SortableArticles) {
+	guids := make(map[string]int, len(articles))
+	for i := range articles {
+		guids[articles[i].GUID]++
+	}
+
+	for i := range articles {
+		item := &articles[i]
+		id := "guid:" + item.GUID
+		if item.GUID == "" || guids[item.GUID] > 1 {
+			date := item.Published
+			if date == "" {
+				date = item.Updated
+			}
+
+			id = fmt.Sprintf("item:%x", sha1.Sum([]byte(item.GUID+"\n"+item.Link+"\n"+item.Title+"\n"+date)))
+		}
+
+		if item.Custom == nil {
+			item.Custom = make(map[string]string, 1)
+		}
+
+		item.Custom[idKey] = id
+	}
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// itemPattern matches the items of a rss feed and the entries of an atom feed
+var itemPattern = regexp.MustCompile(`(?s)<item[\s>].*?</item\s*>|<entry[\s>].*?</entry\s*>`)
+
+// parseLenient parses a feed which the parser rejected one item at a time, so that a single broken item
+// doesn't take down the whole feed. The document around the items is parsed on its own for the details of
+// the feed, every item is parsed wrapped in it. It returns the number of the items which had to be skipped.
+func parseLenient(data []byte) (*gofeed.Feed, int, error) {
+	locs := itemPattern.FindAllIndex(data, -1)
+	if len(locs) == 0 {
+		return nil, 0, errors.New("no items found")
+	}
+
+	head, tail := data[:locs[0][0]], data[locs[len(locs)-1][1]:]
+	feed, err := gofeed.NewParser().Parse(bytes.NewReader(join(head, nil, tail)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	feed.Items = nil
+	skipped := 0
+	for _, loc := range locs {
+		part, err := gofeed.NewParser().Parse(bytes.NewReader(join(head, data[loc[0]:loc[1]], tail)))
+		if err != nil || len(part.Items) != 1 {
+			skipped++
+			continue
+		}
+
+		feed.Items = append(feed.Items, part.Items[0])
+	}
+
+	if len(feed.Items) == 0 {
+		return nil, skipped, errors.New("none of the items could be parsed")
+	}
+
+	return feed, skipped, nil
+}
+
+// join puts an item back between the start and the end of its feed
+func join(head, item, tail []byte) []byte {
+	doc := make([]byte, 0, len(head)+len(item)+len(tail))
+	doc = append(doc, head...)
+	doc = append(doc, item...)
+	return append(doc, tail...)
+}
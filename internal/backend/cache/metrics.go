@@ -0,0 +1,56 @@
+package cache
+
+import "time"
+
+// maxMetrics is the number of the recent fetches of a feed which are kept for its averages
+const maxMetrics = 10
+
+// FetchMetric is how long fetching and parsing a feed took and how big its body was
+type FetchMetric struct {
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+	Size     int           `json:"size"`
+}
+
+// FetchStats sums up the recent fetches of a feed
+type FetchStats struct {
+	Last        FetchMetric
+	Fetches     int
+	AvgDuration time.Duration
+	AvgSize     int
+}
+
+// addMetric appends a fetch to the metrics of a feed, only the most recent ones are kept
+func addMetric(metrics []FetchMetric, metric FetchMetric) []FetchMetric {
+	if len(metrics) >= maxMetrics {
+		metrics = metrics[len(metrics)-maxMetrics+1:]
+	}
+
+	// The slice is copied so that the previous entry isn't changed under its readers
+	return append(append([]FetchMetric(nil), metrics...), metric)
+}
+
+// FetchStats returns the stats of the recent successful fetches of a feed, ok is false if the feed has
+// no recorded fetches
+func (c *Cache) FetchStats(url string) (stats FetchStats, ok bool) {
+	c.mu.RLock()
+	metrics := c.Content[url].Metrics
+	c.mu.RUnlock()
+	if len(metrics) == 0 {
+		return FetchStats{}, false
+	}
+
+	var duration time.Duration
+	var size int
+	for _, metric := range metrics {
+		duration += metric.Duration
+		size += metric.Size
+	}
+
+	return FetchStats{
+		Last:        metrics[len(metrics)-1],
+		Fetches:     len(metrics),
+		AvgDuration: duration / time.Duration(len(metrics)),
+		AvgSize:     size / len(metrics),
+	}, true
+}
@@ -0,0 +1,60 @@
+package cache
+
+import "time"
+
+// Options contains the settings used when fetching feeds and article pages
+type Options struct {
+	// Timeout is the maximum duration of a single request
+	Timeout time.Duration `yaml:"timeout"`
+	// Concurrency is the number of feeds fetched at once by the bulk fetches and the number of article
+	// pages fetched at once when extracting the full text
+	Concurrency int `yaml:"concurrency"`
+	// UserAgent is sent with every request
+	UserAgent string `yaml:"user_agent"`
+	// Proxy is the url of the proxy used for the requests, the environment settings are used if it's empty
+	Proxy string `yaml:"proxy"`
+	// MinFreshness and MaxFreshness limit how long a feed is cached for when the server suggests it
+	// with the Cache-Control or the Expires header
+	MinFreshness time.Duration `yaml:"min_freshness"`
+	MaxFreshness time.Duration `yaml:"max_freshness"`
+	// WhitelistWords and BlacklistWords filter the articles of every feed, the blacklist is added to
+	// the blacklists of the categories and the feeds while their whitelists replace this one
+	WhitelistWords []string `yaml:"whitelist_words"`
+	BlacklistWords []string `yaml:"blacklist_words"`
+	// StripSelectors and StripPatterns match the boilerplate blocks which are removed from the start of
+	// the articles of every feed, the ones set for a feed are used as well
+	StripSelectors []string `yaml:"strip_selectors"`
+	StripPatterns  []string `yaml:"strip_patterns"`
+	// ArchivePages is how many older pages of a paged feed can be loaded from the end of its article
+	// list, zero disables loading the older articles
+	ArchivePages int `yaml:"archive_pages"`
+	// MaxItems is the most articles a feed can have after loading its older pages, zero means no limit
+	MaxItems int `yaml:"max_items"`
+	// MaxResponseSize is the largest feed body or article page in bytes which is downloaded, zero means
+	// no limit
+	MaxResponseSize int64 `yaml:"max_response_size"`
+	// InMemory keeps the cache and the read status in memory for the session, they are never read from or
+	// written to disk
+	InMemory bool `yaml:"in_memory"`
+	// LenientParse salvages the valid items of a feed which fails to parse because of a few broken ones
+	LenientParse bool `yaml:"lenient_parse"`
+	// ResolveLinks makes the relative links and images in the articles absolute, using the link of the
+	// article and the homepage of the feed
+	ResolveLinks bool `yaml:"resolve_links"`
+}
+
+// DefaultOptions contains the default fetch settings
+var DefaultOptions = Options{
+	Timeout:      5 * time.Second,
+	Concurrency:  4,
+	UserAgent:    "goread (by /u/TypicalAM)",
+	Proxy:        "",
+	MinFreshness: 15 * time.Minute,
+	MaxFreshness: 7 * 24 * time.Hour,
+	ArchivePages: 0,
+	MaxItems:     500,
+	// The biggest real feeds are a few megabytes, anything past this is broken or malicious
+	MaxResponseSize: 32 << 20,
+	LenientParse:    true,
+	ResolveLinks:    true,
+}
@@ -0,0 +1,38 @@
+package cache
+
+import "time"
+
+// DefaultPositionExpiry is how long the reading position of an article is kept after it was last saved
+var DefaultPositionExpiry = 30 * 24 * time.Hour
+
+// PositionEntry is the scroll offset the article was left at
+type PositionEntry struct {
+	Expire time.Time `json:"expire"`
+	Offset int       `json:"offset"`
+}
+
+// SavePosition remembers how far the article was scrolled, scrolling back to the top forgets the position
+func (c *Cache) SavePosition(guid string, offset int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if offset <= 0 {
+		delete(c.Positions, guid)
+		return
+	}
+
+	c.Positions[guid] = PositionEntry{time.Now().Add(DefaultPositionExpiry), offset}
+}
+
+// Position returns the scroll offset the article was left at, if it was saved
+func (c *Cache) Position(guid string) (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.Positions[guid]
+	if !ok || entry.Expire.Before(time.Now()) {
+		return 0, false
+	}
+
+	return entry.Offset, true
+}
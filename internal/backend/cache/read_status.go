@@ -7,7 +7,10 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
 
+	"github.com/TypicalAM/goread/internal/backend/atomicfile"
+	"github.com/mmcdole/gofeed"
 	"github.com/spaolacci/murmur3"
 )
 
@@ -17,10 +20,20 @@ import (
 type ReadStatus struct {
 	set      map[uint32]struct{}
 	filePath string
+	inMemory bool
+
+	// mu guards the set, the background refresh counts the unread articles while the interface marks them
+	mu sync.RWMutex
 }
 
-// New creates a new ReadStatus set.
+// New creates a new ReadStatus set which follows the default fetch options like the cache from New.
 func NewReadStatus(dir string) (*ReadStatus, error) {
+	return NewReadStatusWithOptions(dir, DefaultOptions)
+}
+
+// NewReadStatusWithOptions creates a new ReadStatus set, it's kept in memory like the cache from
+// NewWithOptions if the given fetch options or the environment say so.
+func NewReadStatusWithOptions(dir string, options Options) (*ReadStatus, error) {
 	log.Println("Creating new read status")
 	if dir == "" {
 		defaultDir, err := getDefaultDir()
@@ -34,11 +47,16 @@ func NewReadStatus(dir string) (*ReadStatus, error) {
 	return &ReadStatus{
 		filePath: filepath.Join(dir, "read_status"),
 		set:      make(map[uint32]struct{}),
+		inMemory: keepInMemory(options),
 	}, nil
 }
 
-// Load reads the cache from disk
+// Load reads the cache from disk, an in-memory read status starts out empty
 func (rs *ReadStatus) Load() error {
+	if rs.inMemory {
+		return nil
+	}
+
 	log.Println("Loading read status from", rs.filePath)
 	data, err := os.ReadFile(rs.filePath)
 	if err != nil {
@@ -49,17 +67,27 @@ func (rs *ReadStatus) Load() error {
 		return fmt.Errorf("cache.Load: %w", err)
 	}
 
-	rs.set, err = unmarshal(data)
+	set, err := unmarshal(data)
 	if err != nil {
 		return fmt.Errorf("cache.Load: %w", err)
 	}
 
+	rs.mu.Lock()
+	rs.set = set
+	rs.mu.Unlock()
+
 	return nil
 }
 
-// Save writes the cache to disk
-func (rs ReadStatus) Save() error {
+// Save writes the cache to disk, an in-memory read status isn't written
+func (rs *ReadStatus) Save() error {
+	if rs.inMemory {
+		return nil
+	}
+
+	rs.mu.RLock()
 	data := marshal(rs.set)
+	rs.mu.RUnlock()
 	log.Println("Marshalling the data yielded a size of", len(data))
 
 	// Try to write the data to the file
@@ -77,19 +105,65 @@ func (rs ReadStatus) Save() error {
 	return nil
 }
 
+// Snapshot encodes the set and returns a function which writes it to disk atomically, the writing
+// doesn't touch the set so it can happen in the background.
+func (rs *ReadStatus) Snapshot() func() error {
+	if rs.inMemory {
+		return func() error { return nil }
+	}
+
+	rs.mu.RLock()
+	data := marshal(rs.set)
+	rs.mu.RUnlock()
+	path := rs.filePath
+	return func() error { return atomicfile.Write(path, data) }
+}
+
 // MarkAsRead adds an article to the set.
 func (rs *ReadStatus) MarkAsRead(url string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
 	rs.set[hashArticle(url)] = struct{}{}
 }
 
 // IsRead checks if an article is already in the set.
-func (rs ReadStatus) IsRead(url string) bool {
+func (rs *ReadStatus) IsRead(url string) bool {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
 	_, ok := rs.set[hashArticle(url)]
 	return ok
 }
 
+// IsArticleRead checks if an article is already in the set. The articles which were marked as read while
+// they were identified by their link are looked up by it once, their entry is moved to their identity.
+func (rs *ReadStatus) IsArticleRead(item *gofeed.Item) bool {
+	id := ArticleID(item)
+	if rs.IsRead(id) {
+		return true
+	}
+
+	if item.Link == "" || item.Link == id {
+		return false
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, ok := rs.set[hashArticle(item.Link)]; !ok {
+		// The entry could have been moved in the meantime
+		_, ok = rs.set[hashArticle(id)]
+		return ok
+	}
+
+	delete(rs.set, hashArticle(item.Link))
+	rs.set[hashArticle(id)] = struct{}{}
+	return true
+}
+
 // MarkAsUnread removes an article from the set.
 func (rs *ReadStatus) MarkAsUnread(url string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
 	delete(rs.set, hashArticle(url))
 }
 
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// stateVersion is the version of the state file format
+const stateVersion = 1
+
+// state is the reading state which can be moved between machines, it doesn't contain any cached articles
+type state struct {
+	Version   int              `json:"version"`
+	Read      []uint32         `json:"read"`
+	ReadLater []ReadLaterEntry `json:"read_later"`
+}
+
+// ExportState writes the read articles and the read later queue to a json file, the read articles are
+// stored as the same hashes which the read status uses.
+func (c *Cache) ExportState(path string, readStatus *ReadStatus) error {
+	readStatus.mu.RLock()
+	read := make([]uint32, 0, len(readStatus.set))
+	for hash := range readStatus.set {
+		read = append(read, hash)
+	}
+	readStatus.mu.RUnlock()
+
+	// Keep the file stable between exports so that it diffs nicely
+	sort.Slice(read, func(i, j int) bool { return read[i] < read[j] })
+
+	c.mu.RLock()
+	data, err := json.MarshalIndent(state{stateVersion, read, c.ReadLater}, "", "  ")
+	c.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("cache.ExportState: %w", err)
+	}
+
+	if err = os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("cache.ExportState: %w", err)
+	}
+
+	return nil
+}
+
+// ImportState merges the state from a file written by ExportState, articles read on either machine stay
+// read and the queued articles which aren't queued yet are added to the end of the read later queue.
+func (c *Cache) ImportState(path string, readStatus *ReadStatus) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cache.ImportState: %w", err)
+	}
+
+	var imported state
+	if err = json.Unmarshal(data, &imported); err != nil {
+		return fmt.Errorf("cache.ImportState: %w", err)
+	}
+
+	if imported.Version > stateVersion {
+		return fmt.Errorf("cache.ImportState: unsupported state version %d", imported.Version)
+	}
+
+	readStatus.mu.Lock()
+	for _, hash := range imported.Read {
+		readStatus.set[hash] = struct{}{}
+	}
+	readStatus.mu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	queued := make(map[string]bool, len(c.ReadLater))
+	for _, entry := range c.ReadLater {
+		queued[entry.Item.Link] = true
+	}
+
+	for _, entry := range imported.ReadLater {
+		if !queued[entry.Item.Link] {
+			c.ReadLater = append(c.ReadLater, entry)
+			queued[entry.Item.Link] = true
+		}
+	}
+
+	return nil
+}
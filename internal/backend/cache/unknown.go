@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// unknownFields returns the fields of a json object which don't belong to any field of the struct v,
+// they were written by a newer version and are kept so that saving the cache doesn't lose them
+func unknownFields(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	known := jsonNames(reflect.TypeOf(v))
+	for name := range fields {
+		for _, knownName := range known {
+			// The decoder matches the names case insensitively, so does this
+			if strings.EqualFold(name, knownName) {
+				delete(fields, name)
+				break
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return fields, nil
+}
+
+// withUnknownFields adds the unknown fields to an encoded json object, the known fields take precedence
+func withUnknownFields(data []byte, unknown map[string]json.RawMessage) ([]byte, error) {
+	if len(unknown) == 0 {
+		return data, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for name, value := range unknown {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// jsonNames returns the names the exported fields of a struct have in json
+func jsonNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+
+		case "":
+			name = field.Name
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,17 @@
+package backend
+
+// Capabilities tells the interface which operations the backend supports, the actions which wouldn't
+// work are hidden instead of failing or being silently lost
+type Capabilities struct {
+	// EditFeeds is changing anything kept in the urls file, like adding, editing, renaming and removing
+	// the feeds and the categories, pinning, muting and the remembered render modes and sort orders
+	EditFeeds bool
+}
+
+// Capabilities returns the operations the backend supports. The feeds can't be edited if the urls file
+// is read-only since the changes would be thrown away on exit.
+func (b Backend) Capabilities() Capabilities {
+	return Capabilities{
+		EditFeeds: !b.URLsReadOnly,
+	}
+}
@@ -0,0 +1,148 @@
+package backend
+
+import (
+	"fmt"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mmcdole/gofeed"
+)
+
+// EnclosureProgressMsg reports the progress of an enclosure download, Total is -1 if the size isn't
+// known. Done is set once the download finished or failed with Err.
+type EnclosureProgressMsg struct {
+	Title   string
+	Path    string
+	Written int64
+	Total   int64
+	Done    bool
+	Err     error
+	updates <-chan EnclosureProgressMsg
+}
+
+// Next waits for the next progress report of the download
+func (msg EnclosureProgressMsg) Next() tea.Cmd {
+	if msg.Done {
+		return nil
+	}
+
+	return func() tea.Msg { return <-msg.updates }
+}
+
+// DownloadEnclosure downloads the audio or the video enclosure of an article to the enclosure directory
+// in the background, the progress is reported with EnclosureProgressMsg.
+func (b Backend) DownloadEnclosure(feedName string, index int) tea.Cmd {
+	return func() tea.Msg {
+		item, err := b.indexToItem(feedName, index)
+		if err != nil {
+			return ShowErrorMsg{fmt.Sprintf("Error while getting the article: %v", err)}
+		}
+
+		enclosure := mediaEnclosure(item)
+		if enclosure == nil {
+			return ShowErrorMsg{"The article doesn't have an audio or a video file"}
+		}
+
+		if b.Cache.OfflineMode {
+			return ShowErrorMsg{"Cannot download the enclosure in offline mode"}
+		}
+
+		dir, err := b.enclosureDir()
+		if err != nil {
+			return ShowErrorMsg{fmt.Sprintf("Error while finding the download directory: %v", err)}
+		}
+
+		start := EnclosureProgressMsg{Title: item.Title, Path: filepath.Join(dir, enclosureName(item, enclosure)), Total: -1}
+		updates := make(chan EnclosureProgressMsg, 1)
+		start.updates = updates
+
+		go func() {
+			report := start
+			err := b.Cache.DownloadFile(enclosure.URL, start.Path, b.options.EnclosureOverwrite, func(written, total int64) {
+				report.Written, report.Total = written, total
+				// Drop the report if the last one wasn't shown yet, the next one is more recent anyway
+				select {
+				case updates <- report:
+				default:
+				}
+			})
+
+			report.Done, report.Err = true, err
+			updates <- report
+		}()
+
+		return start
+	}
+}
+
+// mediaEnclosure returns the audio or the video enclosure of an article, the images are shown as thumbnails
+func mediaEnclosure(item *gofeed.Item) *gofeed.Enclosure {
+	for _, enclosure := range item.Enclosures {
+		if strings.HasPrefix(enclosure.Type, "audio/") || strings.HasPrefix(enclosure.Type, "video/") {
+			return enclosure
+		}
+	}
+
+	for _, enclosure := range item.Enclosures {
+		if enclosure.URL != "" && !strings.HasPrefix(enclosure.Type, "image/") {
+			return enclosure
+		}
+	}
+
+	return nil
+}
+
+// enclosureName returns the file name of an enclosure, made of the title of the article and the extension
+// from the url or the mime type of the enclosure
+func enclosureName(item *gofeed.Item, enclosure *gofeed.Enclosure) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+
+		return r
+	}, strings.TrimSpace(item.Title))
+
+	if runes := []rune(name); len(runes) > 100 {
+		name = strings.TrimSpace(string(runes[:100]))
+	}
+
+	if name == "" {
+		name = "episode"
+	}
+
+	ext := path.Ext(strings.SplitN(enclosure.URL, "?", 2)[0])
+	if len(ext) < 2 || len(ext) > 5 {
+		ext = ""
+		if exts, err := mime.ExtensionsByType(enclosure.Type); err == nil && len(exts) > 0 {
+			ext = exts[0]
+		}
+	}
+
+	return name + ext
+}
+
+// enclosureDir returns the directory the enclosures are downloaded to, a leading ~ is the home directory
+// and the Downloads directory in the home directory is used by default
+func (b Backend) enclosureDir() (string, error) {
+	dir := b.options.EnclosureDir
+	if dir != "" && dir != "~" && !strings.HasPrefix(dir, "~/") {
+		return dir, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	if dir == "" {
+		return filepath.Join(home, "Downloads"), nil
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(dir, "~")), nil
+}
@@ -0,0 +1,184 @@
+package fulltext
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// removedTags are the elements which never contain the article content
+var removedTags = "script, style, noscript, iframe, form, nav, header, footer, aside, button, svg"
+
+// unlikelyCandidates matches class names and ids of elements which are most likely boilerplate
+var unlikelyCandidates = regexp.MustCompile(`(?i)comment|share|social|sidebar|promo|related|footer|menu|newsletter|subscribe|cookie|banner|ad-|advert`)
+
+// positiveCandidates matches class names and ids of elements which are most likely the article
+var positiveCandidates = regexp.MustCompile(`(?i)article|body|content|entry|main|post|text|story`)
+
+// ErrPageTooLarge is returned when the article page is larger than the size limit
+var ErrPageTooLarge = errors.New("page too large")
+
+// maxPageSize is the largest page in bytes which Fetch downloads
+const maxPageSize = 32 << 20
+
+// Fetch downloads the page behind the link and returns its main content as cleaned html
+func Fetch(link string) (string, error) {
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			Proxy:        http.ProxyFromEnvironment,
+			TLSNextProto: map[string]func(authority string, c *tls.Conn) http.RoundTripper{},
+		},
+	}
+
+	return FetchWith(client, "goread (by /u/TypicalAM)", link, maxPageSize)
+}
+
+// FetchWith is like Fetch, but it uses the given client and user agent for the request and gives up on
+// pages larger than limit bytes, a limit of zero downloads pages of any size
+func FetchWith(client *http.Client, userAgent, link string, limit int64) (string, error) {
+	log.Println("Fetching full text from", link)
+	req, err := http.NewRequest("GET", link, nil)
+	if err != nil {
+		return "", fmt.Errorf("fulltext.FetchWith: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fulltext.FetchWith: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("fulltext.FetchWith: unexpected status %s", resp.Status)
+	}
+
+	page, err := readLimited(resp.Body, limit)
+	if err != nil {
+		return "", fmt.Errorf("fulltext.FetchWith: %w", err)
+	}
+
+	content, err := Extract(bytes.NewReader(page))
+	if err != nil {
+		return "", fmt.Errorf("fulltext.FetchWith: %w", err)
+	}
+
+	return content, nil
+}
+
+// readLimited reads the whole page unless it's larger than limit bytes, a limit of zero reads everything
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w, it's larger than the limit of %d bytes", ErrPageTooLarge, limit)
+	}
+
+	return data, nil
+}
+
+// Extract finds the main content of a html document, readability-style. It first looks for
+// semantic elements and falls back to scoring the blocks by the amount of paragraph text in them.
+func Extract(r io.Reader) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return "", fmt.Errorf("fulltext.Extract: %w", err)
+	}
+
+	doc.Find(removedTags).Remove()
+	doc.Find("*").Each(func(_ int, s *goquery.Selection) {
+		if s.Is("html, body, article, main") {
+			return
+		}
+
+		id, _ := s.Attr("id")
+		class, _ := s.Attr("class")
+		match := id + " " + class
+		if unlikelyCandidates.MatchString(match) && !positiveCandidates.MatchString(match) {
+			s.Remove()
+		}
+	})
+
+	candidate := doc.Find("article").First()
+	if candidate.Length() == 0 {
+		candidate = doc.Find("[role=main], main").First()
+	}
+
+	if candidate.Length() == 0 {
+		candidate = bestCandidate(doc)
+	}
+
+	if candidate.Length() == 0 {
+		return "", fmt.Errorf("fulltext.Extract: no content found")
+	}
+
+	clean(candidate)
+	content, err := goquery.OuterHtml(candidate)
+	if err != nil {
+		return "", fmt.Errorf("fulltext.Extract: %w", err)
+	}
+
+	return strings.TrimSpace(content), nil
+}
+
+// bestCandidate scores the parents of the paragraphs and returns the best one
+func bestCandidate(doc *goquery.Document) *goquery.Selection {
+	scores := make(map[*html.Node]float64)
+
+	doc.Find("p, pre").Each(func(_ int, p *goquery.Selection) {
+		text := strings.TrimSpace(p.Text())
+		if len(text) < 25 {
+			return
+		}
+
+		score := 1 + float64(strings.Count(text, ",")) + float64(len(text))/100
+		for depth, parent := 0, p.Parent(); depth < 2 && parent.Length() > 0; depth, parent = depth+1, parent.Parent() {
+			if parent.Is("html, body") {
+				break
+			}
+
+			scores[parent.Nodes[0]] += score / float64(depth+1)
+		}
+	})
+
+	var best *html.Node
+	var bestScore float64
+	for node, score := range scores {
+		if score > bestScore {
+			best, bestScore = node, score
+		}
+	}
+
+	if best == nil {
+		return doc.Find("body")
+	}
+
+	return doc.FindNodes(best)
+}
+
+// clean removes the presentational attributes from the content
+func clean(s *goquery.Selection) {
+	s.Find("*").AddSelection(s).Each(func(_ int, elem *goquery.Selection) {
+		for _, attr := range []string{"class", "id", "style", "onclick", "width", "height"} {
+			elem.RemoveAttr(attr)
+		}
+	})
+}
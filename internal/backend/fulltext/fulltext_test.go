@@ -0,0 +1,75 @@
+package fulltext
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestExtract if we get an error then the main content isn't extracted from the page
+func TestExtract(t *testing.T) {
+	file, err := os.Open("../../test/data/article.html")
+	if err != nil {
+		t.Fatalf("couldn't open the test page: %v", err)
+	}
+	defer file.Close()
+
+	content, err := Extract(file)
+	if err != nil {
+		t.Fatalf("couldn't extract the content: %v", err)
+	}
+
+	if !strings.Contains(content, "first paragraph") || !strings.Contains(content, "second paragraph") {
+		t.Errorf("expected the article paragraphs in the content, got %s", content)
+	}
+
+	for _, unwanted := range []string{"newsletter", "comment", "Copyright", "tracking", "About"} {
+		if strings.Contains(content, unwanted) {
+			t.Errorf("expected %q to be removed from the content, got %s", unwanted, content)
+		}
+	}
+
+	if strings.Contains(content, "class=") {
+		t.Errorf("expected the attributes to be cleaned, got %s", content)
+	}
+}
+
+// TestExtractArticle if we get an error then the semantic article element isn't preferred
+func TestExtractArticle(t *testing.T) {
+	page := `<html><body><div><p>Some text which is not the article but is long enough.</p></div>
+	<article><p>The article.</p></article></body></html>`
+
+	content, err := Extract(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("couldn't extract the content: %v", err)
+	}
+
+	if content != "<article><p>The article.</p></article>" {
+		t.Errorf("expected the article element, got %s", content)
+	}
+}
+
+// TestFetchWithLimit if we get an error then a page larger than the limit is downloaded and extracted
+func TestFetchWithLimit(t *testing.T) {
+	page := "<html><body><article><p>" + strings.Repeat("The article. ", 100) + "</p></article></body></html>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	if _, err := FetchWith(server.Client(), "goread", server.URL, int64(len(page)-1)); !errors.Is(err, ErrPageTooLarge) {
+		t.Errorf("expected a page too large error, got %v", err)
+	}
+
+	content, err := FetchWith(server.Client(), "goread", server.URL, int64(len(page)))
+	if err != nil {
+		t.Fatalf("couldn't fetch the page: %v", err)
+	}
+
+	if !strings.Contains(content, "The article.") {
+		t.Errorf("expected the article in the content, got %s", content)
+	}
+}
@@ -0,0 +1,163 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Level is how much is written to the log
+type Level string
+
+const (
+	// LevelOff doesn't write a log at all
+	LevelOff Level = "off"
+	// LevelInfo writes the usual messages and the errors
+	LevelInfo Level = "info"
+	// LevelDebug also writes the fetches with their timings and the cache hits and misses
+	LevelDebug Level = "debug"
+)
+
+// Levels contains all the available log levels
+var Levels = []Level{LevelOff, LevelInfo, LevelDebug}
+
+// Options contains the settings of the log
+type Options struct {
+	// File is the path of the log, goread.log in the temporary directory is used if it's empty
+	File  string `yaml:"file"`
+	Level Level  `yaml:"level"`
+	// MaxSize is the size in bytes after which the log is moved aside to a file ending with .1 and
+	// started over, 0 lets it grow forever
+	MaxSize int64 `yaml:"max_size"`
+}
+
+// DefaultOptions contains the default settings of the log
+var DefaultOptions = Options{
+	File:    "",
+	Level:   LevelInfo,
+	MaxSize: 5 << 20,
+}
+
+// debug is set if the debug messages are written
+var debug bool
+
+// Setup sends the standard logger to the log file, nothing is written to the terminal since it would
+// end up in the middle of the interface. The returned closer closes the file.
+func Setup(options Options) (io.Closer, error) {
+	debug = options.Level == LevelDebug
+	if options.Level == LevelOff {
+		log.SetOutput(io.Discard)
+		return io.NopCloser(nil), nil
+	}
+
+	path := options.File
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "goread.log")
+	}
+
+	file, err := openRotating(path, options.MaxSize)
+	if err != nil {
+		log.SetOutput(io.Discard)
+		return nil, fmt.Errorf("logging.Setup: %w", err)
+	}
+
+	log.SetOutput(file)
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
+	return file, nil
+}
+
+// Debug writes a message if the debug messages are turned on, the arguments are handled like in log.Println
+func Debug(v ...interface{}) {
+	if debug {
+		log.Println(append([]interface{}{"DEBUG"}, v...)...)
+	}
+}
+
+// rotatingFile is a log file which is moved aside once it grows past its maximum size
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	size    int64
+	file    *os.File
+}
+
+// openRotating opens the log file for appending
+func openRotating(path string, maxSize int64) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	r := &rotatingFile{path: path, maxSize: maxSize}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// open opens the file at the path and reads its size
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// Write fulfills the io.Writer interface, the file is rotated before a write which would make it too large
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil && r.file == nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate moves the current file aside, replacing the previous one, and starts a new file
+func (r *rotatingFile) rotate() error {
+	closeErr := r.file.Close()
+	r.file = nil
+
+	// The file is opened again even if it can't be moved aside, so the log goes on past its maximum size
+	renameErr := os.Rename(r.path, r.path+".1")
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	if renameErr != nil {
+		return renameErr
+	}
+
+	return closeErr
+}
+
+// Close fulfills the io.Closer interface
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+
+	return r.file.Close()
+}
@@ -0,0 +1,105 @@
+package logging
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRotate if we get an error the log grows past its maximum size or the old messages are lost
+func TestRotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "goread.log")
+	file, err := openRotating(path, 64)
+	if err != nil {
+		t.Fatalf("failed to open the log, %s", err)
+	}
+	defer file.Close()
+
+	for _, line := range []string{"first message, long enough\n", "second message, long enough\n", "third message\n"} {
+		if _, err = file.Write([]byte(line)); err != nil {
+			t.Fatalf("failed to write to the log, %s", err)
+		}
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the log, %s", err)
+	}
+
+	if string(current) != "third message\n" {
+		t.Errorf("expected the log to start over after the maximum size, got %q", current)
+	}
+
+	previous, err := os.ReadFile(path + ".1")
+	if err != nil {
+		t.Fatalf("failed to read the rotated log, %s", err)
+	}
+
+	if !strings.HasPrefix(string(previous), "first message") || !strings.Contains(string(previous), "second message") {
+		t.Errorf("expected the old messages in the rotated log, got %q", previous)
+	}
+}
+
+// TestRotateFailed if we get an error the log stops when the old file can't be moved aside
+func TestRotateFailed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goread.log")
+	if err := os.MkdirAll(filepath.Join(path+".1", "taken"), 0755); err != nil {
+		t.Fatalf("failed to block the rotated log, %s", err)
+	}
+
+	file, err := openRotating(path, 64)
+	if err != nil {
+		t.Fatalf("failed to open the log, %s", err)
+	}
+	defer file.Close()
+
+	for _, line := range []string{"first message, long enough\n", "second message, long enough\n", "third message\n"} {
+		_, _ = file.Write([]byte(line))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the log, %s", err)
+	}
+
+	if !strings.Contains(string(data), "third message") {
+		t.Errorf("expected the log to go on after a failed rotation, got %q", data)
+	}
+}
+
+// TestLevels if we get an error the debug messages are written without the debug level
+func TestLevels(t *testing.T) {
+	defer log.SetOutput(os.Stderr)
+	path := filepath.Join(t.TempDir(), "goread.log")
+
+	for _, level := range []Level{LevelInfo, LevelDebug} {
+		closer, err := Setup(Options{File: path, Level: level})
+		if err != nil {
+			t.Fatalf("failed to set up the log, %s", err)
+		}
+
+		log.Println("info at", level)
+		Debug("debug at", level)
+		closer.Close()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the log, %s", err)
+	}
+
+	content := string(data)
+	if !strings.Contains(content, "info at info") || !strings.Contains(content, "DEBUG debug at debug") {
+		t.Errorf("expected the messages of both levels, got %q", content)
+	}
+
+	if strings.Contains(content, "debug at info") {
+		t.Errorf("expected no debug messages at the info level, got %q", content)
+	}
+
+	if _, err = Setup(Options{Level: LevelOff}); err != nil || debug {
+		t.Errorf("expected the log to be turned off, %v", err)
+	}
+}
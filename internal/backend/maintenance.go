@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+)
+
+// ErrUnsupportedScope is returned when the articles of a tab can't be marked as read in bulk
+var ErrUnsupportedScope = errors.New("the saved articles can't be marked as read in bulk")
+
+// ParseCutoff parses a date like 2006-01-02 or an age like 2w, 30d or 12h into the point in time
+// before which the articles are considered old
+func ParseCutoff(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+
+	// Days and weeks aren't supported by time.ParseDuration
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	age, err := time.ParseDuration(value)
+	if value != "" {
+		if unit, ok := units[value[len(value)-1:]]; ok {
+			var count int
+			count, err = strconv.Atoi(value[:len(value)-1])
+			age = time.Duration(count) * unit
+		}
+	}
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("backend.ParseCutoff: expected a date like 2006-01-02 or an age like 30d, got %q", value)
+	}
+
+	if age < 0 {
+		return time.Time{}, fmt.Errorf("backend.ParseCutoff: the age can't be negative: %s", value)
+	}
+
+	return now.Add(-age), nil
+}
+
+// MarkReadBefore marks the cached articles published before the given time as read and saves the read
+// status right away. The scope is the name of a feed, a category or a combination, the virtual
+// categories use the feeds they aggregate and an empty scope marks the articles of every cached feed.
+func (b Backend) MarkReadBefore(before time.Time, scope string) (int, error) {
+	var feeds []*rss.Feed
+
+	switch scope {
+	case "":
+		// Every cached feed
+
+	case rss.AllFeedsName, rss.TodayFeedsName:
+		feeds = b.aggregatedFeeds()
+
+	case rss.DownloadedFeedsName, rss.ReadLaterFeedsName:
+		return 0, fmt.Errorf("backend.MarkReadBefore: %w", ErrUnsupportedScope)
+
+	default:
+		if strings.HasPrefix(scope, searchTitlePrefix) {
+			return 0, fmt.Errorf("backend.MarkReadBefore: %w", ErrUnsupportedScope)
+		}
+
+		var err error
+		if feeds, err = b.scopeFeeds(scope); err != nil {
+			return 0, fmt.Errorf("backend.MarkReadBefore: %w", err)
+		}
+	}
+
+	marked := b.Cache.MarkReadBefore(before, feeds, b.ReadStatus)
+	if err := b.ReadStatus.Save(); err != nil {
+		return marked, fmt.Errorf("backend.MarkReadBefore: %w", err)
+	}
+
+	return marked, nil
+}
+
+// scopeFeeds resolves the name of a feed, a category or a combination to its feeds
+func (b Backend) scopeFeeds(name string) ([]*rss.Feed, error) {
+	b.feedsMu.RLock()
+	defer b.feedsMu.RUnlock()
+
+	if feed, err := b.Rss.GetFeed(name); err == nil {
+		return []*rss.Feed{feed}, nil
+	}
+
+	if subscriptions, err := b.Rss.GetFeeds(name); err == nil {
+		feeds := make([]*rss.Feed, len(subscriptions))
+		for i := range subscriptions {
+			feeds[i] = &subscriptions[i]
+		}
+
+		return feeds, nil
+	}
+
+	return b.Rss.CombinationFeeds(name)
+}
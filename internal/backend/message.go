@@ -1,6 +1,9 @@
 package backend
 
 import (
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
 	"github.com/TypicalAM/goread/internal/ui/tab"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,7 +16,23 @@ type ArticleItem struct {
 	Desc            string
 	RawDesc         string
 	MarkdownContent string
+	PlainContent    string
+	RawContent      string
 	FeedURL         string
+	Thumbnail       string
+	Author          string
+	Words           int
+	Chars           int
+	// Index is the position of the article in the fetched list, the backend uses it to find the article
+	Index int
+	// GUID identifies the article across sessions, it's the key of its read status and its position
+	GUID string
+	// Position is the scroll offset the article was left at
+	Position int
+	// Published is when the article was published, it's zero if the feed doesn't say
+	Published time.Time
+	// Alert is set if the article contains one of the alert words of its feed
+	Alert bool
 }
 
 // FilterValue fulfills the list.Item interface
@@ -21,9 +40,19 @@ func (a ArticleItem) FilterValue() string {
 	return a.ArtTitle
 }
 
-// Title fulfills the list.DefaultItem interface
+// Title fulfills the list.DefaultItem interface, the image and the alert marks go after the title so that
+// the read and the saved marks stay in front
 func (a ArticleItem) Title() string {
-	return a.ArtTitle
+	title := a.ArtTitle
+	if a.Thumbnail != "" {
+		title += " " + imageMark
+	}
+
+	if a.Alert {
+		title += " " + AlertMark
+	}
+
+	return title
 }
 
 // Description fulfills the list.DefaultItem interface
@@ -40,9 +69,68 @@ type ArticleFetcher func(feedname string, refresh bool) tea.Cmd
 // FetchSuccessMsg is sent on fetch success.
 type FetchSuccessMsg struct{ Items []list.Item }
 
-// FetchArticleSuccessMsg is sent on article fetch success.
+// FetchArticleSuccessMsg is sent on article fetch success, the description and the link are only
+// set for single feeds which provide them. Failed contains the feeds of a bulk fetch which couldn't
+// be fetched. Older is set if the feed has older articles which can be loaded.
 type FetchArticleSuccessMsg struct {
-	Items []list.Item
+	Items       []list.Item
+	Moved       *MovedFeed
+	Notice      string
+	Description string
+	Link        string
+	Failed      []*rss.Feed
+	Older       bool
+}
+
+// RetryProgressMsg is sent after a round of fetching the failed feeds again. GaveUp are the feeds which
+// still failed after the last of their Rounds, Final is true if the retry queue is empty.
+type RetryProgressMsg struct {
+	Retried   int
+	Recovered int
+	GaveUp    []*rss.Feed
+	Rounds    int
+	Final     bool
+}
+
+// FeedUpdate is the number of new unread articles a background refresh found in a feed.
+type FeedUpdate struct {
+	Name string
+	New  int
+}
+
+// Alert is a new article which contains one of the alert words of its feed.
+type Alert struct {
+	Feed    string
+	Title   string
+	Keyword string
+}
+
+// BackgroundRefreshMsg is sent after a background refresh of the feeds, Updates contains the feeds
+// which have more unread articles than before and Alerts the new articles with an alert word.
+type BackgroundRefreshMsg struct {
+	Updates []FeedUpdate
+	Alerts  []Alert
+}
+
+// SaveStateMsg is sent after the state was saved in the background, Err is nil if it succeeded.
+type SaveStateMsg struct {
+	Err   error
+	saved func()
+}
+
+// MarkSaved marks the saved urls file as unmodified, it has to be called from the Update goroutine after
+// the save succeeded
+func (msg SaveStateMsg) MarkSaved() {
+	if msg.Err == nil && msg.saved != nil {
+		msg.saved()
+	}
+}
+
+// MovedFeed describes a feed which is consistently permanently redirected to a new url.
+type MovedFeed struct {
+	Name   string
+	OldURL string
+	NewURL string
 }
 
 // FetchErrorMsg is sent on fetch error.
@@ -92,6 +180,28 @@ func DownloadItem(feedName string, index int) tea.Cmd {
 	return func() tea.Msg { return DownloadItemMsg{feedName, index} }
 }
 
+// DownloadEnclosureMsg contains info the browser needs to know to download the enclosure of an item.
+type DownloadEnclosureMsg struct {
+	FeedName string
+	Index    int
+}
+
+// DownloadEnclosure is called from a tab to tell the browser that the enclosure of an item needs to be downloaded.
+func DownloadEnclosure(feedName string, index int) tea.Cmd {
+	return func() tea.Msg { return DownloadEnclosureMsg{feedName, index} }
+}
+
+// ReadLaterItemMsg contains info the browser needs to know to queue an item to be read later.
+type ReadLaterItemMsg struct {
+	FeedName string
+	Index    int
+}
+
+// ReadLaterItem is called from a tab to tell the browser that an item needs to be queued to be read later.
+func ReadLaterItem(feedName string, index int) tea.Cmd {
+	return func() tea.Msg { return ReadLaterItemMsg{feedName, index} }
+}
+
 // MakeChoiceMsg contains info needed to create a binary choice prompt.
 type MakeChoiceMsg struct {
 	Question string
@@ -107,16 +217,86 @@ func MakeChoice(question string, defaultChoice bool) tea.Cmd {
 type MarkAsReadMsg string
 
 // MarkAsRead is called from a tab to tell the browser that an item needs to be marked as read.
-func MarkAsRead(url string) tea.Cmd {
-	return func() tea.Msg { return MarkAsReadMsg(url) }
+func MarkAsRead(id string) tea.Cmd {
+	return func() tea.Msg { return MarkAsReadMsg(id) }
 }
 
 // MarkAsUnreadMsg contains info needed to mark an item as unread.
 type MarkAsUnreadMsg string
 
 // MarkAsUnread is called from a tab to tell the browser that an item needs to be marked as unread.
-func MarkAsUnread(url string) tea.Cmd {
-	return func() tea.Msg { return MarkAsUnreadMsg(url) }
+func MarkAsUnread(id string) tea.Cmd {
+	return func() tea.Msg { return MarkAsUnreadMsg(id) }
+}
+
+// ToggleSortOrderMsg contains the name of the feed whose articles need to be listed in the other order.
+type ToggleSortOrderMsg string
+
+// ToggleSortOrder is called from a tab to tell the browser that a feed needs to be listed in the other order.
+func ToggleSortOrder(feedName string) tea.Cmd {
+	return func() tea.Msg { return ToggleSortOrderMsg(feedName) }
+}
+
+// ToggleMuteMsg contains the name of the feed which needs to be muted or unmuted.
+type ToggleMuteMsg string
+
+// ToggleMute is called from a tab to tell the browser that a feed needs to be muted or unmuted.
+func ToggleMute(feedName string) tea.Cmd {
+	return func() tea.Msg { return ToggleMuteMsg(feedName) }
+}
+
+// TogglePinMsg contains the name of the category which needs to be pinned or unpinned.
+type TogglePinMsg string
+
+// TogglePin is called from a tab to tell the browser that a category needs to be pinned or unpinned.
+func TogglePin(catName string) tea.Cmd {
+	return func() tea.Msg { return TogglePinMsg(catName) }
+}
+
+// ShowFeedInfoMsg contains the name of the feed whose info needs to be shown.
+type ShowFeedInfoMsg string
+
+// ShowFeedInfo is called from a tab to tell the browser to show the url and the fetch stats of a feed.
+func ShowFeedInfo(feedName string) tea.Cmd {
+	return func() tea.Msg { return ShowFeedInfoMsg(feedName) }
+}
+
+// RenameFeedMsg contains the name of the feed which needs to be renamed.
+type RenameFeedMsg string
+
+// RenameFeed is called from a tab to tell the browser that a feed needs a new name.
+func RenameFeed(feedName string) tea.Cmd {
+	return func() tea.Msg { return RenameFeedMsg(feedName) }
+}
+
+// SetRenderModeMsg contains the name of the feed whose articles are shown in the render mode by default.
+type SetRenderModeMsg struct {
+	FeedName string
+	Mode     rss.RenderMode
+}
+
+// SetRenderMode is called from a tab to tell the browser that the render mode of a feed needs to be saved.
+func SetRenderMode(feedName string, mode rss.RenderMode) tea.Cmd {
+	return func() tea.Msg { return SetRenderModeMsg{feedName, mode} }
+}
+
+// ExportCategoryMsg contains the name of the category which needs to be exported as opml.
+type ExportCategoryMsg string
+
+// ExportCategory is called from a tab to tell the browser that a category needs to be exported.
+func ExportCategory(catName string) tea.Cmd {
+	return func() tea.Msg { return ExportCategoryMsg(catName) }
+}
+
+// SavePositionMsg contains the scroll offset of an article which needs to be remembered.
+type SavePositionMsg struct {
+	GUID   string
+	Offset int
+}
+
+// SavePosition is called from a tab to tell the browser how far an article was scrolled.
+func SavePosition(guid string, offset int) tea.Cmd {
+	return func() tea.Msg { return SavePositionMsg{guid, offset} }
 }
 
 // SetEnableKeybindMsg contains the desired state of the keybinds.
@@ -127,6 +307,14 @@ func SetEnableKeybind(enable bool) tea.Cmd {
 	return func() tea.Msg { return SetEnableKeybindMsg(enable) }
 }
 
+// SetStatusMsg contains the text which the browser shows in the status bar.
+type SetStatusMsg string
+
+// SetStatus is called from a tab to tell the browser to show a message in the status bar.
+func SetStatus(text string) tea.Cmd {
+	return func() tea.Msg { return SetStatusMsg(text) }
+}
+
 // ShowErrorMsg is an error message "thrown" by a tab
 type ShowErrorMsg struct {
 	Msg string
@@ -137,6 +325,12 @@ func ShowError(msg string) tea.Cmd {
 	return func() tea.Msg { return ShowErrorMsg{Msg: msg} }
 }
 
+// EscapeMsg tells the browser that esc was pressed in a tab which had nothing left to back out of.
+type EscapeMsg struct{}
+
+// Escape is called from a tab to let the browser decide what esc should do next.
+func Escape() tea.Cmd { return func() tea.Msg { return EscapeMsg{} } }
+
 // StartQuittingMsg prompts the browser to start quitting (and perform a last browser redraw).
 type StartQuittingMsg struct{}
 
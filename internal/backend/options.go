@@ -0,0 +1,69 @@
+package backend
+
+import "time"
+
+// SortOrder is the order in which the categories and feeds are listed
+type SortOrder string
+
+const (
+	// SortManual keeps the order from the urls file
+	SortManual SortOrder = "manual"
+	// SortAlphabetical sorts the items by their name
+	SortAlphabetical SortOrder = "alphabetical"
+	// SortUnread puts the items with the most unread articles first
+	SortUnread SortOrder = "unread"
+)
+
+// SortOrders contains all the available sort orders
+var SortOrders = []SortOrder{SortManual, SortAlphabetical, SortUnread}
+
+// TodayWindow is the time window of the today category
+type TodayWindow string
+
+const (
+	// WindowToday includes the articles published since midnight
+	WindowToday TodayWindow = "today"
+	// WindowDay includes the articles published in the last 24 hours
+	WindowDay TodayWindow = "24h"
+)
+
+// TodayWindows contains all the available today windows
+var TodayWindows = []TodayWindow{WindowToday, WindowDay}
+
+// Options contains the behaviour settings for the backend
+type Options struct {
+	SortOrder   SortOrder   `yaml:"sort_order"`
+	TodayWindow TodayWindow `yaml:"today_window"`
+	// RefreshCooldown is the minimum time between two manual refreshes of the same tab, 0 disables it
+	RefreshCooldown time.Duration `yaml:"refresh_cooldown"`
+	// DownloadedMaxCount is the maximum number of downloaded articles which are kept, 0 keeps all of them
+	DownloadedMaxCount int `yaml:"downloaded_max_count"`
+	// DownloadedMaxAge is how long the downloaded articles are kept for, 0 keeps them forever
+	DownloadedMaxAge time.Duration `yaml:"downloaded_max_age"`
+	// RetryRounds is how many times the feeds which failed in a bulk fetch are fetched again, 0 disables it
+	RetryRounds int `yaml:"retry_rounds"`
+	// RetryDelay is the time between the rounds of fetching the failed feeds again
+	RetryDelay time.Duration `yaml:"retry_delay"`
+	// EnclosureDir is the directory the podcast episodes are downloaded to, the Downloads directory in the
+	// home directory is used if it's empty
+	EnclosureDir string `yaml:"enclosure_dir"`
+	// EnclosureOverwrite downloads an episode again if it was already downloaded
+	EnclosureOverwrite bool `yaml:"enclosure_overwrite"`
+	// RefreshInterval is the time between the background refreshes of the expired feeds, 0 disables them
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	// ActiveHours is the daily window of the local time in which the background refreshes run, like
+	// 07:00-23:00. They run all day if it's empty.
+	ActiveHours string `yaml:"active_hours"`
+	// AlertWords are the keywords which trigger an alert in every feed, they are used together with the
+	// alert words of the feeds
+	AlertWords []string `yaml:"alert_words"`
+}
+
+// DefaultOptions contains the default settings for the backend
+var DefaultOptions = Options{
+	SortOrder:       SortManual,
+	TodayWindow:     WindowToday,
+	RefreshCooldown: 30 * time.Second,
+	RetryRounds:     3,
+	RetryDelay:      30 * time.Second,
+}
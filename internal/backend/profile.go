@@ -0,0 +1,73 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// DefaultProfile is the name of the profile which uses the default urls file and cache directory
+const DefaultProfile = "default"
+
+// profileNameRegex matches the names which can be safely used as directory names
+var profileNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateProfileName returns an error if the name can't be used as a profile name
+func ValidateProfileName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("backend.ValidateProfileName: invalid profile name %q, only letters, digits, - and _ are allowed", name)
+	}
+
+	return nil
+}
+
+// ProfilePaths returns the urls file and the cache directory of a profile, every profile except for
+// the default one keeps them in its own directories. Empty paths mean the default locations.
+func ProfilePaths(profile string) (urlPath, cacheDir string, err error) {
+	if profile == "" || profile == DefaultProfile {
+		return "", "", nil
+	}
+
+	if err = ValidateProfileName(profile); err != nil {
+		return "", "", fmt.Errorf("backend.ProfilePaths: %w", err)
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", "", fmt.Errorf("backend.ProfilePaths: %w", err)
+	}
+
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", "", fmt.Errorf("backend.ProfilePaths: %w", err)
+	}
+
+	urlPath = filepath.Join(configDir, "goread", "profiles", profile, "urls.yml")
+	cacheDir = filepath.Join(userCacheDir, "goread", "profiles", profile)
+	return urlPath, cacheDir, nil
+}
+
+// Profiles returns the names of the profiles which were created so far, the default profile is always first
+func Profiles() ([]string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("backend.Profiles: %w", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(configDir, "goread", "profiles"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("backend.Profiles: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateProfileName(entry.Name()) == nil && entry.Name() != DefaultProfile {
+			names = append(names, entry.Name())
+		}
+	}
+
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...), nil
+}
@@ -0,0 +1,139 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FetchRawSuccessMsg is sent on raw feed fetch success.
+type FetchRawSuccessMsg struct{ Content string }
+
+// FetchRawFeed gets the unparsed body of a feed, used for debugging feeds which render incorrectly.
+func (b Backend) FetchRawFeed(feedName string) tea.Cmd {
+	return func() tea.Msg {
+		// A failed debug view shouldn't put the tab in an error state, so we just show the error
+		feed, _, err := b.lookupFeed(feedName)
+		if err != nil {
+			return ShowErrorMsg{fmt.Sprintf("Error while trying to get the feed url: %v", err)}
+		}
+
+		if b.Cache.OfflineMode {
+			return ShowErrorMsg{"Cannot fetch the raw feed in offline mode"}
+		}
+
+		data, err := b.Cache.FetchRaw(feed)
+		if err != nil {
+			return ShowErrorMsg{fmt.Sprintf("Error while fetching the raw feed: %v", err)}
+		}
+
+		return FetchRawSuccessMsg{prettyPrint(data)}
+	}
+}
+
+// prettyPrint indents the feed body if it is JSON or XML, otherwise it is returned as is.
+func prettyPrint(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return string(data)
+	}
+
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		var b bytes.Buffer
+		if err := json.Indent(&b, trimmed, "", "  "); err != nil {
+			return string(data)
+		}
+
+		return b.String()
+	}
+
+	if trimmed[0] == '<' {
+		if indented, err := indentXML(trimmed); err == nil {
+			return indented
+		}
+	}
+
+	return string(data)
+}
+
+// indentXML puts every XML token on its own line, indented by its depth. The raw tokens are used so
+// that the namespace prefixes are left as they were in the original document.
+func indentXML(data []byte) (string, error) {
+	var b strings.Builder
+	depth := 0
+	writeLine := func(line string) {
+		b.WriteString(strings.Repeat("  ", depth))
+		b.WriteString(line)
+		b.WriteRune('\n')
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			var tag strings.Builder
+			tag.WriteString("<" + qualifiedName(token.Name))
+			for _, attr := range token.Attr {
+				tag.WriteString(" " + qualifiedName(attr.Name) + `="`)
+				_ = xml.EscapeText(&tag, []byte(attr.Value))
+				tag.WriteRune('"')
+			}
+
+			tag.WriteRune('>')
+			writeLine(tag.String())
+			depth++
+
+		case xml.EndElement:
+			if depth > 0 {
+				depth--
+			}
+
+			writeLine("</" + qualifiedName(token.Name) + ">")
+
+		case xml.CharData:
+			text := bytes.TrimSpace(token)
+			if len(text) == 0 {
+				continue
+			}
+
+			var escaped strings.Builder
+			_ = xml.EscapeText(&escaped, text)
+			writeLine(escaped.String())
+
+		case xml.Comment:
+			writeLine("<!--" + string(token) + "-->")
+
+		case xml.ProcInst:
+			writeLine("<?" + token.Target + " " + string(token.Inst) + "?>")
+
+		case xml.Directive:
+			writeLine("<!" + string(token) + ">")
+		}
+	}
+
+	return b.String(), nil
+}
+
+// qualifiedName returns the name with its namespace prefix.
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
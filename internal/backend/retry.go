@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+)
+
+// retryQueue contains the feeds which failed in a bulk fetch and wait to be fetched again. The failures
+// of every bulk fetch are merged into it and a single chain of retry rounds runs while it isn't empty,
+// so that overlapping fetches don't retry the same feeds over and over.
+type retryQueue struct {
+	mu      sync.Mutex
+	pending []*queuedRetry
+	running bool
+}
+
+// queuedRetry is a failed feed and the number of rounds it was already retried in
+type queuedRetry struct {
+	feed   *rss.Feed
+	rounds int
+}
+
+// RetryFailed adds the feeds which failed in a bulk fetch to the retry queue, the feeds which are queued
+// already keep their rounds. A command which runs the first round after a delay is returned if the
+// retries weren't running yet, nil is returned if they were, if they are disabled or if there is nothing
+// to retry.
+func (b Backend) RetryFailed(feeds []*rss.Feed) tea.Cmd {
+	if len(feeds) == 0 || b.options.RetryRounds <= 0 || b.Cache.OfflineMode {
+		return nil
+	}
+
+	q := b.retries
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, feed := range feeds {
+		if q.find(feed.URL) == nil {
+			q.pending = append(q.pending, &queuedRetry{feed: feed})
+		}
+	}
+
+	if q.running {
+		return nil
+	}
+
+	q.running = true
+	return b.retryRound()
+}
+
+// RetryNext runs the next round of the retries after a round which left feeds in the queue
+func (b Backend) RetryNext() tea.Cmd {
+	q := b.retries
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.running {
+		return nil
+	}
+
+	return b.retryRound()
+}
+
+// retryRound fetches the queued feeds again after the retry delay. The feeds which recover or use up
+// their rounds leave the queue, the chain stops once it's empty.
+func (b Backend) retryRound() tea.Cmd {
+	q := b.retries
+	return tea.Tick(b.options.RetryDelay, func(time.Time) tea.Msg {
+		q.mu.Lock()
+		batch := append([]*queuedRetry(nil), q.pending...)
+		q.mu.Unlock()
+
+		msg := RetryProgressMsg{Rounds: b.options.RetryRounds}
+		failing := make(map[string]bool)
+		if !b.Cache.OfflineMode {
+			feeds := make([]*rss.Feed, len(batch))
+			for i, queued := range batch {
+				feeds[i] = queued.feed
+			}
+
+			for _, feed := range b.Cache.RetryFeeds(feeds) {
+				failing[feed.URL] = true
+			}
+
+			msg.Retried = len(batch)
+		}
+
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		retried := make(map[*queuedRetry]bool, len(batch))
+		for _, queued := range batch {
+			retried[queued] = true
+		}
+
+		kept := make([]*queuedRetry, 0, len(q.pending))
+		for _, queued := range q.pending {
+			switch {
+			case !retried[queued]:
+				// Added while this round was running
+				kept = append(kept, queued)
+
+			case b.Cache.OfflineMode:
+				// Going offline drops the queue
+
+			case !failing[queued.feed.URL]:
+				msg.Recovered++
+
+			case queued.rounds+1 >= b.options.RetryRounds:
+				msg.GaveUp = append(msg.GaveUp, queued.feed)
+
+			default:
+				queued.rounds++
+				kept = append(kept, queued)
+			}
+		}
+
+		q.pending = kept
+		msg.Final = len(kept) == 0
+		q.running = !msg.Final
+		return msg
+	})
+}
+
+// find returns the queued feed with the url, nil if it isn't queued
+func (q *retryQueue) find(url string) *queuedRetry {
+	for _, queued := range q.pending {
+		if queued.feed.URL == url {
+			return queued
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package rss
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+// Boilerplate strips the blocks which feeds put at the start of every article, like a logo or a
+// "Read on our site" link
+type Boilerplate struct {
+	selectors []cascadia.Selector
+	patterns  []*regexp.Regexp
+}
+
+// NewBoilerplate compiles the css selectors and the regular expressions which match the boilerplate
+// blocks, a block matches a selector if it is the selected element or if it only wraps selected
+// elements without any text of its own, like a paragraph with a logo in it
+func NewBoilerplate(selectors, patterns []string) (*Boilerplate, error) {
+	b := &Boilerplate{}
+	for _, selector := range selectors {
+		compiled, err := cascadia.Compile(selector)
+		if err != nil {
+			return nil, fmt.Errorf("rss.NewBoilerplate: invalid selector %q: %w", selector, err)
+		}
+
+		b.selectors = append(b.selectors, compiled)
+	}
+
+	for _, pattern := range patterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rss.NewBoilerplate: invalid pattern %q: %w", pattern, err)
+		}
+
+		b.patterns = append(b.patterns, compiled)
+	}
+
+	return b, nil
+}
+
+// Empty returns true if nothing would be stripped
+func (b *Boilerplate) Empty() bool {
+	return b == nil || (len(b.selectors) == 0 && len(b.patterns) == 0)
+}
+
+// Strip removes the leading blocks of the html which match the boilerplate, it stops at the first
+// block which doesn't. The content is returned as it is if nothing was removed.
+func (b *Boilerplate) Strip(content string) string {
+	if b.Empty() || strings.TrimSpace(content) == "" {
+		return content
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+
+	removed := false
+	body := doc.Find("body")
+	for {
+		first := body.Children().First()
+		if first.Length() == 0 || !b.matches(first) {
+			break
+		}
+
+		// Text outside of the elements is part of the article, like an unwrapped first paragraph
+		if prev := first.Nodes[0].PrevSibling; prev != nil && strings.TrimSpace(goquery.NewDocumentFromNode(prev).Text()) != "" {
+			break
+		}
+
+		first.Remove()
+		removed = true
+	}
+
+	if !removed {
+		return content
+	}
+
+	stripped, err := body.Html()
+	if err != nil {
+		return content
+	}
+
+	return strings.TrimSpace(stripped)
+}
+
+// matches returns true if the block is boilerplate
+func (b *Boilerplate) matches(block *goquery.Selection) bool {
+	text := strings.TrimSpace(block.Text())
+	for _, selector := range b.selectors {
+		if selector.Match(block.Nodes[0]) || (text == "" && block.FindMatcher(selector).Length() > 0) {
+			return true
+		}
+	}
+
+	for _, pattern := range b.patterns {
+		if text != "" && pattern.MatchString(text) {
+			return true
+		}
+	}
+
+	return false
+}
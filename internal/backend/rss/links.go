@@ -0,0 +1,83 @@
+package rss
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ResolveLinks makes the relative links and sources in the html absolute using the base url, the
+// protocol-relative ones like //example.com/image.png get the scheme of the base. The content is
+// returned as it is if nothing was resolved.
+func ResolveLinks(content, base string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil || !baseURL.IsAbs() || !strings.Contains(content, "<") {
+		return content
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+
+	resolved := false
+	doc.Find("[href], [src]").Each(func(_ int, s *goquery.Selection) {
+		for _, attr := range []string{"href", "src"} {
+			if value, ok := s.Attr(attr); ok {
+				if absolute, ok := resolveURL(baseURL, value); ok {
+					s.SetAttr(attr, absolute)
+					resolved = true
+				}
+			}
+		}
+	})
+
+	if !resolved {
+		return content
+	}
+
+	html, err := doc.Find("body").Html()
+	if err != nil {
+		return content
+	}
+
+	return html
+}
+
+// resolveURL resolves a relative url against the base, ok is false if the url is already absolute or
+// only points within the article
+func resolveURL(base *url.URL, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.HasPrefix(value, "#") {
+		return "", false
+	}
+
+	ref, err := url.Parse(value)
+	if err != nil || ref.IsAbs() {
+		return "", false
+	}
+
+	return base.ResolveReference(ref).String(), true
+}
+
+// LinkBase returns the url the relative links of an article are resolved against: its own link, which
+// can be relative to the homepage of the feed, which in turn can be relative to the url of the feed
+func LinkBase(feedURL, feedLink, itemLink string) string {
+	base, err := url.Parse(feedURL)
+	if err != nil {
+		return ""
+	}
+
+	for _, link := range []string{feedLink, itemLink} {
+		if link = strings.TrimSpace(link); link == "" {
+			continue
+		}
+
+		if ref, err := url.Parse(link); err == nil {
+			base = base.ResolveReference(ref)
+		}
+	}
+
+	return base.String()
+}
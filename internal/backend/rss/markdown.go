@@ -0,0 +1,159 @@
+package rss
+
+import (
+	"strconv"
+	"strings"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/PuerkitoBio/goquery"
+)
+
+// definitionIndent is used to indent the definitions in a definition list, non-breaking spaces are
+// used since markdown strips leading spaces and turns four of them into a code block
+const definitionIndent = "\u00a0\u00a0\u00a0\u00a0"
+
+// footnoteAttr marks the elements which replaced the footnote references
+const footnoteAttr = "data-goread-footnote"
+
+// markdownRules are the rules added on top of the commonmark ones
+var markdownRules = []md.Rule{
+	{
+		Filter: []string{"dl"},
+		Replacement: func(content string, _ *goquery.Selection, _ *md.Options) *string {
+			return md.String("\n\n" + content + "\n\n")
+		},
+	},
+	{
+		Filter: []string{"dt"},
+		Replacement: func(content string, _ *goquery.Selection, _ *md.Options) *string {
+			term := strings.Join(strings.Fields(content), " ")
+			if term == "" {
+				return md.String("")
+			}
+
+			return md.String("\n\n**" + term + "**\n\n")
+		},
+	},
+	{
+		Filter: []string{"dd"},
+		Replacement: func(content string, _ *goquery.Selection, _ *md.Options) *string {
+			lines := strings.Split(strings.TrimSpace(content), "\n")
+			for i, line := range lines {
+				if line = strings.TrimSpace(line); line != "" {
+					lines[i] = definitionIndent + line
+				}
+			}
+
+			return md.String("\n\n" + strings.Join(lines, "\n") + "\n\n")
+		},
+	},
+	{
+		Filter: []string{"sup"},
+		Replacement: func(_ string, selec *goquery.Selection, _ *md.Options) *string {
+			number, ok := selec.Attr(footnoteAttr)
+			if !ok {
+				return nil
+			}
+
+			return md.String("[" + number + "]")
+		},
+	},
+}
+
+// newConverter creates a html to markdown converter with the additional rules
+func newConverter() *md.Converter {
+	return md.NewConverter("", true, nil).AddRules(markdownRules...)
+}
+
+// footnotes moves the footnotes of an article to the end, the references are replaced with
+// numbers in the order they appear in and the notes are collected in a numbered list
+type footnotes struct {
+	notes []string
+}
+
+// collect is a before hook which replaces the footnote references and removes the notes from the
+// document, a link is a footnote reference if it points to a list item in the same document
+func (f *footnotes) collect(selec *goquery.Selection) {
+	items := make(map[string]*goquery.Selection)
+	selec.Find("li[id]").Each(func(_ int, item *goquery.Selection) {
+		items[item.AttrOr("id", "")] = item
+	})
+
+	if len(items) == 0 {
+		return
+	}
+
+	var order []*goquery.Selection
+	numbers := make(map[string]int)
+	refIDs := make(map[string]bool)
+	selec.Find(`a[href^="#"]`).Each(func(_ int, ref *goquery.Selection) {
+		id := strings.TrimPrefix(ref.AttrOr("href", ""), "#")
+		item, ok := items[id]
+		if !ok || ref.Closest("li[id]").IsSelection(item) {
+			return
+		}
+
+		number, ok := numbers[id]
+		if !ok {
+			order = append(order, item)
+			number = len(order)
+			numbers[id] = number
+		}
+
+		// Most generators wrap the reference in a sup element, replace the whole thing
+		target := ref
+		if parent := ref.Parent(); parent.Is("sup") && parent.Children().Length() == 1 {
+			target = parent
+		}
+
+		for _, el := range []*goquery.Selection{ref, target} {
+			if refID, ok := el.Attr("id"); ok {
+				refIDs[refID] = true
+			}
+		}
+
+		target.ReplaceWithHtml(`<sup ` + footnoteAttr + `="` + strconv.Itoa(number) + `"></sup>`)
+	})
+
+	conv := newConverter()
+	for _, item := range order {
+		// The back references only make sense in the original document
+		item.Find(`a[href^="#"]`).Each(func(_ int, link *goquery.Selection) {
+			id := strings.TrimPrefix(link.AttrOr("href", ""), "#")
+			if refIDs[id] || link.HasClass("footnote-backref") || link.AttrOr("rev", "") == "footnote" {
+				link.Remove()
+			}
+		})
+
+		note := conv.Convert(item)
+		f.notes = append(f.notes, strings.Join(strings.Fields(note), " "))
+
+		list := item.Parent()
+		item.Remove()
+		if list.Children().Length() > 0 {
+			continue
+		}
+
+		if section := list.Closest(`.footnotes, [role="doc-endnotes"]`); section.Length() > 0 {
+			section.Remove()
+		} else {
+			list.Remove()
+		}
+	}
+}
+
+// append is an after hook which adds the collected notes to the end of the markdown
+func (f *footnotes) append(markdown string) string {
+	if len(f.notes) == 0 {
+		return markdown
+	}
+
+	var b strings.Builder
+	b.WriteString(markdown)
+	b.WriteString("\n\n## Footnotes\n\n")
+	for i, note := range f.notes {
+		b.WriteString(strconv.Itoa(i+1) + ". " + note + "\n")
+	}
+
+	return b.String()
+}
@@ -1,19 +1,38 @@
 package rss
 
-import "errors"
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
 
 var ErrAlreadyExists = errors.New("already exists")
 var ErrTooManyItems = errors.New("too many items")
 var ErrReservedName = errors.New("reserved name")
 var ErrEmptyName = errors.New("empty name")
+var ErrInvalidColor = errors.New("invalid color, expected a hex color like #f38ba8")
+var ErrInvalidRenderMode = errors.New("invalid render mode, expected markdown, plain or raw")
+var ErrInvalidURL = errors.New("invalid url, expected an address like https://example.com/feed")
+var ErrNothingToUndo = errors.New("nothing to undo")
 
-// AddCategory will add a category to the Rss structure
+// hexColor matches the short and the long hex colors
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// AddCategory will add a category to the Rss structure, the name can't be empty, taken by another
+// category or by one of the virtual categories. The errors are meant to be shown to the user as they are.
 func (rss *Rss) AddCategory(name string, description string) error {
-	// Check if the name is empty
-	if name == "" {
+	// Check if the name is empty, a name made of spaces would look empty in the list
+	if strings.TrimSpace(name) == "" {
 		return ErrEmptyName
 	}
 
+	// Check if the name is reserved, the category would be hidden behind the virtual one
+	if IsReservedName(name) {
+		return ErrReservedName
+	}
+
 	// Check if there are too many categories
 	if len(rss.Categories) >= 36 {
 		return ErrTooManyItems
@@ -44,7 +63,7 @@ func (rss *Rss) AddFeed(category string, name string, url string) error {
 	}
 
 	// Check if the name is reserved
-	if name == AllFeedsName || name == DownloadedFeedsName {
+	if IsReservedName(name) {
 		return ErrReservedName
 	}
 
@@ -53,6 +72,11 @@ func (rss *Rss) AddFeed(category string, name string, url string) error {
 		return errors.New("you must include a URL")
 	}
 
+	url, err := NormalizeURL(url)
+	if err != nil {
+		return err
+	}
+
 	// Check if the feed already exists
 	for _, cat := range rss.Categories {
 		if cat.Name == category {
@@ -102,6 +126,18 @@ func (rss *Rss) RemoveCategory(name string) error {
 	return ErrNotFound
 }
 
+// RemoveCombination will remove a combination of categories, the categories themselves are kept
+func (rss *Rss) RemoveCombination(name string) error {
+	for i, combination := range rss.Combinations {
+		if combination.Name == name {
+			rss.Combinations = append(rss.Combinations[:i], rss.Combinations[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrNotFound
+}
+
 // RemoveFeed will remove a feed from the Rss structure
 func (rss *Rss) RemoveFeed(category string, name string) error {
 	for i, cat := range rss.Categories {
@@ -134,7 +170,7 @@ func (rss *Rss) UpdateCategory(key, name, desc string) error {
 	}
 
 	// Check if the name is reserved
-	if key == AllFeedsName || key == DownloadedFeedsName || name == AllFeedsName || name == DownloadedFeedsName {
+	if IsReservedName(key) || IsReservedName(name) {
 		return ErrReservedName
 	}
 
@@ -167,7 +203,7 @@ func (rss *Rss) UpdateFeed(category, key, name, url string) error {
 	}
 
 	// Check if the name is reserved
-	if name == AllFeedsName || name == DownloadedFeedsName {
+	if IsReservedName(name) {
 		return ErrReservedName
 	}
 
@@ -176,6 +212,11 @@ func (rss *Rss) UpdateFeed(category, key, name, url string) error {
 		return errors.New("you must include a URL")
 	}
 
+	url, err := NormalizeURL(url)
+	if err != nil {
+		return err
+	}
+
 	// Find the category
 	for _, cat := range rss.Categories {
 		if cat.Name == category {
@@ -206,3 +247,215 @@ func (rss *Rss) UpdateFeed(category, key, name, url string) error {
 	// We couldn't find the feed
 	return ErrNotFound
 }
+
+// RenameFeed will change the display name of a feed in a category, the url and the position of the feed
+// stay the same. The cache is keyed by the url, so the cached articles are kept too.
+func (rss *Rss) RenameFeed(category, oldName, newName string) error {
+	if newName == "" {
+		return ErrEmptyName
+	}
+
+	if IsReservedName(newName) {
+		return ErrReservedName
+	}
+
+	for i, cat := range rss.Categories {
+		if cat.Name != category {
+			continue
+		}
+
+		index := -1
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == newName && newName != oldName {
+				return ErrAlreadyExists
+			}
+
+			if feed.Name == oldName {
+				index = j
+			}
+		}
+
+		if index == -1 {
+			return ErrNotFound
+		}
+
+		rss.Categories[i].Subscriptions[index].Name = newName
+		return nil
+	}
+
+	// We couldn't find the category
+	return ErrNotFound
+}
+
+// UpdateFeedURL will change the url of a feed by its name, used when the feed has moved
+func (rss *Rss) UpdateFeedURL(name, url string) error {
+	// Check if there is a url
+	if url == "" {
+		return errors.New("you must include a URL")
+	}
+
+	url, err := NormalizeURL(url)
+	if err != nil {
+		return err
+	}
+
+	for i, cat := range rss.Categories {
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == name {
+				rss.Categories[i].Subscriptions[j].URL = url
+				return nil
+			}
+		}
+	}
+
+	// We couldn't find the feed
+	return ErrNotFound
+}
+
+// SetFeedColor will set the color of a feed by its name, an empty color resets it to the colorscheme
+func (rss *Rss) SetFeedColor(name, color string) error {
+	color = strings.TrimSpace(color)
+	if color != "" && !hexColor.MatchString(color) {
+		return ErrInvalidColor
+	}
+
+	for i, cat := range rss.Categories {
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == name {
+				rss.Categories[i].Subscriptions[j].Color = strings.ToLower(color)
+				return nil
+			}
+		}
+	}
+
+	// We couldn't find the feed
+	return ErrNotFound
+}
+
+// SetFeedRenderMode will set how the articles of a feed are shown by its name, an empty mode resets it to
+// the default one
+func (rss *Rss) SetFeedRenderMode(name string, mode RenderMode) error {
+	if mode != "" && !slices.Contains(RenderModes, mode) {
+		return ErrInvalidRenderMode
+	}
+
+	for i, cat := range rss.Categories {
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == name {
+				rss.Categories[i].Subscriptions[j].RenderMode = mode
+				return nil
+			}
+		}
+	}
+
+	// We couldn't find the feed
+	return ErrNotFound
+}
+
+// ToggleMute will mute or unmute a feed by its name, muted feeds are left out of the virtual categories
+func (rss *Rss) ToggleMute(name string) (muted bool, err error) {
+	for i, cat := range rss.Categories {
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == name {
+				rss.Categories[i].Subscriptions[j].Muted = !feed.Muted
+				return !feed.Muted, nil
+			}
+		}
+	}
+
+	// We couldn't find the feed
+	return false, ErrNotFound
+}
+
+// ToggleOldestFirst will flip the order of the articles of a feed by its name, between the newest and the
+// oldest article first
+func (rss *Rss) ToggleOldestFirst(name string) (oldestFirst bool, err error) {
+	for i, cat := range rss.Categories {
+		for j, feed := range cat.Subscriptions {
+			if feed.Name == name {
+				rss.Categories[i].Subscriptions[j].OldestFirst = !feed.OldestFirst
+				return !feed.OldestFirst, nil
+			}
+		}
+	}
+
+	// We couldn't find the feed
+	return false, ErrNotFound
+}
+
+// TogglePin will pin or unpin a category by its name, pinned categories are listed first
+func (rss *Rss) TogglePin(name string) (pinned bool, err error) {
+	for i, cat := range rss.Categories {
+		if cat.Name == name {
+			rss.Categories[i].Pinned = !cat.Pinned
+			return !cat.Pinned, nil
+		}
+	}
+
+	// We couldn't find the category
+	return false, ErrNotFound
+}
+
+// Deduplicate will remove the feeds which have the same url as a feed before them, feeds which only
+// share a name are kept
+func (rss *Rss) Deduplicate() (removed int) {
+	seen := make(map[string]bool)
+	for i, cat := range rss.Categories {
+		kept := make([]Feed, 0, len(cat.Subscriptions))
+		for _, feed := range cat.Subscriptions {
+			canonical := canonicalURL(feed.URL)
+			if seen[canonical] {
+				removed++
+				continue
+			}
+
+			seen[canonical] = true
+			kept = append(kept, feed)
+		}
+
+		rss.Categories[i].Subscriptions = kept
+	}
+
+	return removed
+}
+
+// NormalizeURL prepends https:// to a url which was pasted without a scheme and checks that the result
+// is a valid url, the web urls need a host
+func NormalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+
+	// A host with a port like localhost:8080 parses as a scheme with an opaque rest
+	if parsed, err := url.Parse(raw); err != nil || parsed.Scheme == "" || parsed.Opaque != "" {
+		raw = "https://" + raw
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme == "" {
+		return "", ErrInvalidURL
+	}
+
+	if (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host == "" {
+		return "", ErrInvalidURL
+	}
+
+	return raw, nil
+}
+
+// canonicalURL normalizes the parts of a url which don't change the feed it points to
+func canonicalURL(raw string) string {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" {
+		return strings.TrimSpace(raw)
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	if port := parsed.Port(); (parsed.Scheme == "http" && port == "80") || (parsed.Scheme == "https" && port == "443") {
+		parsed.Host = parsed.Hostname()
+	}
+
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+	parsed.RawPath = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
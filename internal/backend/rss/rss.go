@@ -4,14 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
-	md "github.com/JohannesKaufmann/html-to-markdown"
 	"github.com/PuerkitoBio/goquery"
+	"github.com/TypicalAM/goread/internal/backend/atomicfile"
 	"github.com/gilliek/go-opml/opml"
 	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
 	"gopkg.in/yaml.v3"
 )
 
@@ -21,6 +26,12 @@ var AllFeedsName = "All Feeds"
 // DownloadedFeedsName is the name of the downloaded feeds category
 var DownloadedFeedsName = "Saved"
 
+// ReadLaterFeedsName is the name of the category with the read later queue
+var ReadLaterFeedsName = "Read later"
+
+// TodayFeedsName is the name of the category with the articles published today
+var TodayFeedsName = "Today"
+
 // DefaultCategoryName is the name of the default category
 var DefaultCategoryName = "News"
 
@@ -61,26 +72,77 @@ var Default = Rss{
 
 // Rss will be used to structurize the rss feeds and categories
 type Rss struct {
-	filePath   string
-	Categories []Category `yaml:"categories"`
+	filePath     string
+	saved        string
+	undo         *checkpoint
+	Categories   []Category    `yaml:"categories"`
+	Combinations []Combination `yaml:"combinations,omitempty"`
 }
 
 // Category will be used to structurize the rss feeds
 type Category struct {
-	Name          string `yaml:"name"`
-	Description   string `yaml:"desc"`
-	Subscriptions []Feed `yaml:"subscriptions"`
-}
-
-// Feed is a single rss feed
-type Feed struct {
 	Name           string   `yaml:"name"`
 	Description    string   `yaml:"desc"`
-	URL            string   `yaml:"url"`
+	Subscriptions  []Feed   `yaml:"subscriptions"`
+	Pinned         bool     `yaml:"pinned,omitempty"`
 	WhitelistWords []string `yaml:"whitelist_words,omitempty"`
 	BlacklistWords []string `yaml:"blacklist_words,omitempty"`
 }
 
+// Combination is a named group of categories whose articles are read together
+type Combination struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"desc,omitempty"`
+	Categories  []string `yaml:"categories"`
+}
+
+// Feed is a single rss feed
+type Feed struct {
+	Name           string     `yaml:"name"`
+	Description    string     `yaml:"desc"`
+	URL            string     `yaml:"url"`
+	WhitelistWords []string   `yaml:"whitelist_words,omitempty"`
+	BlacklistWords []string   `yaml:"blacklist_words,omitempty"`
+	FullText       bool       `yaml:"full_text,omitempty"`
+	Muted          bool       `yaml:"muted,omitempty"`
+	MinWords       int        `yaml:"min_words,omitempty"`
+	Color          string     `yaml:"color,omitempty"`
+	RenderMode     RenderMode `yaml:"render_mode,omitempty"`
+	StripSelectors []string   `yaml:"strip_selectors,omitempty"`
+	StripPatterns  []string   `yaml:"strip_patterns,omitempty"`
+	TitleStrip     string     `yaml:"title_strip,omitempty"`
+	// InsecureSkipVerify fetches the feed without verifying its tls certificate, for self-hosted feeds
+	// with a self-signed one
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty"`
+	// OldestFirst lists the articles of the feed from the oldest one, for serials which are read in order
+	OldestFirst bool `yaml:"oldest_first,omitempty"`
+	// AlertWords are the keywords which trigger an alert when a background refresh finds a new article
+	// containing one of them
+	AlertWords []string `yaml:"alert_words,omitempty"`
+	// CaseSensitive matches the whitelist and the blacklist words with their case, by default the case
+	// is ignored
+	CaseSensitive bool `yaml:"case_sensitive,omitempty"`
+	// Headers are added to the requests for the feed, like an api key. A value which is entirely $NAME or
+	// ${NAME} is read from the environment to keep the secrets out of the urls file. The pages of the
+	// articles are fetched without them.
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// RenderMode is how the articles are shown
+type RenderMode string
+
+const (
+	// RenderMarkdown converts the articles to styled markdown
+	RenderMarkdown RenderMode = "markdown"
+	// RenderPlain shows the text of the articles without any styling
+	RenderPlain RenderMode = "plain"
+	// RenderRaw shows the html of the articles as it is in the feed
+	RenderRaw RenderMode = "raw"
+)
+
+// RenderModes contains all the available render modes
+var RenderModes = []RenderMode{RenderMarkdown, RenderPlain, RenderRaw}
+
 // New will create a new Rss structure
 func New(path string) (*Rss, error) {
 	log.Println("Creating new rss structure")
@@ -105,6 +167,7 @@ func (rss *Rss) Load() error {
 	data, err := os.ReadFile(rss.filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
+			rss.markSaved()
 			return nil
 		}
 
@@ -115,12 +178,64 @@ func (rss *Rss) Load() error {
 		return fmt.Errorf("rss.Load: %w", err)
 	}
 
+	for _, cat := range rss.Categories {
+		for _, feed := range cat.Subscriptions {
+			if feed.RenderMode != "" && !slices.Contains(RenderModes, feed.RenderMode) {
+				return fmt.Errorf("rss.Load: unrecognized render mode of %s: %s", feed.Name, feed.RenderMode)
+			}
+
+			if _, err = NewBoilerplate(feed.StripSelectors, feed.StripPatterns); err != nil {
+				return fmt.Errorf("rss.Load: %s: %w", feed.Name, err)
+			}
+
+			if _, err = regexp.Compile(feed.TitleStrip); err != nil {
+				return fmt.Errorf("rss.Load: invalid title strip of %s: %w", feed.Name, err)
+			}
+
+			for name := range feed.Headers {
+				if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+					return fmt.Errorf("rss.Load: invalid header name of %s: %q", feed.Name, name)
+				}
+			}
+		}
+	}
+
+	rss.markSaved()
 	log.Printf("Rss loaded with %d categories\n", len(rss.Categories))
 	return nil
 }
 
+// Reload reads the file again, replacing the categories and the combinations in memory. The structure is
+// left as it was if the file is missing or broken.
+func (rss *Rss) Reload() error {
+	if _, err := os.Stat(rss.filePath); err != nil {
+		return fmt.Errorf("rss.Reload: %w", err)
+	}
+
+	fresh := Rss{filePath: rss.filePath}
+	if err := fresh.Load(); err != nil {
+		return fmt.Errorf("rss.Reload: %w", err)
+	}
+
+	*rss = fresh
+	return nil
+}
+
+// Modified checks if the structure was changed since it was last loaded or saved
+func (rss Rss) Modified() bool {
+	data, err := yaml.Marshal(rss)
+	return err != nil || string(data) != rss.saved
+}
+
+// markSaved remembers the current state as the one in the file
+func (rss *Rss) markSaved() {
+	if data, err := yaml.Marshal(rss); err == nil {
+		rss.saved = string(data)
+	}
+}
+
 // Save will write the Rss structure to a file
-func (rss Rss) Save() error {
+func (rss *Rss) Save() error {
 	yamlData, err := yaml.Marshal(rss)
 	if err != nil {
 		return fmt.Errorf("rss.Save: %w", err)
@@ -136,9 +251,26 @@ func (rss Rss) Save() error {
 		}
 	}
 
+	rss.saved = string(yamlData)
 	return nil
 }
 
+// Snapshot will encode the Rss structure and return a function which writes it to the file atomically
+// and a function which marks the encoded structure as saved. The writing doesn't touch the structure so it
+// can happen in the background, the marking has to wait until the write succeeded and happen on the
+// goroutine which changes the structure.
+func (rss *Rss) Snapshot() (write func() error, markSaved func(), err error) {
+	yamlData, err := yaml.Marshal(rss)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rss.Snapshot: %w", err)
+	}
+
+	path := rss.filePath
+	write = func() error { return atomicfile.Write(path, yamlData) }
+	markSaved = func() { rss.saved = string(yamlData) }
+	return write, markSaved, nil
+}
+
 // GetFeeds will return a list of all subscriptions in a category
 func (rss Rss) GetFeeds(categoryName string) ([]Feed, error) {
 	for _, cat := range rss.Categories {
@@ -152,7 +284,7 @@ func (rss Rss) GetFeeds(categoryName string) ([]Feed, error) {
 
 // GetFeed will return the information about a feed using its name
 func (rss Rss) GetFeed(feedName string) (*Feed, error) {
-	if feedName == AllFeedsName || feedName == DownloadedFeedsName {
+	if IsReservedName(feedName) {
 		return nil, ErrReservedName
 	}
 
@@ -167,6 +299,109 @@ func (rss Rss) GetFeed(feedName string) (*Feed, error) {
 	return nil, ErrNotFound
 }
 
+// FindFeed will return a feed and the name of its category using the name or the url of the feed
+func (rss Rss) FindFeed(id string) (*Feed, string, error) {
+	for _, cat := range rss.Categories {
+		for _, feed := range cat.Subscriptions {
+			if feed.Name == id {
+				return &feed, cat.Name, nil
+			}
+		}
+	}
+
+	// The names take precedence, a feed could be named like the url of another one
+	wanted := canonicalURL(id)
+	for _, cat := range rss.Categories {
+		for _, feed := range cat.Subscriptions {
+			if canonicalURL(feed.URL) == wanted {
+				return &feed, cat.Name, nil
+			}
+		}
+	}
+
+	return nil, "", ErrNotFound
+}
+
+// GetCombination will return a combination of categories using its name
+func (rss Rss) GetCombination(name string) (*Combination, error) {
+	for i := range rss.Combinations {
+		if rss.Combinations[i].Name == name {
+			return &rss.Combinations[i], nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+// CombinationFeeds will return the feeds from the categories of a combination, the muted feeds are
+// skipped like in the virtual categories and the feeds subscribed to in multiple categories are only
+// returned once. The keyword filters of the categories are merged into the feeds.
+func (rss Rss) CombinationFeeds(name string) ([]*Feed, error) {
+	combination, err := rss.GetCombination(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var feeds []*Feed
+	seen := make(map[string]bool)
+	for _, catName := range combination.Categories {
+		for i := range rss.Categories {
+			cat := &rss.Categories[i]
+			if cat.Name != catName {
+				continue
+			}
+
+			for j := range cat.Subscriptions {
+				feed := &cat.Subscriptions[j]
+				if feed.Muted || seen[canonicalURL(feed.URL)] {
+					continue
+				}
+
+				seen[canonicalURL(feed.URL)] = true
+				feeds = append(feeds, withFilters(cat, feed))
+			}
+		}
+	}
+
+	return feeds, nil
+}
+
+// WithCategoryFilters returns the feed with the keyword filters of its category merged in, the
+// blacklists add up while the whitelist of the feed replaces the one of the category. The feed is
+// returned as is if its category doesn't filter the articles.
+func (rss Rss) WithCategoryFilters(feed *Feed) *Feed {
+	for i := range rss.Categories {
+		for _, sub := range rss.Categories[i].Subscriptions {
+			if sub.Name == feed.Name && sub.URL == feed.URL {
+				return withFilters(&rss.Categories[i], feed)
+			}
+		}
+	}
+
+	return feed
+}
+
+// withFilters merges the keyword filters of the category into a copy of the feed
+func withFilters(cat *Category, feed *Feed) *Feed {
+	if len(cat.WhitelistWords) == 0 && len(cat.BlacklistWords) == 0 {
+		return feed
+	}
+
+	merged := *feed
+	merged.BlacklistWords = append(append([]string{}, cat.BlacklistWords...), feed.BlacklistWords...)
+	if len(merged.WhitelistWords) == 0 {
+		merged.WhitelistWords = cat.WhitelistWords
+	}
+
+	return &merged
+}
+
+// IsReservedName checks if the name belongs to one of the virtual categories
+func IsReservedName(name string) bool {
+	return name == AllFeedsName || name == DownloadedFeedsName || name == TodayFeedsName ||
+		name == ReadLaterFeedsName
+}
+
 // GetAllURLs will return a list of all the available feeds
 func (rss Rss) GetAllFeeds() []*Feed {
 	var feeds []*Feed
@@ -191,8 +426,8 @@ func YassifyItem(item *gofeed.Item) string {
 	mdown += "# " + item.Title + "\n "
 
 	// If there are no authors, then don't add the author
-	if item.Authors != nil {
-		mdown += item.Authors[0].Name + "\n"
+	if author := ItemAuthor(item); author != "" {
+		mdown += author + "\n"
 	}
 
 	// Show when the article was published if available
@@ -201,6 +436,11 @@ func YassifyItem(item *gofeed.Item) string {
 		mdown += "Published: " + item.PublishedParsed.Format("2006-01-02 15:04:05")
 	}
 
+	// Show the lead image
+	if image := LeadImage(item); image != "" {
+		mdown += "\n\n![Lead image](" + image + ")"
+	}
+
 	// Convert the html to markdown
 	mdown += "\n\n"
 	htmlMarkdown, err := HTMLToMarkdown(item.Description)
@@ -234,9 +474,217 @@ func YassifyItem(item *gofeed.Item) string {
 	return mdown
 }
 
-// HTMLToMarkdown converts html to markdown using the html-to-markdown library
+// PlainItem will return the item as plain text, it is an alternative to YassifyItem used when the
+// markdown conversion mangles the article
+func PlainItem(item *gofeed.Item) string {
+	var b strings.Builder
+	b.WriteString(item.Title + "\n")
+
+	if author := ItemAuthor(item); author != "" {
+		b.WriteString(author + "\n")
+	}
+
+	if item.PublishedParsed != nil {
+		b.WriteString("Published: " + item.PublishedParsed.Format("2006-01-02 15:04:05") + "\n")
+	}
+
+	for _, content := range []string{item.Description, item.Content} {
+		text, err := HTMLToText(content)
+		if err != nil {
+			text = content
+		}
+
+		if text = strings.TrimSpace(text); text != "" {
+			b.WriteString("\n" + text + "\n")
+		}
+	}
+
+	if len(item.Links) > 0 {
+		b.WriteString("\nLinks:\n")
+		for _, link := range item.Links {
+			b.WriteString(link + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// RawItem will return the article with its html left as it is in the feed
+func RawItem(item *gofeed.Item) string {
+	var b strings.Builder
+	b.WriteString(item.Title + "\n")
+
+	for _, content := range []string{item.Description, item.Content} {
+		if content = strings.TrimSpace(content); content != "" {
+			b.WriteString("\n" + content + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// ItemAuthor returns the name of the first author of the item, or their email if the feed doesn't give
+// the name. An empty string is returned if the item has no author.
+func ItemAuthor(item *gofeed.Item) string {
+	authors := item.Authors
+	if len(authors) == 0 && item.Author != nil {
+		authors = []*gofeed.Person{item.Author}
+	}
+
+	for _, author := range authors {
+		if author == nil {
+			continue
+		}
+
+		if name := strings.TrimSpace(author.Name); name != "" {
+			return name
+		}
+
+		if email := strings.TrimSpace(author.Email); email != "" {
+			return email
+		}
+	}
+
+	return ""
+}
+
+// TextStats returns the number of words and characters in the text of the item, the content is used
+// if the item has one and the description otherwise. Whitespace runs are counted as a single character.
+func TextStats(item *gofeed.Item) (words, chars int) {
+	content := item.Content
+	if strings.TrimSpace(content) == "" {
+		content = item.Description
+	}
+
+	text, err := HTMLToText(content)
+	if err != nil {
+		text = content
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return 0, 0
+	}
+
+	return len(fields), utf8.RuneCountInString(strings.Join(fields, " "))
+}
+
+// LeadImage returns the url of the first image attached to the item using Media RSS, the item image,
+// an enclosure or embedded in the article. An empty string is returned if there is no image.
+func LeadImage(item *gofeed.Item) string {
+	if media, ok := item.Extensions["media"]; ok {
+		if url := mediaImage(media); url != "" {
+			return url
+		}
+
+		// The media elements can be grouped
+		for _, group := range media["group"] {
+			if url := mediaImage(group.Children); url != "" {
+				return url
+			}
+		}
+	}
+
+	if item.Image != nil && item.Image.URL != "" {
+		return item.Image.URL
+	}
+
+	for _, enclosure := range item.Enclosures {
+		if strings.HasPrefix(enclosure.Type, "image/") {
+			return enclosure.URL
+		}
+	}
+
+	// Fall back to the images embedded in the article
+	for _, content := range []string{item.Content, item.Description} {
+		if url := contentImage(content, item.Link); url != "" {
+			return url
+		}
+	}
+
+	return ""
+}
+
+// trackerHosts are the hosts of the tracking pixels which are embedded in the articles
+var trackerHosts = []string{
+	"feeds.feedburner.com",
+	"feedpress.me",
+	"pixel.wp.com",
+	"stats.wordpress.com",
+	"www.google-analytics.com",
+	"pixel.quantserve.com",
+	"www.facebook.com",
+}
+
+// contentImage returns the url of the first image in the html which isn't a tracking pixel, relative
+// urls are resolved against the link of the article
+func contentImage(content, link string) string {
+	if !strings.Contains(content, "<img") {
+		return ""
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return ""
+	}
+
+	base, err := url.Parse(link)
+	if err != nil {
+		base = &url.URL{}
+	}
+
+	var image string
+	doc.Find("img[src]").EachWithBreak(func(_ int, img *goquery.Selection) bool {
+		if isPixel(img.AttrOr("width", "")) || isPixel(img.AttrOr("height", "")) {
+			return true
+		}
+
+		ref, err := url.Parse(strings.TrimSpace(img.AttrOr("src", "")))
+		if err != nil {
+			return true
+		}
+
+		src := base.ResolveReference(ref)
+		if (src.Scheme != "http" && src.Scheme != "https") || slices.Contains(trackerHosts, src.Hostname()) {
+			return true
+		}
+
+		image = src.String()
+		return false
+	})
+
+	return image
+}
+
+// isPixel returns true if the image dimension is one of the dimensions used by the tracking pixels
+func isPixel(dimension string) bool {
+	dimension = strings.TrimSuffix(strings.TrimSpace(dimension), "px")
+	return dimension == "0" || dimension == "1"
+}
+
+// mediaImage returns the url of the first thumbnail or image-type content in the media elements
+func mediaImage(media map[string][]ext.Extension) string {
+	for _, thumbnail := range media["thumbnail"] {
+		if url := thumbnail.Attrs["url"]; url != "" {
+			return url
+		}
+	}
+
+	for _, content := range media["content"] {
+		isImage := content.Attrs["medium"] == "image" || strings.HasPrefix(content.Attrs["type"], "image/")
+		if url := content.Attrs["url"]; isImage && url != "" {
+			return url
+		}
+	}
+
+	return ""
+}
+
+// HTMLToMarkdown converts html to markdown using the html-to-markdown library, definition lists are
+// rendered as bold terms with indented definitions and footnotes are moved to a numbered list at the end
 func HTMLToMarkdown(content string) (string, error) {
-	markdown, err := md.NewConverter("", true, nil).ConvertString(content)
+	notes := &footnotes{}
+	markdown, err := newConverter().Before(notes.collect).After(notes.append).ConvertString(content)
 	if err != nil {
 		return "", fmt.Errorf("HTMLToMarkdown: %w", err)
 	}
@@ -283,15 +731,137 @@ func (rss *Rss) LoadOPML(path string) error {
 	return nil
 }
 
+// OPMLSummary describes the changes made by merging an opml file
+type OPMLSummary struct {
+	// Added is the number of feeds which weren't subscribed to before
+	Added int
+	// Updated is the number of existing categories which got new feeds
+	Updated int
+	// Skipped is the number of feeds which were already subscribed to or whose name is taken in their category
+	Skipped int
+}
+
+// MergeOPML will add the feeds from an opml file which aren't subscribed to yet, the feeds which are
+// already subscribed to keep their category and settings even if the file lists them elsewhere.
+// Categories are only created for the new feeds.
+func (rss *Rss) MergeOPML(path string) (OPMLSummary, error) {
+	parsed, err := opml.NewOPMLFromFile(path)
+	if err != nil {
+		return OPMLSummary{}, fmt.Errorf("rss.MergeOPML: %w", err)
+	}
+
+	subscribed := make(map[string]bool)
+	for _, feed := range rss.GetAllFeeds() {
+		subscribed[canonicalURL(feed.URL)] = true
+	}
+
+	existing := make(map[string]bool, len(rss.Categories))
+	for _, cat := range rss.Categories {
+		existing[cat.Name] = true
+	}
+
+	var summary OPMLSummary
+	updated := make(map[string]bool)
+	merge := func(catName, catDesc string, o opml.Outline) error {
+		if subscribed[canonicalURL(o.XMLURL)] {
+			summary.Skipped++
+			return nil
+		}
+
+		if err := rss.AddCategory(catName, catDesc); err != nil && !errors.Is(err, ErrAlreadyExists) {
+			return err
+		}
+
+		name := o.Title
+		if name == "" {
+			name = o.Text
+		}
+
+		if err := rss.AddFeed(catName, name, o.XMLURL); err != nil {
+			if errors.Is(err, ErrAlreadyExists) {
+				summary.Skipped++
+				return nil
+			}
+
+			return err
+		}
+
+		log.Println("Merged feed:", name)
+		subscribed[canonicalURL(o.XMLURL)] = true
+		summary.Added++
+		if existing[catName] {
+			updated[catName] = true
+		}
+
+		return nil
+	}
+
+	for _, o := range parsed.Outlines() {
+		if len(o.Outlines) == 0 && o.XMLURL != "" {
+			if err = merge(DefaultCategoryName, DefaultCategoryDescription, o); err != nil {
+				return OPMLSummary{}, fmt.Errorf("rss.MergeOPML: %w", err)
+			}
+
+			continue
+		}
+
+		for _, so := range o.Outlines {
+			if err = merge(o.Title, o.Text, so); err != nil {
+				return OPMLSummary{}, fmt.Errorf("rss.MergeOPML: %w", err)
+			}
+		}
+	}
+
+	summary.Updated = len(updated)
+	return summary, nil
+}
+
 // ExportOPML will export the urls to an opml file.
 func (rss *Rss) ExportOPML(path string) error {
+	data, err := toOPML(rss.Categories)
+	if err != nil {
+		return fmt.Errorf("rss.ExportOPML: %w", err)
+	}
+
+	if err = os.WriteFile(path, []byte(data), 0600); err != nil {
+		return fmt.Errorf("rss.ExportOPML: %w", err)
+	}
+
+	return nil
+}
+
+// ExportCategoryOPML will return the opml text with the feeds of a single category, the virtual
+// categories can't be exported since they don't have feeds of their own.
+func (rss *Rss) ExportCategoryOPML(name string) (string, error) {
+	if IsReservedName(name) {
+		return "", fmt.Errorf("rss.ExportCategoryOPML: %s is a virtual category: %w", name, ErrReservedName)
+	}
+
+	for _, cat := range rss.Categories {
+		if cat.Name != name {
+			continue
+		}
+
+		data, err := toOPML([]Category{cat})
+		if err != nil {
+			return "", fmt.Errorf("rss.ExportCategoryOPML: %w", err)
+		}
+
+		return data, nil
+	}
+
+	return "", fmt.Errorf("rss.ExportCategoryOPML: %w", ErrNotFound)
+}
+
+// toOPML serializes the categories and their feeds as opml
+func toOPML(categories []Category) (string, error) {
 	result := opml.OPML{
 		Version: "1.0",
 		Head:    opml.Head{Title: "goread - Exported feeds"},
 		Body:    opml.Body{},
 	}
 
-	for _, cat := range rss.Categories {
+	for _, cat := range categories {
 		result.Body.Outlines = append(result.Body.Outlines, opml.Outline{
 			Title: cat.Name,
 			Text:  cat.Description,
@@ -308,16 +878,7 @@ func (rss *Rss) ExportOPML(path string) error {
 		}
 	}
 
-	data, err := result.XML()
-	if err != nil {
-		return fmt.Errorf("rss.ExportOPML: %w", err)
-	}
-
-	if err = os.WriteFile(path, []byte(data), 0600); err != nil {
-		return fmt.Errorf("rss.ExportOPML: %w", err)
-	}
-
-	return nil
+	return result.XML()
 }
 
 // HTMLToText converts html to text using the goquery library
@@ -1,12 +1,17 @@
 package rss
 
 import (
+	"errors"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/gilliek/go-opml/opml"
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
 )
 
 func getRss(t *testing.T) *Rss {
@@ -101,6 +106,32 @@ func TestRssGetFeedURL(t *testing.T) {
 	}
 }
 
+// TestRssFindFeed if we get an error a feed can't be found by its name or its url
+func TestRssFindFeed(t *testing.T) {
+	myRss := getRss(t)
+	feed, category, err := myRss.FindFeed("Ars Technica")
+	if err != nil {
+		t.Fatalf("failed to find the feed by name, %s", err)
+	}
+
+	if feed.Name != "Ars Technica" || category != "Technology" {
+		t.Errorf("incorrect feed, got %s in %s", feed.Name, category)
+	}
+
+	byURL, category, err := myRss.FindFeed(feed.URL)
+	if err != nil {
+		t.Fatalf("failed to find the feed by url, %s", err)
+	}
+
+	if byURL.Name != "Ars Technica" || category != "Technology" {
+		t.Errorf("incorrect feed, got %s in %s", byURL.Name, category)
+	}
+
+	if _, _, err = myRss.FindFeed("Non-existent"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
 // TestRssGetAllURLs if we get an error then all the urls are not retrieved correctly
 func TestRssGetAllURLs(t *testing.T) {
 	myRss := getRss(t)
@@ -111,7 +142,7 @@ func TestRssGetAllURLs(t *testing.T) {
 	}
 
 	if urls[0].URL != "https://primordialsoup.info/feed" {
-		t.Errorf("incorrect url, expected https://primordialsoup.info/feed, got %s", urls[0])
+		t.Errorf("incorrect url, expected https://primordialsoup.info/feed, got %s", urls[0].URL)
 	}
 }
 
@@ -138,6 +169,18 @@ func TestRssCategoryAdd(t *testing.T) {
 		t.Errorf("expected an error (ErrAlreadyExists), got nil")
 	}
 
+	if err := myRss.AddCategory("  ", "Blank category"); err != ErrEmptyName {
+		t.Errorf("expected an error (ErrEmptyName), got %v", err)
+	}
+
+	if err := myRss.AddCategory(AllFeedsName, "Reserved category"); err != ErrReservedName {
+		t.Errorf("expected an error (ErrReservedName), got %v", err)
+	}
+
+	if len(myRss.Categories) != 3 {
+		t.Errorf("expected the invalid categories not to be added, got %d categories", len(myRss.Categories))
+	}
+
 	// Check if we can add a new category if there are more than 36 already
 	for i := 0; i < 36; i++ {
 		_ = myRss.AddCategory(strconv.Itoa(i), "Some other new category")
@@ -337,6 +380,172 @@ func TestRssFeedUpdate(t *testing.T) {
 	}
 }
 
+// TestRssFeedUpdateURL if we get an error updating the url of a moved feed doesn't work
+func TestRssFeedUpdateURL(t *testing.T) {
+	myRss := getRss(t)
+	if err := myRss.UpdateFeedURL("Ars Technica", "https://moved.feed"); err != nil {
+		t.Errorf("failed to update feed url, %s", err)
+	}
+
+	feed, err := myRss.GetFeed("Ars Technica")
+	if err != nil {
+		t.Errorf("failed to get feed, %s", err)
+	}
+
+	if feed.URL != "https://moved.feed" {
+		t.Errorf("incorrect url, expected https://moved.feed, got %s", feed.URL)
+	}
+
+	if err = myRss.UpdateFeedURL("Non-existent", "https://other.feed"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestRssNormalizeURL if we get an error the urls pasted without a scheme aren't fixed or malformed ones are accepted
+func TestRssNormalizeURL(t *testing.T) {
+	valid := map[string]string{
+		"example.com/feed":             "https://example.com/feed",
+		"  example.com/feed ":          "https://example.com/feed",
+		"localhost:8080/rss":           "https://localhost:8080/rss",
+		"http://example.com/feed":      "http://example.com/feed",
+		"gemini://example.com/gemlog/": "gemini://example.com/gemlog/",
+
+		"example.com/feed?url=https://x": "https://example.com/feed?url=https://x",
+	}
+
+	for raw, expected := range valid {
+		if normalized, err := NormalizeURL(raw); err != nil || normalized != expected {
+			t.Errorf("expected %q to be normalized to %q, got %q, %v", raw, expected, normalized, err)
+		}
+	}
+
+	for _, raw := range []string{"https://", "exa mple.com/feed", "https://%zz"} {
+		if _, err := NormalizeURL(raw); err != ErrInvalidURL {
+			t.Errorf("expected ErrInvalidURL for %q, got %v", raw, err)
+		}
+	}
+
+	myRss := getRss(t)
+	if err := myRss.AddFeed("News", "Pasted feed", "example.com/feed"); err != nil {
+		t.Fatalf("failed to add feed, %s", err)
+	}
+
+	if feed, _ := myRss.GetFeed("Pasted feed"); feed == nil || feed.URL != "https://example.com/feed" {
+		t.Errorf("expected the feed to get a scheme, got %v", feed)
+	}
+
+	if err := myRss.UpdateFeedURL("Pasted feed", "https://"); err != ErrInvalidURL {
+		t.Errorf("expected ErrInvalidURL, got %v", err)
+	}
+}
+
+// TestRssFeedColor if we get an error the color of a feed isn't validated or saved
+func TestRssFeedColor(t *testing.T) {
+	myRss := getRss(t)
+	if err := myRss.SetFeedColor("Ars Technica", "#F38BA8"); err != nil {
+		t.Errorf("failed to set the feed color, %s", err)
+	}
+
+	feed, err := myRss.GetFeed("Ars Technica")
+	if err != nil {
+		t.Fatalf("failed to get feed, %s", err)
+	}
+
+	if feed.Color != "#f38ba8" {
+		t.Errorf("incorrect color, expected #f38ba8, got %s", feed.Color)
+	}
+
+	for _, color := range []string{"f38ba8", "#f38ba", "#ggg", "red"} {
+		if err = myRss.SetFeedColor("Ars Technica", color); err != ErrInvalidColor {
+			t.Errorf("expected ErrInvalidColor for %q, got %v", color, err)
+		}
+	}
+
+	if err = myRss.SetFeedColor("Ars Technica", ""); err != nil {
+		t.Errorf("failed to reset the feed color, %s", err)
+	}
+
+	if feed, _ = myRss.GetFeed("Ars Technica"); feed.Color != "" {
+		t.Errorf("expected the color to be reset, got %s", feed.Color)
+	}
+
+	if err = myRss.SetFeedColor("Non-existent", "#fff"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestRssFeedRenderMode if we get an error the render mode of a feed isn't validated or saved
+func TestRssFeedRenderMode(t *testing.T) {
+	myRss := getRss(t)
+	if err := myRss.SetFeedRenderMode("Ars Technica", RenderPlain); err != nil {
+		t.Errorf("failed to set the render mode, %s", err)
+	}
+
+	if feed, _ := myRss.GetFeed("Ars Technica"); feed.RenderMode != RenderPlain {
+		t.Errorf("incorrect render mode, expected plain, got %s", feed.RenderMode)
+	}
+
+	if err := myRss.SetFeedRenderMode("Ars Technica", "fancy"); err != ErrInvalidRenderMode {
+		t.Errorf("expected ErrInvalidRenderMode, got %v", err)
+	}
+
+	if err := myRss.SetFeedRenderMode("Non-existent", RenderRaw); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "urls.yml")
+	data := "categories:\n  - name: News\n    subscriptions:\n      - name: Broken\n        url: https://example.com\n        render_mode: fancy\n"
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write the urls file, %s", err)
+	}
+
+	loaded, err := New(path)
+	if err != nil {
+		t.Fatalf("error creating rss object: %v", err)
+	}
+
+	if err = loaded.Load(); err == nil {
+		t.Error("expected an error for an unrecognized render mode")
+	}
+}
+
+// TestRssDeduplicate if we get an error duplicate feeds aren't removed or distinct feeds are
+func TestRssDeduplicate(t *testing.T) {
+	myRss := getRss(t)
+	if err := myRss.AddCategory("Imported", ""); err != nil {
+		t.Fatalf("failed to add category, %s", err)
+	}
+
+	feeds := []Feed{
+		{Name: "Primordial soup", URL: "HTTPS://PrimordialSoup.info:443/feed/"},
+		{Name: "Ars Technica", URL: "https://example.com/other"},
+		{Name: "Other", URL: "https://example.com/other#top"},
+	}
+	myRss.Categories[len(myRss.Categories)-1].Subscriptions = feeds
+
+	before := len(myRss.GetAllFeeds())
+	if removed := myRss.Deduplicate(); removed != 2 {
+		t.Errorf("incorrect number of removed feeds, expected 2, got %d", removed)
+	}
+
+	if after := len(myRss.GetAllFeeds()); after != before-2 {
+		t.Errorf("incorrect number of feeds, expected %d, got %d", before-2, after)
+	}
+
+	imported, err := myRss.GetFeeds("Imported")
+	if err != nil {
+		t.Fatalf("failed to get feeds, %s", err)
+	}
+
+	if len(imported) != 1 || imported[0].Name != "Ars Technica" {
+		t.Errorf("expected only the first occurrence of the distinct feed to be kept, got %v", imported)
+	}
+
+	if removed := myRss.Deduplicate(); removed != 0 {
+		t.Errorf("expected no feeds to be removed the second time, got %d", removed)
+	}
+}
+
 // TestRssFeedRemove if we get an error removing a feed doesn't work
 func TestRssFeedRemove(t *testing.T) {
 	myRss := getRss(t)
@@ -362,6 +571,111 @@ func TestRssFeedRemove(t *testing.T) {
 	}
 }
 
+// TestRssCombinations if we get an error the feeds of the combined categories aren't resolved correctly
+func TestRssCombinations(t *testing.T) {
+	myRss := getRss(t)
+	if err := myRss.AddFeed("News", "Ars Technica again", "http://feeds.arstechnica.com/arstechnica/technology-lab/"); err != nil {
+		t.Fatalf("failed to add feed, %s", err)
+	}
+
+	myRss.Combinations = []Combination{{Name: "Everything", Categories: []string{"News", "Technology", "Non-existent"}}}
+	feeds, err := myRss.CombinationFeeds("Everything")
+	if err != nil {
+		t.Fatalf("failed to get the combined feeds, %s", err)
+	}
+
+	// The second Ars Technica subscription has the same url
+	names := make([]string, len(feeds))
+	for i, feed := range feeds {
+		names[i] = feed.Name
+	}
+
+	if len(names) != 3 || names[0] != "Primordial soup" || names[1] != "Ars Technica again" || names[2] != "Chris titus - virtualization" {
+		t.Errorf("incorrect combined feeds, got %v", names)
+	}
+
+	if _, err = myRss.CombinationFeeds("Non-existent"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound got %v", err)
+	}
+
+	if err = myRss.RemoveCombination("Everything"); err != nil || len(myRss.Combinations) != 0 {
+		t.Errorf("failed to remove the combination, %v", err)
+	}
+
+	if err = myRss.RemoveCombination("Everything"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound got %v", err)
+	}
+}
+
+// TestRssCategoryFilters if we get an error the keyword filters of a category aren't merged into its feeds
+func TestRssCategoryFilters(t *testing.T) {
+	myRss := getRss(t)
+	myRss.Categories[1].BlacklistWords = []string{"sponsored"}
+	myRss.Categories[1].WhitelistWords = []string{"linux"}
+	myRss.Categories[1].Subscriptions[1].BlacklistWords = []string{"advertisement"}
+	myRss.Categories[1].Subscriptions[1].WhitelistWords = []string{"kernel"}
+
+	feed, err := myRss.GetFeed("Chris titus - virtualization")
+	if err != nil {
+		t.Fatalf("failed to get feed, %s", err)
+	}
+
+	merged := myRss.WithCategoryFilters(feed)
+	if len(merged.BlacklistWords) != 1 || merged.BlacklistWords[0] != "sponsored" ||
+		len(merged.WhitelistWords) != 1 || merged.WhitelistWords[0] != "linux" {
+		t.Errorf("expected the category filters, got %v and %v", merged.WhitelistWords, merged.BlacklistWords)
+	}
+
+	feed, _ = myRss.GetFeed("Ars Technica")
+	merged = myRss.WithCategoryFilters(feed)
+	if len(merged.BlacklistWords) != 2 || merged.BlacklistWords[1] != "advertisement" ||
+		len(merged.WhitelistWords) != 1 || merged.WhitelistWords[0] != "kernel" {
+		t.Errorf("expected the blacklists to add up and the feed whitelist to win, got %v and %v", merged.WhitelistWords, merged.BlacklistWords)
+	}
+
+	if len(myRss.Categories[1].Subscriptions[1].BlacklistWords) != 1 {
+		t.Error("expected the stored feed to be left unchanged")
+	}
+
+	feed, _ = myRss.GetFeed("Primordial soup")
+	if merged = myRss.WithCategoryFilters(feed); merged != feed {
+		t.Error("expected the feed to be returned as is when its category doesn't filter")
+	}
+}
+
+// TestRssFeedRename if we get an error renaming a feed doesn't keep its url and position
+func TestRssFeedRename(t *testing.T) {
+	myRss := getRss(t)
+	if err := myRss.RenameFeed("Technology", "Chris titus - virtualization", "Virtualization"); err != nil {
+		t.Fatalf("failed to rename feed, %s", err)
+	}
+
+	feeds, err := myRss.GetFeeds("Technology")
+	if err != nil {
+		t.Fatalf("failed to get feeds, %s", err)
+	}
+
+	if feeds[0].Name != "Virtualization" || feeds[0].URL != "https://christitus.com/categories/virtualization/index.xml" {
+		t.Errorf("expected the renamed feed to keep its url and position, got %v", feeds[0])
+	}
+
+	if err = myRss.RenameFeed("Technology", "Virtualization", "Ars Technica"); err != ErrAlreadyExists {
+		t.Errorf("expected ErrAlreadyExists got %s", err)
+	}
+
+	if err = myRss.RenameFeed("Technology", "Virtualization", ""); err != ErrEmptyName {
+		t.Errorf("expected ErrEmptyName got %s", err)
+	}
+
+	if err = myRss.RenameFeed("Technology", "Non-existent", "New name"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound got %s", err)
+	}
+
+	if err = myRss.RenameFeed("Non-existent", "Virtualization", "New name"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound got %s", err)
+	}
+}
+
 // TestOPMLImport if we get an error importing an OPML file doesn't work
 func TestRssOPMLImport(t *testing.T) {
 	myRss := &Rss{}
@@ -397,6 +711,45 @@ func TestRssOPMLImport(t *testing.T) {
 	}
 }
 
+// TestRssOPMLMerge if we get an error then merging an opml file duplicates or moves the subscribed feeds
+func TestRssOPMLMerge(t *testing.T) {
+	myRss := getRss(t)
+	summary, err := myRss.MergeOPML("../../test/data/opml_merge.xml")
+	if err != nil {
+		t.Fatalf("failed to merge OPML, %s", err)
+	}
+
+	if summary != (OPMLSummary{Added: 2, Updated: 1, Skipped: 3}) {
+		t.Errorf("incorrect summary, got %+v", summary)
+	}
+
+	feeds, err := myRss.GetFeeds("Technology")
+	if err != nil {
+		t.Fatalf("failed to get feeds, %s", err)
+	}
+
+	if len(feeds) != 3 || feeds[1].Name != "Ars Technica" || feeds[2].Name != "Hackaday" {
+		t.Errorf("expected Hackaday to be added after the existing feeds, got %v", feeds)
+	}
+
+	feeds, err = myRss.GetFeeds("Reading")
+	if err != nil {
+		t.Fatalf("failed to get the new category, %s", err)
+	}
+
+	if len(feeds) != 1 || feeds[0].Name != "LWN" {
+		t.Errorf("expected only the new feed in the new category, got %v", feeds)
+	}
+
+	if feeds, _ = myRss.GetFeeds("News"); len(feeds) != 1 {
+		t.Errorf("expected the subscribed feed to be kept once, got %v", feeds)
+	}
+
+	if summary, _ = myRss.MergeOPML("../../test/data/opml_merge.xml"); summary.Added != 0 {
+		t.Errorf("expected merging the same file again to add nothing, got %+v", summary)
+	}
+}
+
 // TestOPMLExport if we get an error exporting an OPML file doesn't work
 func TestOPMLExport(t *testing.T) {
 	rss := getRss(t)
@@ -421,3 +774,416 @@ func TestOPMLExport(t *testing.T) {
 		t.Errorf("cannot remove the fake file, %s", err)
 	}
 }
+
+// TestOPMLExportCategory if we get an error exporting a single category doesn't work
+func TestOPMLExportCategory(t *testing.T) {
+	rss := getRss(t)
+	data, err := rss.ExportCategoryOPML("Technology")
+	if err != nil {
+		t.Fatalf("failed to export the category, %s", err)
+	}
+
+	parsed, err := opml.NewOPML([]byte(data))
+	if err != nil {
+		t.Fatalf("failed to parse the exported xml into a struct, %s", err)
+	}
+
+	if len(parsed.Body.Outlines) != 1 || parsed.Body.Outlines[0].Title != "Technology" {
+		t.Fatalf("expected only the Technology category, got %v", parsed.Body.Outlines)
+	}
+
+	if len(parsed.Body.Outlines[0].Outlines) != 2 {
+		t.Errorf("incorrect number of feeds, expected 2, got %d", len(parsed.Body.Outlines[0].Outlines))
+	}
+
+	if _, err = rss.ExportCategoryOPML(AllFeedsName); !errors.Is(err, ErrReservedName) {
+		t.Errorf("expected ErrReservedName got %s", err)
+	}
+
+	if _, err = rss.ExportCategoryOPML("Non-existent"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound got %s", err)
+	}
+}
+
+// TestRssTextStats if we get an error then the words and characters of an article aren't counted correctly
+func TestRssTextStats(t *testing.T) {
+	item := &gofeed.Item{
+		Description: "A short summary",
+		Content:     "<p>Zażółć   gęślą</p>\n<p>jaźń <b>now</b></p>",
+	}
+
+	if words, chars := TextStats(item); words != 4 || chars != 21 {
+		t.Errorf("incorrect stats for the content, expected 4 words and 21 characters, got %d and %d", words, chars)
+	}
+
+	item.Content = ""
+	if words, chars := TextStats(item); words != 3 || chars != 15 {
+		t.Errorf("incorrect stats for the description, expected 3 words and 15 characters, got %d and %d", words, chars)
+	}
+
+	if words, chars := TextStats(&gofeed.Item{}); words != 0 || chars != 0 {
+		t.Errorf("expected no words in an empty item, got %d words and %d characters", words, chars)
+	}
+}
+
+// TestRssItemAuthor if we get an error then the author of an article isn't picked from its authors
+func TestRssItemAuthor(t *testing.T) {
+	item := &gofeed.Item{Authors: []*gofeed.Person{{Email: "jane@example.com"}, {Name: "John"}}}
+	if author := ItemAuthor(item); author != "jane@example.com" {
+		t.Errorf("expected the email of the first author without a name, got %q", author)
+	}
+
+	item = &gofeed.Item{Author: &gofeed.Person{Name: " Jane Doe "}}
+	if author := ItemAuthor(item); author != "Jane Doe" {
+		t.Errorf("expected the author without the authors list, got %q", author)
+	}
+
+	if author := ItemAuthor(&gofeed.Item{Authors: []*gofeed.Person{nil, {}}}); author != "" {
+		t.Errorf("expected no author, got %q", author)
+	}
+
+	if markdown := YassifyItem(item); !strings.Contains(markdown, "Jane Doe") {
+		t.Errorf("expected the author in the article header, got %q", markdown)
+	}
+}
+
+// TestRssLeadImage if we get an error then the lead image isn't picked from the media elements
+func TestRssLeadImage(t *testing.T) {
+	item := &gofeed.Item{
+		Extensions: ext.Extensions{"media": {
+			"content": {
+				{Name: "content", Attrs: map[string]string{"url": "https://example.com/video.mp4", "medium": "video"}},
+				{Name: "content", Attrs: map[string]string{"url": "https://example.com/image.jpg", "type": "image/jpeg"}},
+			},
+		}},
+	}
+
+	if image := LeadImage(item); image != "https://example.com/image.jpg" {
+		t.Errorf("incorrect lead image, expected the first image-type content, got %q", image)
+	}
+
+	item.Extensions["media"]["group"] = []ext.Extension{{Name: "group", Children: map[string][]ext.Extension{
+		"thumbnail": {{Name: "thumbnail", Attrs: map[string]string{"url": "https://example.com/thumb.jpg"}}},
+	}}}
+	delete(item.Extensions["media"], "content")
+	if image := LeadImage(item); image != "https://example.com/thumb.jpg" {
+		t.Errorf("incorrect lead image, expected the grouped thumbnail, got %q", image)
+	}
+
+	item = &gofeed.Item{Enclosures: []*gofeed.Enclosure{{URL: "https://example.com/image.png", Type: "image/png"}}}
+	if image := LeadImage(item); image != "https://example.com/image.png" {
+		t.Errorf("incorrect lead image, expected the image enclosure, got %q", image)
+	}
+
+	item = &gofeed.Item{
+		Link: "https://example.com/posts/1",
+		Content: `<p><img src="https://pixel.wp.com/g.gif" alt="">` +
+			`<img src="/counter.gif" width="1" height="1">` +
+			`<img src="../images/photo.jpg"></p>`,
+	}
+	if image := LeadImage(item); image != "https://example.com/images/photo.jpg" {
+		t.Errorf("incorrect lead image, expected the first embedded image which isn't a tracker, got %q", image)
+	}
+
+	item = &gofeed.Item{Description: `<img src="https://feeds.feedburner.com/~r/example/~4/abc" height="1px">`}
+	if image := LeadImage(item); image != "" {
+		t.Errorf("expected the tracking pixels to be skipped, got %q", image)
+	}
+
+	if image := LeadImage(&gofeed.Item{}); image != "" {
+		t.Errorf("expected no lead image, got %q", image)
+	}
+}
+
+// TestRssBoilerplate if we get an error the leading boilerplate isn't stripped or the article is
+func TestRssBoilerplate(t *testing.T) {
+	data, err := os.ReadFile("../../test/data/boilerplate.html")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture: %v", err)
+	}
+
+	boilerplate, err := NewBoilerplate([]string{"img", ".promo"}, []string{`(?i)^read (this article )?on our site`})
+	if err != nil {
+		t.Fatalf("couldn't compile the boilerplate: %v", err)
+	}
+
+	stripped := boilerplate.Strip(string(data))
+	if !strings.HasPrefix(stripped, "<p>The first real paragraph") {
+		t.Errorf("expected the leading blocks to be stripped, got %q", stripped)
+	}
+
+	for _, kept := range []string{"inline.png", "Read on our site if you liked this one.", "figure.png"} {
+		if !strings.Contains(stripped, kept) {
+			t.Errorf("expected %q after the article started to be kept, got %q", kept, stripped)
+		}
+	}
+
+	// Text before the first block is the start of the article
+	unwrapped := "The article starts here. <p>Read on our site</p>"
+	if result := boilerplate.Strip(unwrapped); result != unwrapped {
+		t.Errorf("expected the content without leading blocks to be kept, got %q", result)
+	}
+
+	if result := (*Boilerplate)(nil).Strip(string(data)); result != string(data) {
+		t.Errorf("expected an empty boilerplate to keep the content, got %q", result)
+	}
+
+	if _, err = NewBoilerplate([]string{"p["}, nil); err == nil {
+		t.Error("expected an error for an invalid selector")
+	}
+
+	if _, err = NewBoilerplate(nil, []string{"(unclosed"}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+// TestRssMarkdownDefinitionList if we get an error then the definition lists aren't rendered as bold terms
+// with indented definitions
+func TestRssMarkdownDefinitionList(t *testing.T) {
+	data, err := os.ReadFile("../../test/data/definition_list.html")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture: %v", err)
+	}
+
+	markdown, err := HTMLToMarkdown(string(data))
+	if err != nil {
+		t.Fatalf("couldn't convert the html: %v", err)
+	}
+
+	expected := []string{
+		"**Feed**\n\n" + definitionIndent + "A list of articles published by a website.",
+		"**OPML**\n\n**Outline Processor Markup Language**\n\n" + definitionIndent + "A format for exchanging subscriptions.",
+		definitionIndent + "It is supported by most readers.\n\n" + definitionIndent + "Even the _old_ ones.",
+	}
+
+	for _, part := range expected {
+		if !strings.Contains(markdown, part) {
+			t.Errorf("expected the markdown to contain %q, got %q", part, markdown)
+		}
+	}
+}
+
+// TestRssMarkdownFootnotes if we get an error then the footnotes aren't collected into a numbered list at the
+// end of the article in the order they are referenced in
+func TestRssMarkdownFootnotes(t *testing.T) {
+	data, err := os.ReadFile("../../test/data/footnotes.html")
+	if err != nil {
+		t.Fatalf("couldn't read the fixture: %v", err)
+	}
+
+	markdown, err := HTMLToMarkdown(string(data))
+	if err != nil {
+		t.Fatalf("couldn't convert the html: %v", err)
+	}
+
+	body, notes, found := strings.Cut(markdown, "## Footnotes")
+	if !found {
+		t.Fatalf("expected a footnotes section, got %q", markdown)
+	}
+
+	if !strings.Contains(body, "Claim number 12[12].") || !strings.Contains(body, "The first claim again[1]") {
+		t.Errorf("expected the references to be replaced with numbers, got %q", body)
+	}
+
+	if !strings.Contains(body, "[normal anchor](#top)") {
+		t.Errorf("expected the links which aren't footnotes to be kept, got %q", body)
+	}
+
+	if strings.Contains(body, "Source for claim") || strings.Contains(notes, "↩") {
+		t.Errorf("expected the notes to be moved without the back references, got %q", markdown)
+	}
+
+	lines := strings.Split(strings.TrimSpace(notes), "\n")
+	if len(lines) != 12 {
+		t.Fatalf("expected 12 footnotes, got %d", len(lines))
+	}
+
+	for i, line := range lines {
+		expected := strconv.Itoa(i+1) + ". Source for claim " + strconv.Itoa(i+1) + "."
+		if line != expected {
+			t.Errorf("incorrect footnote, expected %q, got %q", expected, line)
+		}
+	}
+
+	if markdown, _ = HTMLToMarkdown("<p>No notes <a href=\"#top\">here</a></p>"); strings.Contains(markdown, "Footnotes") {
+		t.Errorf("expected no footnotes section, got %q", markdown)
+	}
+}
+
+// TestRssReload if we get an error then the changes made to the file aren't picked up or the unsaved
+// changes aren't noticed
+func TestRssReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "urls.yml")
+	myRss, err := New(path)
+	if err != nil {
+		t.Fatalf("error creating rss object: %v", err)
+	}
+
+	if err = myRss.Save(); err != nil {
+		t.Fatalf("error saving the file: %v", err)
+	}
+
+	if myRss.Modified() {
+		t.Error("expected the saved structure not to be modified")
+	}
+
+	if err = myRss.AddCategory("Local", "Only in memory"); err != nil {
+		t.Fatalf("error adding the category: %v", err)
+	}
+
+	if !myRss.Modified() {
+		t.Error("expected the new category to count as an unsaved change")
+	}
+
+	external := "categories:\n  - name: Edited\n    desc: Edited by hand\n    subscriptions: []\n"
+	if err = os.WriteFile(path, []byte(external), 0600); err != nil {
+		t.Fatalf("error editing the file: %v", err)
+	}
+
+	if err = myRss.Reload(); err != nil {
+		t.Fatalf("error reloading the file: %v", err)
+	}
+
+	if len(myRss.Categories) != 1 || myRss.Categories[0].Name != "Edited" || myRss.Modified() {
+		t.Errorf("expected only the edited category, got %v", myRss.Categories)
+	}
+
+	if err = os.WriteFile(path, []byte("categories: ["), 0600); err != nil {
+		t.Fatalf("error breaking the file: %v", err)
+	}
+
+	if err = myRss.Reload(); err == nil || myRss.Categories[0].Name != "Edited" {
+		t.Error("expected a broken file to leave the categories as they were")
+	}
+}
+
+// TestRssSnapshot if we get an error then the structure counts as saved before its snapshot was written
+func TestRssSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	myRss, err := New(filepath.Join(dir, "urls.yml"))
+	if err != nil {
+		t.Fatalf("error creating rss object: %v", err)
+	}
+
+	if err = myRss.AddCategory("Local", "Only in memory"); err != nil {
+		t.Fatalf("error adding the category: %v", err)
+	}
+
+	// The file can't be written over a directory
+	if err = os.MkdirAll(filepath.Join(dir, "urls.yml"), 0755); err != nil {
+		t.Fatalf("error blocking the file: %v", err)
+	}
+
+	write, markSaved, err := myRss.Snapshot()
+	if err != nil {
+		t.Fatalf("error encoding the structure: %v", err)
+	}
+
+	if !myRss.Modified() {
+		t.Error("expected the structure to stay modified until the snapshot is written")
+	}
+
+	if err = write(); err == nil {
+		t.Fatal("expected the write to fail")
+	}
+
+	if !myRss.Modified() {
+		t.Error("expected the structure to stay modified after a failed write")
+	}
+
+	if err = os.Remove(filepath.Join(dir, "urls.yml")); err != nil {
+		t.Fatalf("error unblocking the file: %v", err)
+	}
+
+	if err = write(); err != nil {
+		t.Fatalf("error writing the snapshot: %v", err)
+	}
+
+	markSaved()
+	if myRss.Modified() {
+		t.Error("expected the written snapshot to count as saved")
+	}
+}
+
+// TestRssResolveLinks if we get an error then the relative links of an article aren't made absolute or the
+// other links are changed
+func TestRssResolveLinks(t *testing.T) {
+	content := `<p>See <a href="/posts/2">the next post</a>, <a href="#notes">the notes</a> and ` +
+		`<a href="https://example.org/x">elsewhere</a>.</p><img src="//cdn.example.com/a.png"/><img src="b.png"/>`
+
+	resolved := ResolveLinks(content, "https://example.com/posts/1")
+	for _, want := range []string{
+		`href="https://example.com/posts/2"`, `href="#notes"`, `href="https://example.org/x"`,
+		`src="https://cdn.example.com/a.png"`, `src="https://example.com/posts/b.png"`,
+	} {
+		if !strings.Contains(resolved, want) {
+			t.Errorf("expected %s in the resolved content, got %q", want, resolved)
+		}
+	}
+
+	if plain := "<p>Nothing to resolve</p>"; ResolveLinks(plain, "https://example.com") != plain {
+		t.Error("expected the content without relative links to be left as it is")
+	}
+
+	if base := LinkBase("https://example.com/feed.xml", "/blog/", "posts/1"); base != "https://example.com/blog/posts/1" {
+		t.Errorf("expected the item link resolved against the homepage, got %s", base)
+	}
+}
+
+// TestRssUndo if we get an error a removed category can't be restored, the checkpoint is used more than
+// once or it still applies after the feeds were changed again
+func TestRssUndo(t *testing.T) {
+	myRss, err := New(filepath.Join(t.TempDir(), "urls.yml"))
+	if err != nil {
+		t.Fatalf("error creating rss object: %v", err)
+	}
+
+	if _, err = myRss.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("expected nothing to undo, got %v", err)
+	}
+
+	name := myRss.Categories[0].Name
+	count := len(myRss.Categories)
+	remove := func() error { return myRss.RemoveCategory(name) }
+	if err = myRss.Checkpoint("Delete category "+name, remove); err != nil {
+		t.Fatalf("error removing the category: %v", err)
+	}
+
+	if operation, _, ok := myRss.LastCheckpoint(); !ok || operation != "Delete category "+name {
+		t.Errorf("expected the removal to be undoable, got %q", operation)
+	}
+
+	operation, err := myRss.Undo()
+	if err != nil || operation != "Delete category "+name {
+		t.Fatalf("expected the removal to be undone, got %q and %v", operation, err)
+	}
+
+	if len(myRss.Categories) != count || myRss.Categories[0].Name != name {
+		t.Errorf("expected the category to be restored, got %v", myRss.Categories)
+	}
+
+	if _, err = myRss.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("expected the checkpoint to be used up, got %v", err)
+	}
+
+	// A failed operation doesn't leave a checkpoint behind
+	if err = myRss.Checkpoint("Delete category Missing", func() error { return myRss.RemoveCategory("Missing") }); err == nil {
+		t.Error("expected the missing category not to be removed")
+	}
+
+	if _, _, ok := myRss.LastCheckpoint(); ok {
+		t.Error("expected no checkpoint of the failed operation")
+	}
+
+	if err = myRss.Checkpoint("Delete category "+name, remove); err != nil {
+		t.Fatalf("error removing the category: %v", err)
+	}
+
+	if err = myRss.AddCategory("Later", "Added after the removal"); err != nil {
+		t.Fatalf("error adding the category: %v", err)
+	}
+
+	if _, err = myRss.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("expected the later change to invalidate the checkpoint, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package rss
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// checkpoint is the state of the feeds before and after a destructive operation
+type checkpoint struct {
+	operation string
+	time      time.Time
+	before    []byte
+	after     []byte
+}
+
+// feedState is the part of the structure which is brought back by Undo
+type feedState struct {
+	Categories   []Category    `yaml:"categories"`
+	Combinations []Combination `yaml:"combinations,omitempty"`
+}
+
+// state encodes the categories and the combinations, which also copies them
+func (rss Rss) state() ([]byte, error) {
+	return yaml.Marshal(feedState{rss.Categories, rss.Combinations})
+}
+
+// Checkpoint runs a destructive operation like removing a category and remembers the feeds from before
+// it, so that the operation can be undone with Undo. Only the last operation is remembered and only for
+// this session, the checkpoint no longer applies once the feeds are changed in any other way.
+func (rss *Rss) Checkpoint(operation string, apply func() error) error {
+	before, err := rss.state()
+	if err != nil {
+		return fmt.Errorf("rss.Checkpoint: %w", err)
+	}
+
+	if err = apply(); err != nil {
+		return err
+	}
+
+	after, err := rss.state()
+	if err != nil {
+		return fmt.Errorf("rss.Checkpoint: %w", err)
+	}
+
+	rss.undo = &checkpoint{operation, time.Now(), before, after}
+	return nil
+}
+
+// LastCheckpoint returns the operation which Undo would undo and when it happened, ok is false if there
+// is nothing to undo
+func (rss *Rss) LastCheckpoint() (operation string, at time.Time, ok bool) {
+	if rss.undo == nil {
+		return "", time.Time{}, false
+	}
+
+	// The feeds were changed after the operation, undoing it would throw the changes away
+	if current, err := rss.state(); err != nil || string(current) != string(rss.undo.after) {
+		return "", time.Time{}, false
+	}
+
+	return rss.undo.operation, rss.undo.time, true
+}
+
+// Undo brings back the feeds from before the last checkpointed operation and forgets the checkpoint,
+// it returns the operation which was undone. The restored feeds are saved with the next save.
+func (rss *Rss) Undo() (string, error) {
+	operation, _, ok := rss.LastCheckpoint()
+	if !ok {
+		return "", ErrNothingToUndo
+	}
+
+	var restored feedState
+	if err := yaml.Unmarshal(rss.undo.before, &restored); err != nil {
+		return "", fmt.Errorf("rss.Undo: %w", err)
+	}
+
+	rss.Categories = restored.Categories
+	rss.Combinations = restored.Combinations
+	rss.undo = nil
+	return operation, nil
+}
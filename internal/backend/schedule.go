@@ -0,0 +1,91 @@
+package backend
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ActiveHours is a daily window of the local time like 07:00-23:00, a window which ends before it starts
+// goes past midnight. The zero value is the whole day.
+type ActiveHours struct {
+	start time.Duration
+	end   time.Duration
+	set   bool
+}
+
+// ParseActiveHours parses a window written as HH:MM-HH:MM, an empty string is the whole day
+func ParseActiveHours(value string) (ActiveHours, error) {
+	if strings.TrimSpace(value) == "" {
+		return ActiveHours{}, nil
+	}
+
+	from, to, ok := strings.Cut(value, "-")
+	if !ok {
+		return ActiveHours{}, fmt.Errorf("backend.ParseActiveHours: expected HH:MM-HH:MM, got %q", value)
+	}
+
+	start, err := parseClock(from)
+	if err != nil {
+		return ActiveHours{}, fmt.Errorf("backend.ParseActiveHours: %w", err)
+	}
+
+	end, err := parseClock(to)
+	if err != nil {
+		return ActiveHours{}, fmt.Errorf("backend.ParseActiveHours: %w", err)
+	}
+
+	if start == end {
+		return ActiveHours{}, fmt.Errorf("backend.ParseActiveHours: the window %q is empty", value)
+	}
+
+	return ActiveHours{start: start, end: end, set: true}, nil
+}
+
+// parseClock parses a time of the day written as HH:MM, 24:00 is the end of the day
+func parseClock(value string) (time.Duration, error) {
+	var hours, minutes int
+	value = strings.TrimSpace(value)
+	if n, err := fmt.Sscanf(value, "%d:%d", &hours, &minutes); err != nil || n != 2 || len(value) > 5 {
+		return 0, fmt.Errorf("invalid time of the day %q", value)
+	}
+
+	clock := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	if hours < 0 || minutes < 0 || minutes > 59 || clock > 24*time.Hour {
+		return 0, fmt.Errorf("invalid time of the day %q", value)
+	}
+
+	return clock, nil
+}
+
+// Contains checks if the window includes the time, the wall clock of the time's location is used so
+// that the window follows the daylight saving changes
+func (h ActiveHours) Contains(t time.Time) bool {
+	if !h.set {
+		return true
+	}
+
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	if h.start < h.end {
+		return clock >= h.start && clock < h.end
+	}
+
+	return clock >= h.start || clock < h.end
+}
+
+// Next returns when the window opens next, the time itself is returned if the window is open
+func (h ActiveHours) Next(t time.Time) time.Time {
+	if h.Contains(t) {
+		return t
+	}
+
+	// The date is normalized by time.Date, which keeps the wall clock on the days the clocks change
+	minutes := int(h.start / time.Minute)
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, minutes, 0, 0, t.Location())
+	if !start.After(t) {
+		start = time.Date(t.Year(), t.Month(), t.Day()+1, 0, minutes, 0, 0, t.Location())
+	}
+
+	return start
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -10,6 +11,11 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/backend/logging"
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/TypicalAM/goread/internal/ui/browser"
 	"github.com/TypicalAM/goread/internal/ui/simplelist"
 	"github.com/TypicalAM/goread/internal/ui/tab/category"
@@ -19,13 +25,24 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-var Default = Config{}
+var Default = Config{
+	Backend: backend.DefaultOptions,
+	Browser: browser.DefaultOptions,
+	Feed:    feed.DefaultOptions,
+	Fetch:   cache.DefaultOptions,
+	Log:     logging.DefaultOptions,
+}
 
 var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
 var matchAllCap = regexp.MustCompile("([a-z0-9])([A-Z])")
 
 type Config struct {
-	Keymap map[string]KeymapConfig `yaml:"keymap"`
+	Keymap  map[string]KeymapConfig `yaml:"keymap"`
+	Backend backend.Options         `yaml:"backend"`
+	Browser browser.Options         `yaml:"browser"`
+	Feed    feed.Options            `yaml:"feed"`
+	Fetch   cache.Options           `yaml:"fetch"`
+	Log     logging.Options         `yaml:"log"`
 
 	filePath string
 }
@@ -69,6 +86,132 @@ func (cfg *Config) Load() error {
 	}
 
 	// Apply the config
+	if !slices.Contains(backend.SortOrders, cfg.Backend.SortOrder) {
+		return fmt.Errorf("cfg.Load: unrecognized sort order: %s", cfg.Backend.SortOrder)
+	}
+
+	if !slices.Contains(backend.TodayWindows, cfg.Backend.TodayWindow) {
+		return fmt.Errorf("cfg.Load: unrecognized today window: %s", cfg.Backend.TodayWindow)
+	}
+
+	if cfg.Backend.RefreshCooldown < 0 {
+		return fmt.Errorf("cfg.Load: the refresh cooldown can't be negative: %s", cfg.Backend.RefreshCooldown)
+	}
+
+	if cfg.Backend.DownloadedMaxCount < 0 || cfg.Backend.DownloadedMaxAge < 0 {
+		return fmt.Errorf("cfg.Load: the downloaded articles retention can't be negative")
+	}
+
+	if cfg.Backend.RetryRounds < 0 || cfg.Backend.RetryDelay < 0 {
+		return fmt.Errorf("cfg.Load: the retries of the failed feeds can't be negative")
+	}
+
+	if cfg.Backend.RefreshInterval < 0 {
+		return fmt.Errorf("cfg.Load: the refresh interval can't be negative: %s", cfg.Backend.RefreshInterval)
+	}
+
+	if _, err = backend.ParseActiveHours(cfg.Backend.ActiveHours); err != nil {
+		return fmt.Errorf("cfg.Load: %w", err)
+	}
+
+	if cfg.Fetch.Timeout <= 0 {
+		return fmt.Errorf("cfg.Load: the fetch timeout has to be positive: %s", cfg.Fetch.Timeout)
+	}
+
+	if cfg.Fetch.Concurrency < 1 {
+		return fmt.Errorf("cfg.Load: the fetch concurrency has to be at least 1: %d", cfg.Fetch.Concurrency)
+	}
+
+	if cfg.Fetch.MinFreshness <= 0 || cfg.Fetch.MaxFreshness < cfg.Fetch.MinFreshness {
+		return fmt.Errorf("cfg.Load: the fetch freshness limits have to be positive and the minimum can't exceed the maximum: %s, %s", cfg.Fetch.MinFreshness, cfg.Fetch.MaxFreshness)
+	}
+
+	if cfg.Fetch.ArchivePages < 0 || cfg.Fetch.MaxItems < 0 {
+		return fmt.Errorf("cfg.Load: the archive page limit and the item limit can't be negative: %d, %d", cfg.Fetch.ArchivePages, cfg.Fetch.MaxItems)
+	}
+
+	if cfg.Fetch.MaxResponseSize < 0 {
+		return fmt.Errorf("cfg.Load: the maximum response size can't be negative: %d", cfg.Fetch.MaxResponseSize)
+	}
+
+	if _, err = rss.NewBoilerplate(cfg.Fetch.StripSelectors, cfg.Fetch.StripPatterns); err != nil {
+		return fmt.Errorf("cfg.Load: %w", err)
+	}
+
+	if cfg.Fetch.Proxy != "" {
+		if _, err = url.Parse(cfg.Fetch.Proxy); err != nil {
+			return fmt.Errorf("cfg.Load: invalid proxy url: %w", err)
+		}
+	}
+
+	if cfg.Browser.UnreadThreshold < 0 {
+		return fmt.Errorf("cfg.Load: the unread threshold can't be negative: %d", cfg.Browser.UnreadThreshold)
+	}
+
+	if cfg.Browser.TabTitleWidth < 1 {
+		return fmt.Errorf("cfg.Load: the tab title width has to be at least 1: %d", cfg.Browser.TabTitleWidth)
+	}
+
+	if !slices.Contains(browser.LastTabActions, cfg.Browser.LastTabAction) {
+		return fmt.Errorf("cfg.Load: unrecognized last tab action: %s", cfg.Browser.LastTabAction)
+	}
+
+	if cfg.Browser.MaxTabs < 0 {
+		return fmt.Errorf("cfg.Load: the maximum number of tabs can't be negative: %d", cfg.Browser.MaxTabs)
+	}
+
+	if cfg.Browser.MinWidth < 0 || cfg.Browser.MinHeight < 0 {
+		return fmt.Errorf("cfg.Load: the minimum terminal size can't be negative: %dx%d", cfg.Browser.MinWidth, cfg.Browser.MinHeight)
+	}
+
+	if cfg.Browser.HistoryLength < 0 {
+		return fmt.Errorf("cfg.Load: the history length can't be negative: %d", cfg.Browser.HistoryLength)
+	}
+
+	if cfg.Browser.SaveDelay < 0 {
+		return fmt.Errorf("cfg.Load: the save delay can't be negative: %s", cfg.Browser.SaveDelay)
+	}
+
+	if !slices.Contains(browser.TabOverflows, cfg.Browser.TabOverflow) {
+		return fmt.Errorf("cfg.Load: unrecognized tab overflow: %s", cfg.Browser.TabOverflow)
+	}
+
+	if strings.ContainsAny(cfg.Browser.Ellipsis, "\r\n") {
+		return fmt.Errorf("cfg.Load: the ellipsis has to fit on a single line: %q", cfg.Browser.Ellipsis)
+	}
+
+	if cfg.Feed.InlineLines < 0 {
+		return fmt.Errorf("cfg.Load: the number of inline article lines can't be negative: %d", cfg.Feed.InlineLines)
+	}
+
+	if cfg.Feed.ArticleMargin < 0 || cfg.Feed.ArticleMaxWidth < 0 {
+		return fmt.Errorf("cfg.Load: the article margin and maximum width can't be negative: %d, %d",
+			cfg.Feed.ArticleMargin, cfg.Feed.ArticleMaxWidth)
+	}
+
+	if !slices.Contains(rss.RenderModes, cfg.Feed.RenderMode) {
+		return fmt.Errorf("cfg.Load: unrecognized render mode: %s", cfg.Feed.RenderMode)
+	}
+
+	if err = feed.ValidateOpenCommand(cfg.Feed.OpenCommand); err != nil {
+		return fmt.Errorf("cfg.Load: %w", err)
+	}
+
+	if !slices.Contains(logging.Levels, cfg.Log.Level) {
+		return fmt.Errorf("cfg.Load: unrecognized log level: %s", cfg.Log.Level)
+	}
+
+	if cfg.Log.MaxSize < 0 {
+		return fmt.Errorf("cfg.Load: the maximum log size can't be negative: %d", cfg.Log.MaxSize)
+	}
+
+	backend.DefaultOptions = cfg.Backend
+	browser.DefaultOptions = cfg.Browser
+	feed.DefaultOptions = cfg.Feed
+	cache.DefaultOptions = cfg.Fetch
+	logging.DefaultOptions = cfg.Log
+	theme.Ellipsis = cfg.Browser.Ellipsis
+
 	allowedKeymaps := []string{"browser", "overview", "category", "feed", "list"}
 	for keyCategory, keymap := range cfg.Keymap {
 		if !slices.Contains(allowedKeymaps, keyCategory) {
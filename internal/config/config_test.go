@@ -3,7 +3,10 @@ package config
 import (
 	"slices"
 	"testing"
+	"time"
 
+	"github.com/TypicalAM/goread/internal/backend/cache"
+	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/TypicalAM/goread/internal/ui/browser"
 )
 
@@ -47,6 +50,30 @@ func TestConfigLoadFile(t *testing.T) {
 	if !slices.Contains(keys, "EXTRA") {
 		t.Errorf("incorrect keys loaded, expected 'EXTRA' in %v", keys)
 	}
+
+	if !browser.DefaultOptions.ShowCounts {
+		t.Error("incorrect options loaded, expected show_counts to be enabled")
+	}
+
+	if browser.DefaultOptions.MessageTimeout != 2*time.Second {
+		t.Errorf("incorrect options loaded, expected a message timeout of 2s, got %v", browser.DefaultOptions.MessageTimeout)
+	}
+
+	if browser.DefaultOptions.DefaultCategory != "Tech" {
+		t.Errorf("incorrect options loaded, expected the Tech default category, got %q", browser.DefaultOptions.DefaultCategory)
+	}
+
+	if !browser.DefaultOptions.EscClosesTab || browser.DefaultOptions.EscQuits {
+		t.Errorf("incorrect options loaded, expected esc to close tabs but not quit, got %+v", browser.DefaultOptions)
+	}
+
+	if theme.Ellipsis != "..." {
+		t.Errorf("incorrect ellipsis loaded, expected ..., got %q", theme.Ellipsis)
+	}
+
+	if cache.DefaultOptions.Timeout != 10*time.Second || cache.DefaultOptions.Concurrency != 4 {
+		t.Errorf("incorrect fetch options loaded, got %+v", cache.DefaultOptions)
+	}
 }
 
 // TestConfigLoadFile if we get an error then the config loader doesn't recognize non-existant categories
@@ -84,3 +111,15 @@ func TestConfigLoadNoKeys(t *testing.T) {
 		t.Error("expected error when loading file with bindings missing keys, but got none")
 	}
 }
+
+// TestConfigLoadBadOpenCommand if we get an error then the config loader accepts an open command without the url
+func TestConfigLoadBadOpenCommand(t *testing.T) {
+	myCfg, err := New("../test/data/goread_bad_open_command.yml")
+	if err != nil {
+		t.Fatalf("error creating config object: %v", err)
+	}
+
+	if err = myCfg.Load(); err == nil {
+		t.Fatalf("expected error when loading file with an open command without {url}, but got none")
+	}
+}
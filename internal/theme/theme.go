@@ -26,6 +26,8 @@ var Default = Colors{
 	Color5:        "#98c379",
 	Color6:        "#fab387",
 	Color7:        "#f1c1e4",
+	UnreadNone:    "#676985",
+	UnreadMany:    "#e06c75",
 	MarkdownStyle: glamour.DraculaStyleConfig,
 }
 
@@ -44,6 +46,8 @@ type Colors struct {
 	Color6        lipgloss.Color   `json:"color6"`
 	Color7        lipgloss.Color   `json:"color7"`
 	BgDark        lipgloss.Color   `json:"bg_dark"`
+	UnreadNone    lipgloss.Color   `json:"unread_none"`
+	UnreadMany    lipgloss.Color   `json:"unread_many"`
 }
 
 // New will create a new colorscheme and try to load it
@@ -132,6 +136,8 @@ func (c *Colors) Convert(pywalFilePath string) error {
 	c.Color5 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color5"].(string))
 	c.Color6 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color6"].(string))
 	c.Color7 = lipgloss.Color(walColorscheme["colors"].(map[string]interface{})["color7"].(string))
+	c.UnreadNone = c.TextDark
+	c.UnreadMany = c.Color1
 
 	return nil
 }
@@ -140,7 +146,10 @@ func (c *Colors) Convert(pywalFilePath string) error {
 func (c Colors) PrettyPrint() string {
 	result := []string{"A table of all the colors:"}
 
-	for _, color := range []lipgloss.Color{c.BgDark, c.BgDarker, c.Text, c.TextDark, c.Color1, c.Color2, c.Color3, c.Color4, c.Color5, c.Color6, c.Color7} {
+	for _, color := range []lipgloss.Color{
+		c.BgDark, c.BgDarker, c.Text, c.TextDark, c.Color1, c.Color2, c.Color3, c.Color4, c.Color5, c.Color6, c.Color7,
+		c.UnreadNone, c.UnreadMany,
+	} {
 		foreground := lipgloss.NewStyle().Foreground(color)
 		background := lipgloss.NewStyle().Background(color)
 		result = append(result, fmt.Sprintf(
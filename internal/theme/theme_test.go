@@ -1,7 +1,10 @@
 package theme
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // TestThemeLoadNoFile if we get an error then the default theme is not generated
@@ -35,3 +38,77 @@ func TestPywalConvert(t *testing.T) {
 		t.Errorf("Theme not converted correctly")
 	}
 }
+
+// TestThemeTruncate if we get an error then the text isn't truncated by its display width
+func TestThemeTruncate(t *testing.T) {
+	if text := Truncate("Short", 12); text != "Short" {
+		t.Errorf("short text was changed, got %q", text)
+	}
+
+	if text := Truncate("Exactly12chr", 12); text != "Exactly12chr" {
+		t.Errorf("text which fits exactly was changed, got %q", text)
+	}
+
+	if text := Truncate("A rather long feed title", 12); text != "A rather lo…" {
+		t.Errorf("long text was truncated incorrectly, got %q", text)
+	}
+
+	// Every character here takes up two cells, so only five of them fit alongside the ellipsis
+	wide := "日本語のニュースフィード"
+	text := Truncate(wide, 12)
+	if width := lipgloss.Width(text); width > 12 {
+		t.Errorf("wide text is %d cells wide, expected at most 12: %q", width, text)
+	}
+
+	if text != "日本語のニ…" {
+		t.Errorf("wide text was truncated incorrectly, got %q", text)
+	}
+
+	if text := Truncate("🚀🚀🚀🚀🚀🚀🚀🚀", 6); lipgloss.Width(text) > 6 {
+		t.Errorf("emoji text is too wide, got %q", text)
+	}
+
+	defer func(ellipsis string) { Ellipsis = ellipsis }(Ellipsis)
+	Ellipsis = "..."
+	if text := Truncate("A rather long feed title", 12); text != "A rather ..." {
+		t.Errorf("text was truncated with the wrong ellipsis, got %q", text)
+	}
+
+	if text := Truncate("A rather long feed title", 2); text != "A " {
+		t.Errorf("expected the text to be cut without the ellipsis which doesn't fit, got %q", text)
+	}
+}
+
+// TestThemeWrap if we get an error then the long links are split across lines
+func TestThemeWrap(t *testing.T) {
+	link := "https://example.com/2023/05/a-very-long-article-slug-which-goes-on-and-on"
+	text := "Read the whole story at " + link + " before it is gone"
+
+	for _, line := range strings.Split(Wrap(text, 80, true), "\n") {
+		if lipgloss.Width(line) > 80 {
+			t.Errorf("line is wider than 80 cells: %q", line)
+		}
+	}
+
+	if lines := strings.Split(Wrap(text, 80, true), "\n"); len(lines) != 3 || !strings.HasPrefix(lines[1], link+" ") {
+		t.Errorf("expected the whole link to be pushed to the next line, got %q", lines)
+	}
+
+	if wrapped := Wrap(text, 80, false); strings.Contains(wrapped, link) {
+		t.Errorf("expected the link to be split without preserving the links, got %q", wrapped)
+	}
+
+	if wrapped := Wrap("A well-known fact", 9, true); wrapped != "A well-\nknown\nfact" {
+		t.Errorf("expected the words to be split at the hyphen, got %q", wrapped)
+	}
+
+	// A link wider than a line can't be kept whole, it starts on a new line and fills the lines instead
+	wrapped := Wrap("See "+link, 30, true)
+	if !strings.HasPrefix(wrapped, "See\nhttps://") || strings.ReplaceAll(wrapped, "\n", "") != "See"+link {
+		t.Errorf("expected the link to start on a new line and be split at the width, got %q", wrapped)
+	}
+
+	if wrapped := Wrap("  indented text", 40, true); wrapped != "  indented text" {
+		t.Errorf("expected the indentation to be kept, got %q", wrapped)
+	}
+}
@@ -0,0 +1,25 @@
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+)
+
+// Ellipsis is appended to the text which is shortened by Truncate, "..." can be used on terminals
+// which render "…" poorly
+var Ellipsis = "…"
+
+// Truncate shortens the text so that its display width doesn't exceed width, wide characters (like CJK
+// or emoji) take up two cells so the byte or rune count can't be used here
+func Truncate(s string, width int) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+
+	// The ellipsis doesn't fit, cut the text without it
+	if lipgloss.Width(Ellipsis) >= width {
+		return truncate.String(s, uint(width))
+	}
+
+	return truncate.StringWithTail(s, uint(width), Ellipsis)
+}
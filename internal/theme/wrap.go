@@ -0,0 +1,95 @@
+package theme
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
+)
+
+// Wrap wraps the text so that no line is wider than width, the words are moved to the next line and the
+// ones which don't fit on a line of their own are broken up. If preserveURLs is set the links are only
+// ever broken up if they are wider than a line, otherwise they can be split at a hyphen like any word.
+func Wrap(s string, width int, preserveURLs bool) string {
+	if width <= 0 {
+		return s
+	}
+
+	if !preserveURLs {
+		return wrap.String(wordwrap.String(s, width), width)
+	}
+
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] = wrapLine(lines[i], width)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine wraps a single line, the hyphenated words other than links can be split at a hyphen
+func wrapLine(line string, width int) string {
+	var b strings.Builder
+	lineWidth := 0
+	wrapped := false
+	for i, word := range strings.Split(line, " ") {
+		wordWidth := lipgloss.Width(word)
+		switch {
+		case i == 0:
+
+		case wrapped && lineWidth == 0:
+			// The spaces at the start of a wrapped line would look like indentation
+			if word == "" {
+				continue
+			}
+
+		case lineWidth+1+wordWidth <= width:
+			b.WriteByte(' ')
+			lineWidth++
+
+		default:
+			if head, tail, ok := splitHyphen(word, width-lineWidth-1); ok {
+				b.WriteString(" " + head)
+				word, wordWidth = tail, lipgloss.Width(tail)
+			}
+
+			b.WriteByte('\n')
+			lineWidth = 0
+			wrapped = true
+			if word == "" {
+				continue
+			}
+		}
+
+		// Not even a line of its own fits the word, there is no way around breaking it up
+		if wordWidth > width-lineWidth {
+			chunks := strings.Split(wrap.String(word, width), "\n")
+			b.WriteString(strings.Join(chunks, "\n"))
+			lineWidth = lipgloss.Width(chunks[len(chunks)-1])
+			wrapped = wrapped || len(chunks) > 1
+			continue
+		}
+
+		b.WriteString(word)
+		lineWidth += wordWidth
+	}
+
+	return b.String()
+}
+
+// splitHyphen splits a word after the last hyphen which leaves a head no wider than room, links are
+// never split
+func splitHyphen(word string, room int) (head, tail string, ok bool) {
+	if room <= 0 || strings.Contains(word, "://") {
+		return "", word, false
+	}
+
+	for i := strings.LastIndexByte(word, '-'); i > 0; i = strings.LastIndexByte(word[:i], '-') {
+		if i+1 < len(word) && lipgloss.Width(word[:i+1]) <= room {
+			return word[:i+1], word[i+1:], true
+		}
+	}
+
+	return "", word, false
+}
@@ -0,0 +1,53 @@
+package browser
+
+import (
+	"log"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/ui/popup/lollypops"
+	"github.com/TypicalAM/goread/internal/ui/tab/category"
+	"github.com/TypicalAM/goread/internal/ui/tab/overview"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// saveDueMsg is sent when the save delay passes, only the latest one triggers a save
+type saveDueMsg int
+
+// mutates returns true if handling the message can change the state which is saved on exit
+func mutates(msg tea.Msg) bool {
+	switch msg.(type) {
+	case overview.ChosenCategoryMsg, category.ChosenFeedMsg, backend.DeleteItemMsg, backend.DownloadItemMsg,
+		backend.ReadLaterItemMsg, backend.ToggleMuteMsg, backend.TogglePinMsg, backend.SetRenderModeMsg,
+		backend.ToggleSortOrderMsg,
+		backend.SavePositionMsg, backend.MarkAsReadMsg, backend.MarkAsUnreadMsg, lollypops.ChoiceResultMsg,
+		lollypops.InputResultMsg:
+		return true
+	}
+
+	return false
+}
+
+// scheduleSave saves the state once the save delay passes without another change, so that a burst of
+// changes is written only once
+func (m Model) scheduleSave() (Model, tea.Cmd) {
+	if m.options.SaveDelay <= 0 {
+		return m, nil
+	}
+
+	m.saveID++
+	id := m.saveID
+	return m, tea.Tick(m.options.SaveDelay, func(time.Time) tea.Msg {
+		return saveDueMsg(id)
+	})
+}
+
+// saveState starts saving the state in the background if no change happened since it was scheduled
+func (m Model) saveState(msg saveDueMsg) tea.Cmd {
+	if int(msg) != m.saveID || m.quitting {
+		return nil
+	}
+
+	log.Println("Saving the state in the background")
+	return m.backend.SaveState()
+}
@@ -4,10 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/cache"
 	"github.com/TypicalAM/goread/internal/backend/rss"
 	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/TypicalAM/goread/internal/ui/popup"
@@ -22,6 +26,33 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// choice is a question asked by the browser itself, rather than by one of the tabs
+type choice int
+
+const (
+	choiceNone choice = iota
+	choiceCloseOtherTabs
+	choiceUpdateFeedURL
+	choiceRetrySave
+	choiceReloadFeeds
+	choiceUndo
+)
+
+// input is a line of text asked for by the browser
+type input int
+
+const (
+	inputNone input = iota
+	inputSearch
+	inputProfile
+	inputRenameFeed
+	inputExportCategory
+	inputMarkOlder
+)
+
+// clearMsgMsg is sent when a status message times out
+type clearMsgMsg int
+
 // Model is used to store the state of the application
 type Model struct {
 	popup          popup.Window
@@ -29,14 +60,30 @@ type Model struct {
 	backend        *backend.Backend
 	style          style
 	msg            string
+	msgID          int
 	keymap         Keymap
+	options        Options
 	tabs           []tab.Tab
+	recent         []string
+	counts         map[string]int
 	activeTab      int
+	pendingChoice  choice
+	pendingInput   input
+	movedFeed      *backend.MovedFeed
+	renamedFeed    string
+	exportedCat    string
+	markScope      string
+	throttle       *throttle
+	saveID         int
 	height         int
 	width          int
 	waitingForSize bool
+	openDefault    bool
 	quitting       bool
+	closed         bool
 	offline        bool
+	incognito      bool
+	focused        bool
 }
 
 // New returns a new model with some sensible defaults
@@ -48,17 +95,46 @@ func New(colors *theme.Colors, backend *backend.Backend) Model {
 		backend:        backend,
 		waitingForSize: true,
 		keymap:         DefaultKeymap,
+		options:        DefaultOptions,
+		counts:         make(map[string]int),
 		msg:            "Pro-tip - press [ctrl+h] to view the help page",
+		throttle:       newThrottle(frameInterval),
 	}
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return nil
+	return m.backend.RefreshInBackground()
 }
 
-// Update handles the terminal size, modifying rss items and modifying tabs
+// Update handles the messages, the view is redrawn at most once per frame so that a flood of messages
+// (like fast scrolling or a background refresh) doesn't render it after every single one
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case redrawMsg:
+		m.throttle.redrawn()
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.throttle.invalidate()
+	}
+
+	model, cmd := m.update(msg)
+	var saveCmd tea.Cmd
+	if updated, ok := model.(Model); ok {
+		updated = updated.touchActiveTab(m)
+		if mutates(msg) {
+			updated, saveCmd = updated.scheduleSave()
+		}
+
+		model = updated
+	}
+
+	return model, tea.Batch(cmd, saveCmd, m.throttle.schedule())
+}
+
+// update handles the terminal size, modifying rss items and modifying tabs
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.waitingForSize {
 		return m.waitForSize(msg)
 	}
@@ -67,8 +143,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case backend.StartQuittingMsg:
-		m.quitting = true
-		return m, tea.Quit
+		return m.quit()
+
+	case backend.EscapeMsg:
+		// The popups and the filters were already handled, try closing the tab and quit as a last resort
+		if m.options.EscClosesTab && len(m.tabs) > 1 {
+			return m.closeTab()
+		}
+
+		if m.options.EscQuits {
+			return m.quit()
+		}
+
+		return m, m.setMsg("Press ctrl+c to quit")
 
 	case backend.FetchErrorMsg:
 		// Update the underlying tab in case it also handles error input
@@ -89,8 +176,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.Sequence(cmd, m.backend.FetchCategories(""))
 			}
 
-			m.msg = fmt.Sprintf("Updated category %s", msg.Name)
-			return m, m.backend.FetchCategories("")
+			m = m.renameCategoryTabs(msg.OldName, msg.Name)
+
+			cmd := m.setMsg(fmt.Sprintf("Updated category %s", msg.Name))
+			return m, tea.Batch(cmd, m.backend.FetchCategories(""))
 		}
 
 		if err := m.backend.Rss.AddCategory(msg.Name, msg.Desc); err != nil {
@@ -99,22 +188,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Sequence(cmd, m.backend.FetchCategories(""))
 		}
 
-		m.msg = fmt.Sprintf("Added category %s", msg.Name)
-		return m, m.backend.FetchCategories("")
+		cmd := m.setMsg(fmt.Sprintf("Added category %s", msg.Name))
+		return m, tea.Batch(cmd, m.backend.FetchCategories(""))
 
 	case category.ChosenFeedMsg:
 		m.popup = nil
 		m.keymap.SetEnabled(true)
 
 		if msg.IsEdit {
-			if err := m.backend.Rss.UpdateFeed(msg.Parent, msg.OldName, msg.Name, msg.URL); err != nil {
+			if err := m.backend.UpdateFeed(msg.Parent, msg.OldName, msg.Name, msg.URL); err != nil {
 				errMsg := fmt.Sprintf("Error updating feed: %s", unwrapErrs(err))
 				m, cmd := m.showPopup(lollypops.NewError(m.style.colors, errMsg))
 				return m, tea.Batch(cmd, m.backend.FetchFeeds(msg.Parent))
 			}
 
-			m.msg = fmt.Sprintf("Updated feed %s", msg.Name)
-			return m, m.backend.FetchFeeds(msg.Parent)
+			m = m.renameFeedTabs(msg.OldName, msg.Name)
+
+			if err := m.backend.Rss.SetFeedColor(msg.Name, msg.Color); err != nil {
+				errMsg := fmt.Sprintf("Error setting the feed color: %s", unwrapErrs(err))
+				m, cmd := m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+				return m, tea.Batch(cmd, m.backend.FetchFeeds(msg.Parent))
+			}
+
+			cmd := m.setMsg(fmt.Sprintf("Updated feed %s", msg.Name))
+			return m, tea.Batch(cmd, m.backend.FetchFeeds(msg.Parent))
 		}
 
 		if err := m.backend.Rss.AddFeed(msg.Parent, msg.Name, msg.URL); err != nil {
@@ -123,12 +220,70 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmd, m.backend.FetchFeeds(msg.Parent))
 		}
 
-		m.msg = fmt.Sprintf("Added feed %s", msg.Name)
-		return m, m.backend.FetchFeeds(msg.Parent)
+		if err := m.backend.Rss.SetFeedColor(msg.Name, msg.Color); err != nil {
+			errMsg := fmt.Sprintf("Added feed %s without a color: %s", msg.Name, unwrapErrs(err))
+			m, cmd := m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+			return m, tea.Batch(cmd, m.backend.FetchFeeds(msg.Parent))
+		}
+
+		cmd := m.setMsg(fmt.Sprintf("Added feed %s", msg.Name))
+		return m, tea.Batch(cmd, m.backend.FetchFeeds(msg.Parent))
 
 	case tab.NewTabMsg:
 		return m.createNewTab(msg)
 
+	case clearMsgMsg:
+		// Only clear the message if it wasn't replaced in the meantime
+		if int(msg) == m.msgID {
+			m.msg = ""
+		}
+
+		return m, nil
+
+	case backend.FetchSuccessMsg:
+		// The cache might have changed, the message is still handled by the tab
+		m.updateCounts()
+
+		// The welcome tab has loaded the categories, open the default one
+		if m.openDefault && m.activeTab == 0 {
+			m.openDefault = false
+			updated, cmd := m.tabs[0].Update(msg)
+			m.tabs[0] = updated.(tab.Tab)
+			m, openCmd := m.openDefaultCategory()
+			return m, tea.Batch(cmd, openCmd)
+		}
+
+	case backend.FetchArticleSuccessMsg:
+		m.updateCounts()
+
+		var noticeCmd tea.Cmd
+		if msg.Notice != "" {
+			noticeCmd = m.setMsg(msg.Notice)
+		}
+
+		// Try the feeds which failed again in the background, the tab shows the articles it got
+		if retryCmd := m.backend.RetryFailed(msg.Failed); retryCmd != nil {
+			noticeCmd = tea.Batch(noticeCmd, retryCmd)
+		}
+
+		// Offer to follow the redirect if the feed has moved, the tab still gets the articles
+		if msg.Moved != nil && m.popup == nil {
+			updated, cmd := m.tabs[m.activeTab].Update(msg)
+			m.tabs[m.activeTab] = updated.(tab.Tab)
+			m.pendingChoice = choiceUpdateFeedURL
+			m.movedFeed = msg.Moved
+			m.keymap.SetEnabled(false)
+			question := fmt.Sprintf("%s has moved, update the url?", msg.Moved.Name)
+			m, popupCmd := m.showPopup(lollypops.NewChoice(m.style.colors, question, true))
+			return m, tea.Batch(cmd, popupCmd, noticeCmd)
+		}
+
+		if noticeCmd != nil {
+			updated, cmd := m.tabs[m.activeTab].Update(msg)
+			m.tabs[m.activeTab] = updated.(tab.Tab)
+			return m, tea.Batch(cmd, noticeCmd)
+		}
+
 	case backend.NewItemMsg:
 		m.keymap.SetEnabled(false)
 
@@ -150,7 +305,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case overview.Model:
 			return m.showPopup(overview.NewPopup(m.style.colors, oldName, oldDesc))
 		case category.Model:
-			return m.showPopup(category.NewPopup(m.style.colors, oldName, oldDesc, msg.Sender.Title()))
+			editPopup := category.NewPopup(m.style.colors, oldName, oldDesc, msg.Sender.Title())
+			if edited, err := m.backend.Rss.GetFeed(oldName); err == nil {
+				editPopup = editPopup.WithColor(edited.Color)
+			}
+
+			return m.showPopup(editPopup)
 		case feed.Model:
 		}
 
@@ -160,25 +320,226 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.deleteItem(msg)
 
 	case backend.DownloadItemMsg:
+		if m.incognito {
+			return m.refuseIncognito("save articles")
+		}
+
 		return m.downloadItem(msg)
 
+	case backend.DownloadEnclosureMsg:
+		log.Println("Downloading the enclosure of", msg.FeedName, msg.Index)
+		return m, m.backend.DownloadEnclosure(msg.FeedName, msg.Index)
+
+	case backend.EnclosureProgressMsg:
+		return m.enclosureProgress(msg)
+
+	case backend.ReadLaterItemMsg:
+		if m.incognito {
+			return m.refuseIncognito("queue articles")
+		}
+
+		log.Println("Adding item to read later", msg.FeedName, msg.Index)
+		cmd := m.setMsg("Item added to the read later queue")
+		return m, tea.Batch(cmd, m.backend.AddToReadLater(msg.FeedName, msg.Index))
+
+	case backend.RetryProgressMsg:
+		m.updateCounts()
+		for _, failed := range msg.GaveUp {
+			log.Println("Giving up on fetching", failed.URL, "after", msg.Rounds, "retries")
+		}
+
+		var nextCmd tea.Cmd
+		if !msg.Final {
+			nextCmd = m.backend.RetryNext()
+		}
+
+		switch {
+		case msg.Retried == 0:
+			return m, nextCmd
+		case len(msg.GaveUp) > 0:
+			text := fmt.Sprintf("%d feeds still fail after %d retries, see the log", len(msg.GaveUp), msg.Rounds)
+			return m, tea.Batch(m.setMsg(text), nextCmd)
+		case msg.Final:
+			text := fmt.Sprintf("%d failed feeds recovered, refresh to see their articles", msg.Recovered)
+			return m, m.setMsg(text)
+		}
+
+		text := fmt.Sprintf("Retried %d failed feeds, %d recovered", msg.Retried, msg.Recovered)
+		return m, tea.Batch(m.setMsg(text), nextCmd)
+
+	case backend.BackgroundRefreshMsg:
+		return m.notifyNew(msg)
+
+	case backend.ToggleMuteMsg:
+		muted, err := m.backend.Rss.ToggleMute(string(msg))
+		if err != nil {
+			errMsg := fmt.Sprintf("Error muting feed %s: %s", string(msg), unwrapErrs(err))
+			return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+		}
+
+		text := fmt.Sprintf("Unmuted feed %s", string(msg))
+		if muted {
+			text = fmt.Sprintf("Muted feed %s", string(msg))
+		}
+
+		m.updateCounts()
+		cmd := m.setMsg(text)
+		return m, tea.Batch(cmd, m.backend.FetchFeeds(m.tabs[m.activeTab].Title()))
+
+	case backend.ToggleSortOrderMsg:
+		oldestFirst, err := m.backend.Rss.ToggleOldestFirst(string(msg))
+		if err != nil {
+			errMsg := fmt.Sprintf("Error changing the article order of %s: %s", string(msg), unwrapErrs(err))
+			return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+		}
+
+		text := fmt.Sprintf("Feed %s now lists the newest articles first", string(msg))
+		if oldestFirst {
+			text = fmt.Sprintf("Feed %s now lists the oldest articles first", string(msg))
+		}
+
+		cmd := m.setMsg(text)
+		return m, tea.Batch(cmd, m.backend.FetchArticles(string(msg), false))
+
+	case backend.SetRenderModeMsg:
+		if err := m.backend.Rss.SetFeedRenderMode(msg.FeedName, msg.Mode); err != nil {
+			errMsg := fmt.Sprintf("Error saving the render mode of %s: %s", msg.FeedName, unwrapErrs(err))
+			return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+		}
+
+		return m, m.setMsg(fmt.Sprintf("Feed %s is now shown as %s by default", msg.FeedName, msg.Mode))
+
+	case backend.RenameFeedMsg:
+		m.keymap.SetEnabled(false)
+		m.pendingInput = inputRenameFeed
+		m.renamedFeed = string(msg)
+		input := lollypops.NewInput(m.style.colors, "Rename feed", "Name: ").WithValue(string(msg))
+		return m.showPopup(input)
+
+	case backend.ExportCategoryMsg:
+		if rss.IsReservedName(string(msg)) {
+			errMsg := fmt.Sprintf("%s is a virtual category, only the categories with their own feeds can be exported", string(msg))
+			return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+		}
+
+		m.keymap.SetEnabled(false)
+		m.pendingInput = inputExportCategory
+		m.exportedCat = string(msg)
+		fileName := strings.ReplaceAll(strings.ToLower(string(msg)), " ", "_") + ".opml"
+		input := lollypops.NewInput(m.style.colors, "Export category", "Path: ").WithValue(fileName)
+		return m.showPopup(input)
+
+	case backend.SavePositionMsg:
+		if m.incognito {
+			return m, nil
+		}
+
+		m.backend.Cache.SavePosition(msg.GUID, msg.Offset)
+		return m, nil
+
+	case backend.TogglePinMsg:
+		pinned, err := m.backend.Rss.TogglePin(string(msg))
+		if err != nil {
+			errMsg := fmt.Sprintf("Error pinning category %s: %s", string(msg), unwrapErrs(err))
+			return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+		}
+
+		text := fmt.Sprintf("Unpinned category %s", string(msg))
+		if pinned {
+			text = fmt.Sprintf("Pinned category %s", string(msg))
+		}
+
+		cmd := m.setMsg(text)
+		return m, tea.Batch(cmd, m.backend.FetchCategories(""))
+
 	case backend.MarkAsReadMsg:
+		if m.incognito {
+			return m, nil
+		}
+
 		m.backend.ReadStatus.MarkAsRead(string(msg))
+		m.updateCounts()
 		return m, nil
 
 	case backend.MarkAsUnreadMsg:
+		if m.incognito {
+			return m, nil
+		}
+
 		m.backend.ReadStatus.MarkAsUnread(string(msg))
+		m.updateCounts()
 		return m, nil
 
 	case backend.MakeChoiceMsg:
+		// The tab asked the question, the answer has to go back to it
+		m.pendingChoice = choiceNone
 		return m.showPopup(lollypops.NewChoice(m.style.colors, msg.Question, msg.Default))
 
+	case backend.SetStatusMsg:
+		return m, m.setMsg(string(msg))
+
+	case saveDueMsg:
+		return m, m.saveState(msg)
+
+	case backend.SaveStateMsg:
+		if msg.Err != nil {
+			log.Println("Failed to save the state in the background:", msg.Err)
+			return m, m.setMsg(fmt.Sprintf("Failed to save - %s", unwrapErrs(msg.Err)))
+		}
+
+		msg.MarkSaved()
+
 	case backend.ShowErrorMsg:
+		m = m.closePopup()
+		return m.showPopup(lollypops.NewError(m.style.colors, msg.Msg))
+
+	case lollypops.ChoiceResultMsg:
+		// The browser asked the question, don't pass the answer to the tab. The question is only still
+		// pending while its popup is showing.
+		_, showing := m.popup.(lollypops.Choice)
 		m.keymap.SetEnabled(true)
 		m.popup = nil
-		return m.showPopup(lollypops.NewError(m.style.colors, msg.Msg))
+		if m.pendingChoice != choiceNone && showing {
+			return m.handleChoice(msg.Result)
+		}
+
+		m.pendingChoice = choiceNone
+		m.movedFeed = nil
+
+	case lollypops.InputResultMsg:
+		m.keymap.SetEnabled(true)
+		m.popup = nil
+		pending := m.pendingInput
+		m.pendingInput = inputNone
+		if msg.Value == "" {
+			return m, nil
+		}
+
+		switch pending {
+		case inputProfile:
+			return m.switchProfile(strings.TrimSpace(msg.Value))
+		case inputRenameFeed:
+			return m.renameFeed(strings.TrimSpace(msg.Value))
+		case inputExportCategory:
+			return m.exportCategory(strings.TrimSpace(msg.Value))
+		case inputMarkOlder:
+			return m.markOlderAsRead(msg.Value)
+		}
 
-	case lollypops.ChoiceResultMsg, lollypops.ErrorResultMsg, closeHelpMsg:
+		return m.insertTab(feed.New(
+			m.style.colors, m.width, m.tabHeight(), backend.SearchTitle(msg.Value), m.backend.FetchSearchResults,
+		).DisableDeleting())
+
+	case switcherResultMsg:
+		m.keymap.SetEnabled(true)
+		m.popup = nil
+
+		return m.insertTab(m.newFeedTab(msg.feed, msg.category))
+
+	case backend.ShowFeedInfoMsg:
+		return m.showFeedInfo(string(msg))
+
+	case lollypops.ErrorResultMsg, closeHelpMsg, closeFeedInfoMsg:
 		m.keymap.SetEnabled(true)
 		m.popup = nil
 
@@ -188,7 +549,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.msg = ""
 
 		for i := range m.tabs {
-			m.tabs[i] = m.tabs[i].SetSize(m.width, m.height-5)
+			m.tabs[i] = m.tabs[i].SetSize(m.width, m.tabHeight())
 		}
 
 		// Delete the popup, update the overlay and rerender
@@ -203,33 +564,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case msg.String() == "ctrl+c":
-			m.quitting = true
-			return m, tea.Quit
+			return m.quit()
 
 		case msg.String() == "esc":
 			// If we are showing a popup, close it. We leave esc handling to the model.
 			if m.popup != nil {
-				m.keymap.SetEnabled(true)
-				m.popup = nil
-				return m, nil
+				return m.closePopup(), nil
 			}
 
 		case key.Matches(msg, m.keymap.CloseTab):
 			if len(m.tabs) == 1 {
-				m.quitting = true
-				return m, tea.Quit
+				if m.options.LastTabAction == LastTabWelcome {
+					return m.replaceLastTab()
+				}
+
+				return m.quit()
 			}
 
-			// Close the current tab
-			m.tabs = append(m.tabs[:m.activeTab], m.tabs[m.activeTab+1:]...)
-			m.activeTab--
+			return m.closeTab()
 
-			if m.activeTab < 0 {
-				m.activeTab = 0
+		case key.Matches(msg, m.keymap.CloseOtherTabs):
+			toClose := len(m.tabs) - len(m.tabsToKeep())
+			if toClose == 0 {
+				return m, nil
 			}
 
-			m.msg = fmt.Sprintf("Closed tab - %s", m.tabs[m.activeTab].Title())
-			return m, nil
+			if toClose <= 2 {
+				return m.closeOtherTabs()
+			}
+
+			m.pendingChoice = choiceCloseOtherTabs
+			m.keymap.SetEnabled(false)
+			question := fmt.Sprintf("Close %d tabs?", toClose)
+			return m.showPopup(lollypops.NewChoice(m.style.colors, question, true))
+
+		case key.Matches(msg, m.keymap.GoHome):
+			return m.goHome()
 
 		case key.Matches(msg, m.keymap.NextTab):
 			m.activeTab++
@@ -249,11 +619,79 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.msg = ""
 			return m, nil
 
+		case key.Matches(msg, m.keymap.JumpToTab):
+			// The n-th key of the binding jumps to the n-th tab
+			index := slices.Index(m.keymap.JumpToTab.Keys(), msg.String())
+			if index < 0 || index > len(m.tabs)-1 {
+				return m, nil
+			}
+
+			m.activeTab = index
+			m.msg = ""
+			return m, nil
+
+		case key.Matches(msg, m.keymap.SearchAll):
+			m.keymap.SetEnabled(false)
+			m.pendingInput = inputSearch
+			return m.showPopup(lollypops.NewInput(m.style.colors, "Search all feeds", "Query: "))
+
+		case key.Matches(msg, m.keymap.JumpToFeed):
+			m.keymap.SetEnabled(false)
+			return m.showPopup(newSwitcher(m.style.colors, "Jump to feed", allFeeds(m.backend.Rss.Categories)))
+
+		case key.Matches(msg, m.keymap.RecentFeeds):
+			m.keymap.SetEnabled(false)
+			recent := recentFeeds(m.backend.Rss.Categories, m.backend.Cache.RecentFeeds())
+			return m.showPopup(newSwitcher(m.style.colors, "Recent feeds", recent))
+
+		case key.Matches(msg, m.keymap.MarkOlderAsRead):
+			if m.incognito {
+				return m.refuseIncognito("mark articles as read")
+			}
+
+			// The welcome tab marks the articles of every feed
+			m.markScope = m.tabs[m.activeTab].Title()
+			title := "Mark as read in " + m.markScope
+			if _, ok := m.tabs[m.activeTab].(overview.Model); ok {
+				m.markScope = ""
+				title = "Mark as read everywhere"
+			}
+
+			m.keymap.SetEnabled(false)
+			m.pendingInput = inputMarkOlder
+			return m.showPopup(lollypops.NewInput(m.style.colors, title, "Older than: ").WithValue("30d"))
+
+		case key.Matches(msg, m.keymap.SwitchProfile):
+			m.keymap.SetEnabled(false)
+			m.pendingInput = inputProfile
+			var msgCmd tea.Cmd
+			if profiles, err := backend.Profiles(); err == nil {
+				msgCmd = m.setMsg("Profiles: " + strings.Join(profiles, ", "))
+			}
+
+			m, popupCmd := m.showPopup(lollypops.NewInput(m.style.colors, "Switch profile", "Profile: "))
+			return m, tea.Batch(msgCmd, popupCmd)
+
 		case key.Matches(msg, m.keymap.ShowHelp):
 			return m.showPopup(newHelp(m.style.colors, m.FullHelp()))
 
 		case key.Matches(msg, m.keymap.ToggleOfflineMode):
 			return m.toggleOffline()
+
+		case key.Matches(msg, m.keymap.ToggleIncognito):
+			return m.toggleIncognito()
+
+		case key.Matches(msg, m.keymap.ReloadColors):
+			return m.reloadColors()
+
+		case key.Matches(msg, m.keymap.ToggleFocus):
+			return m.toggleFocus()
+
+		case key.Matches(msg, m.keymap.ReloadFeeds):
+			return m.askReloadFeeds()
+
+		case key.Matches(msg, m.keymap.Undo):
+			return m.askUndo()
 		}
 	}
 
@@ -279,10 +717,27 @@ func (m Model) View() string {
 		return "Loading..."
 	}
 
+	// The tab bar and the status bar fall apart in tiny terminals, ask for more room instead
+	if m.width < m.options.MinWidth || m.height < m.options.MinHeight {
+		warning := fmt.Sprintf("Terminal too small (need %dx%d)", m.options.MinWidth, m.options.MinHeight)
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, warning)
+	}
+
+	return m.throttle.render(m.render)
+}
+
+// render renders the tabs, the popup and the status bar
+func (m Model) render() string {
 	if m.popup != nil {
 		return m.overlay.WrapView(m.popup.View())
 	}
 
+	// The focus mode leaves out the bars and the status message
+	if m.focused {
+		constrainHeight := lipgloss.NewStyle().Height(m.height).MaxHeight(m.height)
+		return constrainHeight.Render(m.tabs[m.activeTab].View())
+	}
+
 	var b strings.Builder
 	b.WriteString(m.renderTabBar())
 	b.WriteRune('\n')
@@ -294,6 +749,8 @@ func (m Model) View() string {
 
 	if strings.Contains(m.msg, "Error") {
 		b.WriteString(m.style.errMsg.Render(m.msg))
+	} else if strings.HasPrefix(m.msg, backend.AlertMark) {
+		b.WriteString(m.style.alertMsg.Render(m.msg))
 	} else {
 		b.WriteString(m.msg)
 	}
@@ -303,7 +760,21 @@ func (m Model) View() string {
 
 // ShortHelp returns the short help for the browser.
 func (m Model) ShortHelp() []key.Binding {
-	return []key.Binding{m.keymap.CloseTab, m.keymap.NextTab, m.keymap.PrevTab, m.keymap.ToggleOfflineMode}
+	return []key.Binding{
+		m.keymap.CloseTab, m.keymap.CloseOtherTabs, m.keymap.GoHome, m.keymap.NextTab, m.keymap.PrevTab, m.keymap.JumpToTab,
+		m.keymap.SearchAll, m.keymap.JumpToFeed, m.keymap.RecentFeeds, m.keymap.SwitchProfile, m.keymap.ToggleOfflineMode, m.keymap.ToggleIncognito,
+		m.keymap.MarkOlderAsRead, m.keymap.ReloadColors, m.keymap.ToggleFocus, m.keymap.ReloadFeeds, m.undoKey(),
+	}
+}
+
+// undoKey returns the undo binding, it's disabled if the feeds can't be edited
+func (m Model) undoKey() key.Binding {
+	undo := m.keymap.Undo
+	if !m.backend.Capabilities().EditFeeds {
+		undo.SetEnabled(false)
+	}
+
+	return undo
 }
 
 // FullHelp returns the full help for the browser.
@@ -324,21 +795,54 @@ func (m Model) waitForSize(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.height = sizeMsg.Height
 	m.waitingForSize = false
 
-	m.tabs = append(m.tabs, overview.New(
-		m.style.colors,
-		m.width,
-		m.height-5,
-		"Welcome",
-		m.backend.FetchCategories,
-	))
+	m.tabs = append(m.tabs, m.newWelcomeTab())
 
-	return m, m.tabs[0].Init()
+	m.openDefault = m.options.DefaultCategory != ""
+	m, launchCmd := m.openLaunchFeeds()
+	return m, tea.Batch(m.tabs[0].Init(), launchCmd)
+}
+
+// openLaunchFeeds opens the feeds set in the options after the welcome tab, the feeds which no longer
+// exist are skipped
+func (m Model) openLaunchFeeds() (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	var missing []string
+	for _, id := range m.options.OpenOnLaunch {
+		launchFeed, categoryName, err := m.backend.Rss.FindFeed(id)
+		if err != nil {
+			log.Println("The feed opened on launch doesn't exist:", id)
+			missing = append(missing, id)
+			continue
+		}
+
+		var cmd tea.Cmd
+		m, cmd = m.insertTab(m.newFeedTab(launchFeed.Name, categoryName))
+		cmds = append(cmds, cmd)
+	}
+
+	m.activeTab = 0
+	if len(missing) > 0 {
+		cmds = append(cmds, m.setMsg(fmt.Sprintf("Skipped the missing launch feeds - %s", strings.Join(missing, ", "))))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// openDefaultCategory opens the category set in the options, if it no longer exists the welcome tab is kept
+func (m Model) openDefaultCategory() (Model, tea.Cmd) {
+	name := m.options.DefaultCategory
+	if _, err := m.backend.Rss.GetFeeds(name); err != nil && !rss.IsReservedName(name) {
+		log.Println("The default category doesn't exist:", name)
+		return m, nil
+	}
+
+	return m.createNewTab(tab.NewTabMsg{Sender: m.tabs[0], Title: name})
 }
 
 // createNewTab bootstraps the new tab and adds it to the model
 func (m Model) createNewTab(msg tab.NewTabMsg) (Model, tea.Cmd) {
 	var newTab tab.Tab
-	height := m.height - 5
+	height := m.tabHeight()
 
 	switch msg.Sender.(type) {
 	case overview.Model:
@@ -351,45 +855,329 @@ func (m Model) createNewTab(msg tab.NewTabMsg) (Model, tea.Cmd) {
 			newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchDownloadedArticles).
 				DisableSaving()
 
+		case rss.ReadLaterFeedsName:
+			newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchReadLaterArticles)
+
+		case rss.TodayFeedsName:
+			newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchTodayArticles).
+				DisableDeleting()
+
 		default:
 			newTab = category.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchFeeds)
+			if m.isCombination(msg.Title) {
+				newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchCombinedArticles).
+					DisableDeleting()
+			}
 		}
 
 	case category.Model:
-		newTab = feed.New(m.style.colors, m.width, height, msg.Title, m.backend.FetchArticles).
-			DisableDeleting()
+		newTab = m.newFeedTab(msg.Title, msg.Sender.Title())
+	}
+
+	return m.insertTab(newTab)
+}
+
+// closeTab closes the active tab, there has to be another tab left to show
+func (m Model) closeTab() (tea.Model, tea.Cmd) {
+	closed := m.tabs[m.activeTab].Title()
+	m.tabs = append(m.tabs[:m.activeTab], m.tabs[m.activeTab+1:]...)
+	m.activeTab--
+
+	if m.activeTab < 0 {
+		m.activeTab = 0
+	}
+
+	return m, m.setMsg(fmt.Sprintf("Closed tab - %s", closed))
+}
+
+// replaceLastTab replaces the last open tab with a fresh welcome tab
+func (m Model) replaceLastTab() (tea.Model, tea.Cmd) {
+	closed := m.tabs[0].Title()
+	m.tabs = []tab.Tab{m.newWelcomeTab()}
+	m.activeTab = 0
+
+	return m, tea.Batch(m.tabs[0].Init(), m.setMsg(fmt.Sprintf("Closed tab - %s", closed)))
+}
+
+// goHome switches to the welcome tab, a fresh one is opened as the first tab if it was closed
+func (m Model) goHome() (tea.Model, tea.Cmd) {
+	m.msg = ""
+	if _, ok := m.tabs[0].(overview.Model); ok {
+		m.activeTab = 0
+		return m, nil
+	}
+
+	m.tabs = append([]tab.Tab{m.newWelcomeTab()}, m.tabs...)
+	m.activeTab = 0
+	return m, m.tabs[0].Init()
+}
+
+// isCombination returns true if the name belongs to a combination of categories, the categories take
+// precedence if the names clash
+func (m Model) isCombination(name string) bool {
+	if _, err := m.backend.Rss.GetFeeds(name); err == nil {
+		return false
+	}
+
+	_, err := m.backend.Rss.GetCombination(name)
+	return err == nil
+}
+
+// newWelcomeTab creates the overview tab which lists the categories
+func (m Model) newWelcomeTab() tab.Tab {
+	return m.applyCapabilities(overview.New(m.style.colors, m.width, m.tabHeight(), "Welcome", m.backend.FetchCategories))
+}
+
+// applyCapabilities hides the actions of a tab which the backend doesn't support
+func (m Model) applyCapabilities(t tab.Tab) tab.Tab {
+	if m.backend.Capabilities().EditFeeds {
+		return t
+	}
+
+	switch t := t.(type) {
+	case overview.Model:
+		return t.DisableEditing()
+	case category.Model:
+		return t.DisableEditing()
+	case feed.Model:
+		return t.DisableEditing()
+	}
+
+	return t
+}
+
+// newFeedTab creates a tab with the articles of a feed, the feeds of its category can be switched in place
+func (m Model) newFeedTab(name, categoryName string) feed.Model {
+	if !m.incognito {
+		m.backend.Cache.AddToHistory(name, m.options.HistoryLength)
+	}
+	siblings, err := m.backend.SortedFeedNames(categoryName)
+	if err != nil {
+		log.Println("Couldn't get the feeds of the category", categoryName, err)
+	}
+
+	accents := make(map[string]lipgloss.Color)
+	modes := make(map[string]rss.RenderMode)
+	for _, sub := range m.siblingFeeds(name, categoryName) {
+		if sub.Color != "" {
+			accents[sub.Name] = lipgloss.Color(sub.Color)
+		}
+
+		if sub.RenderMode != "" {
+			modes[sub.Name] = sub.RenderMode
+		}
+	}
+
+	return feed.New(m.style.colors, m.width, m.tabHeight(), name, m.backend.FetchArticles).
+		DisableDeleting().
+		EnableRawView(m.backend.FetchRawFeed).
+		EnableOlder(m.backend.LoadOlderArticles).
+		WithSiblings(siblings).
+		WithAccents(accents).
+		WithRenderModes(modes)
+}
+
+// siblingFeeds returns the feeds of the category, or just the feed if the category doesn't exist
+func (m Model) siblingFeeds(name, categoryName string) []rss.Feed {
+	if feeds, err := m.backend.Rss.GetFeeds(categoryName); err == nil {
+		return feeds
+	}
+
+	if single, err := m.backend.Rss.GetFeed(name); err == nil {
+		return []rss.Feed{*single}
+	}
+
+	return nil
+}
+
+// insertTab inserts the tab after the active tab and initializes it, if the same tab is already open
+// and the tabs are reused the existing one is shown instead. Reaching the tab limit closes the least
+// recently used tab or refuses to open a new one.
+func (m Model) insertTab(newTab tab.Tab) (Model, tea.Cmd) {
+	newTab = m.applyCapabilities(newTab)
+	if m.options.ReuseTabs {
+		if index := m.findTab(newTab); index >= 0 {
+			m.activeTab = index
+			m.msg = ""
+			return m, nil
+		}
+	}
+
+	m.msg = ""
+	m, msgCmd, ok := m.evictTab()
+	if !ok {
+		return m, msgCmd
 	}
 
-	// Insert the tab after the active tab
 	m.tabs = append(m.tabs[:m.activeTab+1], append([]tab.Tab{newTab}, m.tabs[m.activeTab+1:]...)...)
 	m.activeTab++
-	m.msg = ""
 
-	return m, newTab.Init()
+	return m, tea.Batch(newTab.Init(), msgCmd)
+}
+
+// findTab returns the index of the open tab which shows the same thing as the given one or -1 if
+// there is none
+func (m Model) findTab(target tab.Tab) int {
+	wanted := m.tabKey(target)
+	if wanted == "" {
+		return -1
+	}
+
+	for i, t := range m.tabs {
+		if m.tabKey(t) == wanted {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// tabKey identifies what the tab shows, the feed tabs are matched by the url of the feed so that
+// feeds subscribed to under different names are caught too. An empty key means the tab can't be matched.
+func (m Model) tabKey(t tab.Tab) string {
+	switch t.(type) {
+	case category.Model:
+		return "category:" + t.Title()
+
+	case feed.Model:
+		if subscription, err := m.backend.Rss.GetFeed(t.Title()); err == nil {
+			return "url:" + subscription.URL
+		}
+
+		return "feed:" + t.Title()
+	}
+
+	return ""
+}
+
+// handleChoice acts on the answer to a question asked by the browser
+func (m Model) handleChoice(result bool) (tea.Model, tea.Cmd) {
+	pending := m.pendingChoice
+	m.pendingChoice = choiceNone
+	if pending == choiceRetrySave {
+		return m.retrySave(result)
+	}
+
+	if !result {
+		if pending == choiceUpdateFeedURL {
+			// Don't ask again until the feed is redirected enough times
+			m.backend.Cache.ResetRedirects(m.movedFeed.OldURL)
+			m.movedFeed = nil
+		}
+
+		return m, nil
+	}
+
+	switch pending {
+	case choiceCloseOtherTabs:
+		return m.closeOtherTabs()
+
+	case choiceUpdateFeedURL:
+		return m.updateFeedURL()
+
+	case choiceReloadFeeds:
+		return m.reloadFeeds()
+
+	case choiceUndo:
+		return m.undo()
+	}
+
+	return m, nil
+}
+
+// updateFeedURL changes the url of the moved feed to the url it redirects to
+func (m Model) updateFeedURL() (tea.Model, tea.Cmd) {
+	moved := m.movedFeed
+	m.movedFeed = nil
+	if err := m.backend.Rss.UpdateFeedURL(moved.Name, moved.NewURL); err != nil {
+		errMsg := fmt.Sprintf("Error updating feed url: %s", unwrapErrs(err))
+		return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+	}
+
+	m.backend.Cache.ResetRedirects(moved.OldURL)
+	cmd := m.setMsg(fmt.Sprintf("Updated the url of %s to %s", moved.Name, moved.NewURL))
+	log.Println(m.msg)
+	return m, cmd
+}
+
+// tabsToKeep returns the tabs which are left open after closing the other tabs, the welcome tab is
+// always kept so that the user can still navigate
+func (m Model) tabsToKeep() []tab.Tab {
+	kept := make([]tab.Tab, 0, 2)
+	if m.activeTab != 0 {
+		if _, ok := m.tabs[0].(overview.Model); ok {
+			kept = append(kept, m.tabs[0])
+		}
+	}
+
+	return append(kept, m.tabs[m.activeTab])
+}
+
+// closeOtherTabs closes every tab except the active one
+func (m Model) closeOtherTabs() (Model, tea.Cmd) {
+	closed := len(m.tabs)
+	m.tabs = m.tabsToKeep()
+	closed -= len(m.tabs)
+	m.activeTab = len(m.tabs) - 1
+	cmd := m.setMsg(fmt.Sprintf("Closed %d tabs", closed))
+	log.Println(m.msg)
+	return m, cmd
 }
 
 // deleteItem deletes the focused item from the backend
 func (m Model) deleteItem(msg backend.DeleteItemMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
-	m.msg = fmt.Sprintf("Deleting item %s", msg.ItemName)
+	msgCmd := m.setMsg(fmt.Sprintf("Deleting item %s", msg.ItemName))
 
 	// Check the type of the item
 	switch msg.Sender.(type) {
 	case overview.Model:
 		cmd = m.backend.FetchCategories("")
-		if err := m.backend.Rss.RemoveCategory(msg.ItemName); err != nil {
+		remove := m.backend.Rss.RemoveCategory
+		operation := fmt.Sprintf("Delete category %s", msg.ItemName)
+		if m.isCombination(msg.ItemName) {
+			remove = m.backend.Rss.RemoveCombination
+			operation = fmt.Sprintf("Delete combination %s", msg.ItemName)
+		}
+
+		err := m.backend.Rss.Checkpoint(operation, func() error { return remove(msg.ItemName) })
+		if err != nil {
 			errMsg := fmt.Sprintf("Error deleting category %s: %s", msg.ItemName, unwrapErrs(err))
 			return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
 		}
 
 	case category.Model:
 		cmd = m.backend.FetchFeeds(m.tabs[m.activeTab].Title())
-		if err := m.backend.Rss.RemoveFeed(m.tabs[m.activeTab].Title(), msg.ItemName); err != nil {
+		catname := m.tabs[m.activeTab].Title()
+		err := m.backend.Rss.Checkpoint(fmt.Sprintf("Delete feed %s", msg.ItemName), func() error {
+			return m.backend.RemoveFeed(catname, msg.ItemName)
+		})
+		if err != nil {
 			errMsg := fmt.Sprintf("Error deleting feed %s: %s", msg.ItemName, unwrapErrs(err))
 			return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
 		}
 
 	case feed.Model:
+		if m.incognito {
+			return m.refuseIncognito("remove saved articles")
+		}
+
+		if msg.Sender.Title() == rss.ReadLaterFeedsName {
+			cmd = m.backend.FetchReadLaterArticles("", false)
+			index, err := strconv.Atoi(msg.ItemName)
+			if err != nil {
+				errMsg := fmt.Sprintf("Error removing from read later %s: %s", msg.ItemName, unwrapErrs(err))
+				return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+			}
+
+			if err := m.backend.Cache.RemoveFromReadLater(index); err != nil {
+				errMsg := fmt.Sprintf("Error removing from read later %s: %s", msg.ItemName, unwrapErrs(err))
+				return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+			}
+
+			break
+		}
+
 		cmd = m.backend.FetchDownloadedArticles("", false)
 		if msg.Sender.Title() == rss.DownloadedFeedsName {
 			index, err := strconv.Atoi(msg.ItemName)
@@ -406,14 +1194,173 @@ func (m Model) deleteItem(msg backend.DeleteItemMsg) (tea.Model, tea.Cmd) {
 	}
 
 	log.Println(m.msg)
-	return m, cmd
+	return m, tea.Batch(cmd, msgCmd)
 }
 
 // downloadItem downloads an item
 func (m Model) downloadItem(msg backend.DownloadItemMsg) (tea.Model, tea.Cmd) {
 	log.Println("Downloading item", msg.FeedName, msg.Index)
-	m.msg = "Item saved! You can find it in the downloaded category"
-	return m, m.backend.DownloadItem(msg.FeedName, msg.Index)
+	cmd := m.setMsg("Item saved! You can find it in the downloaded category")
+	return m, tea.Batch(cmd, m.backend.DownloadItem(msg.FeedName, msg.Index))
+}
+
+// enclosureProgress shows the progress of an enclosure download in the status bar and waits for the next report
+func (m Model) enclosureProgress(msg backend.EnclosureProgressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case errors.Is(msg.Err, cache.ErrAlreadyDownloaded):
+		return m, m.setMsg(fmt.Sprintf("Already downloaded to %s", msg.Path))
+
+	case msg.Err != nil:
+		log.Println("Failed to download the enclosure of", msg.Title, msg.Err)
+		return m, m.setMsg(fmt.Sprintf("Error downloading %s: %s", msg.Title, unwrapErrs(msg.Err)))
+
+	case msg.Done:
+		return m, m.setMsg(fmt.Sprintf("Saved %s to %s", msg.Title, msg.Path))
+
+	case msg.Total > 0:
+		m.msg = fmt.Sprintf("Downloading %s - %d%%", msg.Title, msg.Written*100/msg.Total)
+
+	default:
+		m.msg = fmt.Sprintf("Downloading %s - %.1f MB", msg.Title, float64(msg.Written)/(1<<20))
+	}
+
+	return m, msg.Next()
+}
+
+// renameFeed gives the feed picked in the category tab a new name, its url and cached articles stay the same
+func (m Model) renameFeed(name string) (tea.Model, tea.Cmd) {
+	catName := m.tabs[m.activeTab].Title()
+	if err := m.backend.RenameFeed(catName, m.renamedFeed, name); err != nil {
+		errMsg := fmt.Sprintf("Error renaming feed %s: %s", m.renamedFeed, unwrapErrs(err))
+		return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+	}
+
+	m = m.renameFeedTabs(m.renamedFeed, name)
+	m.updateCounts()
+	cmd := m.setMsg(fmt.Sprintf("Renamed feed %s to %s", m.renamedFeed, name))
+	return m, tea.Batch(cmd, m.backend.FetchFeeds(catName))
+}
+
+// renameFeedTabs switches the open feed tabs to the new name of a renamed feed
+func (m Model) renameFeedTabs(oldName, newName string) Model {
+	tabs := make([]tab.Tab, len(m.tabs))
+	for i, t := range m.tabs {
+		if t, ok := t.(feed.Model); ok {
+			tabs[i] = t.RenameFeed(oldName, newName)
+			continue
+		}
+
+		tabs[i] = t
+	}
+
+	m.tabs = tabs
+	return m
+}
+
+// renameCategoryTabs switches the open tabs of a renamed category to its new name
+func (m Model) renameCategoryTabs(oldName, newName string) Model {
+	tabs := make([]tab.Tab, len(m.tabs))
+	for i, t := range m.tabs {
+		if t, ok := t.(category.Model); ok && t.Title() == oldName {
+			tabs[i] = t.Rename(newName)
+			continue
+		}
+
+		tabs[i] = t
+	}
+
+	m.tabs = tabs
+	return m
+}
+
+// exportCategory writes the feeds of the category picked in the overview to an opml file
+func (m Model) exportCategory(path string) (tea.Model, tea.Cmd) {
+	data, err := m.backend.Rss.ExportCategoryOPML(m.exportedCat)
+	if err == nil {
+		err = os.WriteFile(path, []byte(data), 0600)
+	}
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error exporting category %s: %s", m.exportedCat, unwrapErrs(err))
+		return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+	}
+
+	return m, m.setMsg(fmt.Sprintf("Exported category %s to %s", m.exportedCat, path))
+}
+
+// markOlderAsRead marks the articles older than the entered date or age as read in the scope picked
+// when the input was opened
+func (m Model) markOlderAsRead(value string) (tea.Model, tea.Cmd) {
+	before, err := backend.ParseCutoff(value, time.Now())
+	if err == nil {
+		var marked int
+		marked, err = m.backend.MarkReadBefore(before, m.markScope)
+		if err == nil {
+			m.updateCounts()
+			cmd := m.setMsg(fmt.Sprintf("Marked %d articles published before %s as read", marked, before.Format("2006-01-02 15:04")))
+			return m, tea.Batch(cmd, m.tabs[m.activeTab].Init())
+		}
+	}
+
+	errMsg := fmt.Sprintf("Error marking the older articles as read: %s", unwrapErrs(err))
+	return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+}
+
+// switchProfile saves the current profile and starts over with the backend of the new one
+func (m Model) switchProfile(name string) (tea.Model, tea.Cmd) {
+	if name == m.backend.Profile {
+		return m, m.setMsg(fmt.Sprintf("Already using the profile %s", name))
+	}
+
+	if err := backend.ValidateProfileName(name); err != nil {
+		return m.showPopup(lollypops.NewError(m.style.colors, unwrapErrs(err).Error()))
+	}
+
+	// The current profile is only closed once the new one loaded, so that a failed switch keeps it working
+	newBackend, err := backend.New(name, "", "", false)
+	if err != nil {
+		log.Println("Failed to load the profile:", err)
+		return m.showPopup(lollypops.NewError(m.style.colors, fmt.Sprintf("Failed to load the profile: %v", unwrapErrs(err))))
+	}
+
+	if err = m.backend.Close(m.backend.URLsReadOnly); err != nil {
+		log.Println("Failed to save the profile:", err)
+		return m.showPopup(lollypops.NewError(m.style.colors, fmt.Sprintf("Failed to save the profile: %v", unwrapErrs(err))))
+	}
+
+	newBackend.URLsReadOnly = m.backend.URLsReadOnly
+	newBackend.Cache.OfflineMode = m.offline
+	m.backend = newBackend
+	m.counts = make(map[string]int)
+	m.activeTab = 0
+	m.tabs = []tab.Tab{m.newWelcomeTab()}
+
+	return m, tea.Batch(m.tabs[0].Init(), m.setMsg(fmt.Sprintf("Switched to the profile %s", name)))
+}
+
+// reloadColors reads the colorscheme from its file again and rebuilds the styles of every tab, the
+// popups pick up the new colors when they are opened. A broken file leaves the colors as they were.
+func (m Model) reloadColors() (tea.Model, tea.Cmd) {
+	colors := *m.style.colors
+	if err := colors.Load(); err != nil {
+		log.Println("Failed to reload the colorscheme:", err)
+		return m, m.setMsg(fmt.Sprintf("Error while reloading the colorscheme: %v", unwrapErrs(err)))
+	}
+
+	// The tabs share the colors, so they are changed in place
+	*m.style.colors = colors
+	m.style = newStyle(m.style.colors)
+	for i := range m.tabs {
+		m.tabs[i] = m.tabs[i].Restyle()
+	}
+
+	log.Println("Reloaded the colorscheme from", colors.FilePath)
+	return m, m.setMsg("Reloaded the colorscheme")
+}
+
+// Backend returns the backend the browser is currently using, it changes when the profile is switched
+func (m Model) Backend() *backend.Backend {
+	return m.backend
 }
 
 // toggleOffline toggles the offline mode
@@ -421,21 +1368,51 @@ func (m Model) toggleOffline() (tea.Model, tea.Cmd) {
 	m.offline = !m.offline
 	m.backend.Cache.OfflineMode = m.offline
 
+	var cmd tea.Cmd
 	if m.offline {
-		m.msg = "Offline mode enabled"
+		cmd = m.setMsg("Offline mode enabled")
 	} else {
-		m.msg = "Offline mode disabled"
+		cmd = m.setMsg("Offline mode disabled")
 	}
 
 	log.Println(m.msg)
-	return m, nil
+	return m, cmd
+}
+
+// setMsg shows a transient message in the status bar, it is cleared after the message timeout.
+// Permanent messages are set directly.
+func (m *Model) setMsg(text string) tea.Cmd {
+	m.msg = text
+	m.msgID++
+	if m.options.MessageTimeout <= 0 {
+		return nil
+	}
+
+	id := m.msgID
+	return tea.Tick(m.options.MessageTimeout, func(time.Time) tea.Msg {
+		return clearMsgMsg(id)
+	})
+}
+
+// closePopup dismisses the popup without an answer, the question or the input the browser was waiting
+// for is dropped so that the next answer goes to the tab which asks for it
+func (m Model) closePopup() Model {
+	m.keymap.SetEnabled(true)
+	m.popup = nil
+	m.pendingChoice = choiceNone
+	m.pendingInput = inputNone
+	m.movedFeed = nil
+	m.markScope = ""
+	return m
 }
 
 // showPopup tells the model to show the popup
 func (m Model) showPopup(window popup.Window) (Model, tea.Cmd) {
+	// The cached frame could still show the previous popup or miss this one, so the throttle is skipped
 	m.popup = nil
-	background := m.View()
+	background := m.render()
 	m.popup = window
+	m.throttle.invalidate()
 	width, height := m.popup.GetSize()
 	m.overlay = popup.NewOverlay(background, width, height)
 	return m, m.popup.Init() // TODO: Maybe don't call this while resizing
@@ -445,7 +1422,18 @@ func (m Model) showPopup(window popup.Window) (Model, tea.Cmd) {
 func (m Model) renderTabBar() string {
 	tabs := make([]string, len(m.tabs))
 	for i := range m.tabs {
-		tabs[i] = m.style.attachIcon(m.tabs[i], m.tabs[i].Title(), i == m.activeTab)
+		// Only the tabs reachable with the jump keys get a number
+		number := -1
+		if i < len(m.keymap.JumpToTab.Keys()) {
+			number = (i + 1) % 10
+		}
+
+		count := -1
+		if c, ok := m.counts[countKey(m.tabs[i])]; ok {
+			count = c
+		}
+
+		tabs[i] = m.style.attachIcon(m.tabs[i], m.tabs[i].Title(), m.options.TabTitleWidth, number, count, m.options.UnreadThreshold, i == m.activeTab)
 	}
 
 	if lipgloss.Width(strings.Join(tabs, "")) > m.width {
@@ -465,9 +1453,37 @@ func (m Model) renderTabBar() string {
 	return lipgloss.JoinHorizontal(lipgloss.Left, row, gap)
 }
 
+// updateCounts recalculates the article counts shown next to the tab titles
+func (m Model) updateCounts() {
+	for key := range m.counts {
+		delete(m.counts, key)
+	}
+
+	if !m.options.ShowCounts {
+		return
+	}
+
+	for _, t := range m.tabs {
+		switch t.(type) {
+		case category.Model:
+			m.counts[countKey(t)] = m.backend.CategoryUnreadCount(t.Title())
+		case feed.Model:
+			m.counts[countKey(t)] = m.backend.UnreadCount(t.Title())
+		}
+	}
+}
+
+// countKey returns the key of the tab in the counts map, categories and feeds can share names
+func countKey(t tab.Tab) string {
+	return t.Style().Name + ":" + t.Title()
+}
+
 // renderStatusBar is used to render the status bar at the bottom of the screen
 func (m Model) renderStatusBar() string {
 	row := m.style.styleStatusBarCell(m.tabs[m.activeTab], m.offline)
+	if m.incognito {
+		row = lipgloss.JoinHorizontal(lipgloss.Bottom, row, m.style.incognitoStatusBarCell.Render("INCOGNITO"))
+	}
 
 	var gapAmount int
 	if m.width-lipgloss.Width(row) < 0 {
@@ -0,0 +1,186 @@
+package browser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup/lollypops"
+	"github.com/TypicalAM/goread/internal/ui/tab/category"
+	"github.com/TypicalAM/goread/internal/ui/tab/overview"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestBrowser creates a browser with the default feeds in the directory and a loaded welcome tab
+func newTestBrowser(t *testing.T, dir string) Model {
+	b, err := backend.New("", filepath.Join(dir, "urls.yml"), filepath.Join(dir, "cache"), true)
+	if err != nil {
+		t.Fatalf("couldn't create the backend: %v", err)
+	}
+
+	colors := theme.Default
+	model, _ := New(&colors, b).update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m := model.(Model)
+	m.tabs[0] = m.refreshListTab(m.tabs[0])
+	return m
+}
+
+// press sends a key to the browser
+func press(m Model, keys string) Model {
+	model, _ := m.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)})
+	return model.(Model)
+}
+
+// TestBrowserDismissedChoice if we get an error the answer to a question of a tab goes to a question of
+// the browser which was dismissed with esc
+func TestBrowserDismissedChoice(t *testing.T) {
+	m := newTestBrowser(t, t.TempDir())
+	m.tabs = append(m.tabs, m.tabs[0], m.tabs[0], m.tabs[0], m.tabs[0])
+	m.activeTab = len(m.tabs) - 1
+
+	m = press(m, "C")
+	if m.pendingChoice != choiceCloseOtherTabs || m.popup == nil {
+		t.Fatal("expected to be asked before closing the other tabs")
+	}
+
+	model, _ := m.update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(Model)
+	if m.pendingChoice != choiceNone || m.popup != nil {
+		t.Fatal("expected esc to drop the question")
+	}
+
+	// The welcome tab asks before deleting a category
+	model, _ = m.update(backend.MakeChoiceMsg{Question: "Delete category?", Default: false})
+	model, cmd := model.(Model).update(lollypops.ChoiceResultMsg{Result: true})
+	m = model.(Model)
+	if len(m.tabs) != 5 {
+		t.Errorf("expected the tabs to stay open, got %d tabs", len(m.tabs))
+	}
+
+	if cmd == nil {
+		t.Fatal("expected the answer to go to the tab")
+	}
+
+	if _, ok := cmd().(backend.DeleteItemMsg); !ok {
+		t.Error("expected the tab to delete the category")
+	}
+}
+
+// TestBrowserDismissedRetrySave if we get an error then answering a question of a tab after dismissing the
+// failed save quits the app
+func TestBrowserDismissedRetrySave(t *testing.T) {
+	dir := t.TempDir()
+	m := newTestBrowser(t, dir)
+
+	// The urls file can't be written over a directory
+	if err := os.MkdirAll(filepath.Join(dir, "urls.yml"), 0755); err != nil {
+		t.Fatalf("couldn't block the urls file: %v", err)
+	}
+
+	m, _ = m.quit()
+	if m.pendingChoice != choiceRetrySave || m.quitting {
+		t.Fatal("expected to be asked to save again")
+	}
+
+	model, _ := m.update(tea.KeyMsg{Type: tea.KeyEsc})
+	model, _ = model.(Model).update(backend.MakeChoiceMsg{Question: "Delete this feed?", Default: false})
+	model, _ = model.(Model).update(lollypops.ChoiceResultMsg{Result: false})
+	if m = model.(Model); m.quitting || m.closed {
+		t.Error("expected the answer to the tab not to quit")
+	}
+}
+
+// TestBrowserRenameTabs if we get an error then the open tabs keep showing a renamed feed or category under
+// its old name
+func TestBrowserRenameTabs(t *testing.T) {
+	m := newTestBrowser(t, t.TempDir())
+	// The first category is the one with all the feeds
+	catName := m.backend.Rss.Categories[1].Name
+	feedName := m.backend.Rss.Categories[1].Subscriptions[0].Name
+
+	catTab := m.refreshListTab(category.New(m.style.colors, m.width, m.tabHeight(), catName, m.backend.FetchFeeds))
+	m.tabs = append(m.tabs, catTab, m.newFeedTab(feedName, catName))
+	m.activeTab = 1
+
+	m.renamedFeed = feedName
+	model, _ := m.renameFeed("Renamed feed")
+	m = model.(Model)
+	if title := m.tabs[2].Title(); title != "Renamed feed" {
+		t.Errorf("expected the feed tab to show the new name, got %s", title)
+	}
+
+	model, _ = m.update(overview.ChosenCategoryMsg{Name: "Renamed category", OldName: catName, IsEdit: true})
+	m = model.(Model)
+	if title := m.tabs[1].Title(); title != "Renamed category" {
+		t.Errorf("expected the category tab to show the new name, got %s", title)
+	}
+
+	if _, err := m.backend.Rss.GetFeeds(m.tabs[1].Title()); err != nil {
+		t.Errorf("expected the category tab to find its feeds, got %v", err)
+	}
+}
+
+// TestBrowserSwitchBrokenProfile if we get an error then a profile which fails to load leaves the browser
+// with a closed backend which doesn't save anymore
+func TestBrowserSwitchBrokenProfile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+
+	profileDir := filepath.Join(dir, "config", "goread", "profiles", "broken")
+	if err := os.MkdirAll(profileDir, 0755); err != nil {
+		t.Fatalf("couldn't create the profile: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(profileDir, "urls.yml"), []byte("categories: ["), 0600); err != nil {
+		t.Fatalf("couldn't break the profile: %v", err)
+	}
+
+	m := newTestBrowser(t, dir)
+	old := m.backend
+	model, _ := m.switchProfile("broken")
+	if m = model.(Model); m.backend != old {
+		t.Fatal("expected the broken profile not to be used")
+	}
+
+	if _, ok := m.popup.(lollypops.AppError); !ok {
+		t.Error("expected the failed switch to be shown")
+	}
+
+	if err := m.backend.Rss.AddCategory("Later", "Added after the switch"); err != nil {
+		t.Fatalf("couldn't add the category: %v", err)
+	}
+
+	if msg := m.backend.SaveState()().(backend.SaveStateMsg); msg.Err != nil {
+		t.Fatalf("couldn't save the state: %v", msg.Err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(dir, "urls.yml")); !strings.Contains(string(data), "Later") {
+		t.Error("expected the current profile to still be saved")
+	}
+}
+
+// TestBrowserPopupFrame if we get an error then a popup shows up a frame late or a new popup is drawn over
+// the frame of the previous one
+func TestBrowserPopupFrame(t *testing.T) {
+	m := newTestBrowser(t, t.TempDir())
+	now := time.Now()
+	m.throttle.now = func() time.Time { return now }
+	m.View()
+
+	model, _ := m.update(backend.ShowErrorMsg{Msg: "First failure"})
+	m = model.(Model)
+	if !strings.Contains(m.View(), "First failure") {
+		t.Fatal("expected the popup to show up in the same frame")
+	}
+
+	model, _ = m.update(backend.ShowErrorMsg{Msg: "Second failure"})
+	m = model.(Model)
+	if view := m.View(); !strings.Contains(view, "Second failure") || strings.Contains(view, "First failure") {
+		t.Errorf("expected only the second popup, got %s", view)
+	}
+}
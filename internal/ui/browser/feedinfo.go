@@ -0,0 +1,122 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/TypicalAM/goread/internal/ui/popup/lollypops"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// closeFeedInfoMsg is the message sent when the user presses a button to close the feed info
+type closeFeedInfoMsg struct{}
+
+// FeedInfo is a popup which shows the url of a feed and how long its recent fetches took.
+type FeedInfo struct {
+	border popup.TitleBorder
+	box    lipgloss.Style
+	text   string
+	width  int
+	height int
+}
+
+// newFeedInfo returns a new FeedInfo popup with the given rows of labels and values
+func newFeedInfo(colors *theme.Colors, title string, rows [][2]string) *FeedInfo {
+	labelWidth := 0
+	for _, row := range rows {
+		if len(row[0]) > labelWidth {
+			labelWidth = len(row[0])
+		}
+	}
+
+	label := lipgloss.NewStyle().Foreground(colors.Color2).Width(labelWidth + 2)
+	value := lipgloss.NewStyle().Foreground(colors.Text)
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = label.Render(row[0]) + value.Render(row[1])
+	}
+
+	text := strings.Join(lines, "\n")
+	width := lipgloss.Width(text) + 8
+	if titleWidth := lipgloss.Width(title) + 8; width < titleWidth {
+		width = titleWidth
+	}
+
+	height := len(lines) + 4
+	return &FeedInfo{
+		border: popup.NewTitleBorder(title, width, height, colors.Color1, lipgloss.NormalBorder()),
+		box:    lipgloss.NewStyle().Margin(1, 2, 1, 4),
+		text:   text,
+		width:  width,
+		height: height,
+	}
+}
+
+// GetSize returns the size of the popup.
+func (f FeedInfo) GetSize() (width int, height int) {
+	return f.width, f.height
+}
+
+// Init initializes the popup.
+func (f FeedInfo) Init() tea.Cmd {
+	return nil
+}
+
+// Update updates the popup, any key closes it.
+func (f FeedInfo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		return f, func() tea.Msg { return closeFeedInfoMsg{} }
+	}
+
+	return f, nil
+}
+
+// View renders the popup.
+func (f FeedInfo) View() string {
+	return f.border.Render(f.box.Render(f.text))
+}
+
+// showFeedInfo shows the url of a feed along with the duration and the size of its recent fetches
+func (m Model) showFeedInfo(name string) (tea.Model, tea.Cmd) {
+	feed, err := m.backend.Rss.GetFeed(name)
+	if err != nil {
+		errMsg := fmt.Sprintf("Error showing the info of %s: %s", name, unwrapErrs(err))
+		return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+	}
+
+	rows := [][2]string{
+		{"URL", feed.URL},
+		{"Articles", fmt.Sprintf("%d cached", len(m.backend.Cache.GetCachedArticles(feed.URL)))},
+	}
+
+	stats, ok := m.backend.Cache.FetchStats(feed.URL)
+	if !ok {
+		rows = append(rows, [2]string{"Last fetch", "Not fetched yet"})
+		return m.showPopup(newFeedInfo(m.style.colors, name, rows))
+	}
+
+	rows = append(rows,
+		[2]string{"Last fetch", fmt.Sprintf("%s, %s, %s", stats.Last.Time.Format("2006-01-02 15:04"),
+			stats.Last.Duration.Round(time.Millisecond), formatSize(stats.Last.Size))},
+		[2]string{"Average", fmt.Sprintf("%s, %s over the last %d fetches",
+			stats.AvgDuration.Round(time.Millisecond), formatSize(stats.AvgSize), stats.Fetches)},
+	)
+
+	return m.showPopup(newFeedInfo(m.style.colors, name, rows))
+}
+
+// formatSize formats a size in bytes using the binary units
+func formatSize(size int) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
@@ -0,0 +1,28 @@
+package browser
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// toggleFocus toggles the focus mode, which hides the tab bar and the status bar so that the active
+// tab gets the whole screen
+func (m Model) toggleFocus() (tea.Model, tea.Cmd) {
+	m.focused = !m.focused
+	m.msg = ""
+
+	for i := range m.tabs {
+		m.tabs[i] = m.tabs[i].SetSize(m.width, m.tabHeight())
+	}
+
+	// The cached frame has the old layout
+	m.throttle.invalidate()
+	return m, nil
+}
+
+// tabHeight returns the height the tabs are sized to, the bars take up three lines unless the focus
+// mode hides them
+func (m Model) tabHeight() int {
+	if m.focused {
+		return m.height - 2
+	}
+
+	return m.height - 5
+}
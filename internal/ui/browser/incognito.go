@@ -0,0 +1,28 @@
+package browser
+
+import (
+	"log"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleIncognito toggles the incognito mode, in which reading doesn't change the read status, the reading
+// positions, the saved articles or the history of opened feeds
+func (m Model) toggleIncognito() (tea.Model, tea.Cmd) {
+	m.incognito = !m.incognito
+
+	var cmd tea.Cmd
+	if m.incognito {
+		cmd = m.setMsg("Incognito mode enabled, nothing you read is remembered")
+	} else {
+		cmd = m.setMsg("Incognito mode disabled")
+	}
+
+	log.Println(m.msg)
+	return m, cmd
+}
+
+// refuseIncognito tells the user that an action which changes the reading state isn't done in incognito mode
+func (m Model) refuseIncognito(action string) (tea.Model, tea.Cmd) {
+	return m, m.setMsg("Can't " + action + " in incognito mode")
+}
@@ -5,10 +5,23 @@ import "github.com/charmbracelet/bubbles/key"
 // Keymap contains the key bindings for the browser
 type Keymap struct {
 	CloseTab          key.Binding
+	CloseOtherTabs    key.Binding
+	GoHome            key.Binding
 	NextTab           key.Binding
 	PrevTab           key.Binding
+	JumpToTab         key.Binding
 	ShowHelp          key.Binding
+	SearchAll         key.Binding
+	JumpToFeed        key.Binding
+	RecentFeeds       key.Binding
+	SwitchProfile     key.Binding
 	ToggleOfflineMode key.Binding
+	ToggleIncognito   key.Binding
+	MarkOlderAsRead   key.Binding
+	ReloadColors      key.Binding
+	ToggleFocus       key.Binding
+	ReloadFeeds       key.Binding
+	Undo              key.Binding
 }
 
 // DefaultKeymap contains the default key bindings for the browser
@@ -17,6 +30,14 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("c", "ctrl+w"),
 		key.WithHelp("c", "Close tab"),
 	),
+	CloseOtherTabs: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "Close other tabs"),
+	),
+	GoHome: key.NewBinding(
+		key.WithKeys("home"),
+		key.WithHelp("Home", "Go to welcome"),
+	),
 	NextTab: key.NewBinding(
 		key.WithKeys("tab"),
 		key.WithHelp("Tab", "Next tab"),
@@ -25,21 +46,78 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("shift+tab"),
 		key.WithHelp("Shift+Tab", "Previous tab"),
 	),
+	JumpToTab: key.NewBinding(
+		key.WithKeys("alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9", "alt+0"),
+		key.WithHelp("alt+1-0", "Jump to tab"),
+	),
 	ShowHelp: key.NewBinding(
 		key.WithKeys("h", "ctrl+h"),
 		key.WithHelp("h", "Help"),
 	),
+	SearchAll: key.NewBinding(
+		key.WithKeys("ctrl+f"),
+		key.WithHelp("ctrl+f", "Search all feeds"),
+	),
+	JumpToFeed: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("ctrl+g", "Jump to feed"),
+	),
+	RecentFeeds: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "Recent feeds"),
+	),
+	SwitchProfile: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "Switch profile"),
+	),
 	ToggleOfflineMode: key.NewBinding(
 		key.WithKeys("o", "ctrl+o"),
 		key.WithHelp("o", "Offline mode"),
 	),
+	ToggleIncognito: key.NewBinding(
+		key.WithKeys("I"),
+		key.WithHelp("I", "Incognito mode"),
+	),
+	MarkOlderAsRead: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "Mark older as read"),
+	),
+	ReloadColors: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("ctrl+t", "Reload colorscheme"),
+	),
+	ToggleFocus: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "Focus mode"),
+	),
+	ReloadFeeds: key.NewBinding(
+		key.WithKeys("ctrl+l"),
+		key.WithHelp("ctrl+l", "Reload feeds"),
+	),
+	Undo: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "Undo deletion"),
+	),
 }
 
 // SetEnabled allows to disable/enable shortcuts
 func (k *Keymap) SetEnabled(enabled bool) {
 	k.CloseTab.SetEnabled(enabled)
+	k.CloseOtherTabs.SetEnabled(enabled)
+	k.GoHome.SetEnabled(enabled)
 	k.NextTab.SetEnabled(enabled)
 	k.PrevTab.SetEnabled(enabled)
+	k.JumpToTab.SetEnabled(enabled)
 	k.ShowHelp.SetEnabled(enabled)
+	k.SearchAll.SetEnabled(enabled)
+	k.JumpToFeed.SetEnabled(enabled)
+	k.RecentFeeds.SetEnabled(enabled)
+	k.SwitchProfile.SetEnabled(enabled)
 	k.ToggleOfflineMode.SetEnabled(enabled)
+	k.ToggleIncognito.SetEnabled(enabled)
+	k.MarkOlderAsRead.SetEnabled(enabled)
+	k.ReloadColors.SetEnabled(enabled)
+	k.ToggleFocus.SetEnabled(enabled)
+	k.ReloadFeeds.SetEnabled(enabled)
+	k.Undo.SetEnabled(enabled)
 }
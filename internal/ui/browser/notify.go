@@ -0,0 +1,72 @@
+package browser
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// notifyNew schedules the next background refresh and tells the user about the new articles of the last
+// one, a single message covers all the updated feeds so that a big refresh doesn't flood the status bar.
+// The alerts take precedence over the new articles.
+func (m Model) notifyNew(msg backend.BackgroundRefreshMsg) (tea.Model, tea.Cmd) {
+	m.updateCounts()
+	next := m.backend.RefreshInBackground()
+	if len(msg.Alerts) > 0 {
+		return m.notifyAlerts(msg.Alerts, next)
+	}
+
+	if len(msg.Updates) == 0 || !m.options.NotifyNew {
+		return m, next
+	}
+
+	total := 0
+	for _, update := range msg.Updates {
+		total += update.New
+		log.Println("Background refresh found", update.New, "new articles in", update.Name)
+	}
+
+	text := fmt.Sprintf("%d new in %s", msg.Updates[0].New, msg.Updates[0].Name)
+	if others := len(msg.Updates) - 1; others == 1 {
+		text = fmt.Sprintf("%d new in %s and %s", total, msg.Updates[0].Name, msg.Updates[1].Name)
+	} else if others > 1 {
+		text = fmt.Sprintf("%d new in %s and %d other feeds", total, msg.Updates[0].Name, others)
+	}
+
+	cmds := []tea.Cmd{next, m.setMsg(text)}
+	if m.options.NotifyBell {
+		cmds = append(cmds, ringBell)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// notifyAlerts tells the user about the new articles with an alert word, the alerts are shown even if the
+// new articles aren't since the alert words are set on purpose
+func (m Model) notifyAlerts(alerts []backend.Alert, next tea.Cmd) (tea.Model, tea.Cmd) {
+	for _, alert := range alerts {
+		log.Println("Alert for", alert.Keyword, "in", alert.Feed, "-", alert.Title)
+	}
+
+	first := alerts[0]
+	text := fmt.Sprintf("%s %q in %s: %s", backend.AlertMark, first.Keyword, first.Feed, first.Title)
+	if len(alerts) > 1 {
+		text += fmt.Sprintf(" (and %d more alerts)", len(alerts)-1)
+	}
+
+	cmds := []tea.Cmd{next, m.setMsg(text)}
+	if m.options.NotifyBell {
+		cmds = append(cmds, ringBell)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// ringBell rings the terminal bell, it goes to stderr so that it doesn't end up in the middle of a frame
+func ringBell() tea.Msg {
+	_, _ = fmt.Fprint(os.Stderr, "\a")
+	return nil
+}
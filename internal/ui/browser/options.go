@@ -0,0 +1,85 @@
+package browser
+
+import "time"
+
+// LastTabAction is what happens when the last tab is closed
+type LastTabAction string
+
+const (
+	// LastTabQuit quits the app
+	LastTabQuit LastTabAction = "quit"
+	// LastTabWelcome replaces the last tab with a fresh welcome tab
+	LastTabWelcome LastTabAction = "welcome"
+)
+
+// LastTabActions contains all the available last tab actions
+var LastTabActions = []LastTabAction{LastTabQuit, LastTabWelcome}
+
+// TabOverflow is what happens when a tab is opened after reaching the tab limit
+type TabOverflow string
+
+const (
+	// OverflowCloseOldest closes the least recently used tab
+	OverflowCloseOldest TabOverflow = "close_oldest"
+	// OverflowRefuse doesn't open the new tab
+	OverflowRefuse TabOverflow = "refuse"
+)
+
+// TabOverflows contains all the available tab overflow behaviours
+var TabOverflows = []TabOverflow{OverflowCloseOldest, OverflowRefuse}
+
+// Options contains the behaviour settings for the browser
+type Options struct {
+	ShowCounts      bool          `yaml:"show_counts"`
+	MessageTimeout  time.Duration `yaml:"message_timeout"`
+	DefaultCategory string        `yaml:"default_category"`
+	TabTitleWidth   int           `yaml:"tab_title_width"`
+	UnreadThreshold int           `yaml:"unread_threshold"`
+	EscClosesTab    bool          `yaml:"esc_closes_tab"`
+	EscQuits        bool          `yaml:"esc_quits"`
+	LastTabAction   LastTabAction `yaml:"last_tab_action"`
+	// ReuseTabs switches to the open tab instead of opening the same feed or category twice
+	ReuseTabs bool `yaml:"reuse_tabs"`
+	// MaxTabs is the maximum number of open tabs, 0 doesn't limit them
+	MaxTabs     int         `yaml:"max_tabs"`
+	TabOverflow TabOverflow `yaml:"tab_overflow"`
+	// Ellipsis marks the truncated text everywhere in the interface
+	Ellipsis string `yaml:"ellipsis"`
+	// OpenOnLaunch contains the names or urls of the feeds which are opened in tabs on startup
+	OpenOnLaunch []string `yaml:"open_on_launch"`
+	// SaveDelay is how long after a change the state is saved in the background, 0 only saves on exit
+	SaveDelay time.Duration `yaml:"save_delay"`
+	// MinWidth and MinHeight are the smallest terminal size the interface is drawn in, a smaller
+	// terminal shows a warning instead, 0 doesn't limit the size
+	MinWidth  int `yaml:"min_width"`
+	MinHeight int `yaml:"min_height"`
+	// HistoryLength is how many recently opened feeds are remembered, 0 doesn't remember any
+	HistoryLength int `yaml:"history_length"`
+	// NotifyNew shows a message when a background refresh finds new articles, NotifyBell also rings
+	// the terminal bell
+	NotifyNew  bool `yaml:"notify_new"`
+	NotifyBell bool `yaml:"notify_bell"`
+}
+
+// DefaultOptions contains the default settings for the browser
+var DefaultOptions = Options{
+	ShowCounts:      false,
+	MessageTimeout:  5 * time.Second,
+	DefaultCategory: "",
+	TabTitleWidth:   12,
+	UnreadThreshold: 50,
+	EscClosesTab:    true,
+	EscQuits:        true,
+	LastTabAction:   LastTabQuit,
+	ReuseTabs:       true,
+	MaxTabs:         0,
+	TabOverflow:     OverflowCloseOldest,
+	Ellipsis:        "…",
+	OpenOnLaunch:    []string{},
+	SaveDelay:       2 * time.Second,
+	MinWidth:        40,
+	MinHeight:       12,
+	HistoryLength:   10,
+	NotifyNew:       true,
+	NotifyBell:      false,
+}
@@ -0,0 +1,100 @@
+package browser
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/ui/popup/lollypops"
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/TypicalAM/goread/internal/ui/tab/category"
+	"github.com/TypicalAM/goread/internal/ui/tab/feed"
+	"github.com/TypicalAM/goread/internal/ui/tab/overview"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// askReloadFeeds reloads the urls file, asking first if the changes made in the app weren't saved yet
+// since the reload would throw them away
+func (m Model) askReloadFeeds() (tea.Model, tea.Cmd) {
+	if !m.backend.Rss.Modified() {
+		return m.reloadFeeds()
+	}
+
+	m.keymap.SetEnabled(false)
+	m.pendingChoice = choiceReloadFeeds
+	question := "Discard the unsaved changes to the feeds and reload them?"
+	return m.showPopup(lollypops.NewChoice(m.style.colors, question, false))
+}
+
+// reloadFeeds reads the urls file from the disk again. The tabs of the categories and the feeds which
+// were removed from the file are closed, the welcome tab and the category tabs are refreshed.
+func (m Model) reloadFeeds() (tea.Model, tea.Cmd) {
+	old := *m.backend.Rss
+	if err := m.backend.ReloadFeeds(); err != nil {
+		errMsg := fmt.Sprintf("Error reloading the feeds: %s", unwrapErrs(err))
+		return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+	}
+
+	kept := make([]tab.Tab, 0, len(m.tabs))
+	active := 0
+	for i, t := range m.tabs {
+		if shows(&old, t) && !shows(m.backend.Rss, t) {
+			continue
+		}
+
+		// The active tab is kept or the tab before it becomes active
+		if i <= m.activeTab {
+			active = len(kept)
+		}
+
+		kept = append(kept, m.refreshListTab(t))
+	}
+
+	closed := len(m.tabs) - len(kept)
+	if len(kept) == 0 {
+		kept = append(kept, m.refreshListTab(m.newWelcomeTab()))
+	}
+
+	m.tabs = kept
+	m.activeTab = active
+	m.updateCounts()
+	text := fmt.Sprintf("Reloaded %d categories from the urls file", len(m.backend.Rss.Categories))
+	if closed > 0 {
+		text += fmt.Sprintf(", closed %d tabs of the removed feeds", closed)
+	}
+
+	log.Println(text)
+	return m, m.setMsg(text)
+}
+
+// shows checks if the category, the combination or the feed shown by a tab is in the feeds, the other
+// tabs like the search results don't belong to the feeds
+func shows(feeds *rss.Rss, t tab.Tab) bool {
+	switch t.(type) {
+	case category.Model:
+		_, err := feeds.GetFeeds(t.Title())
+		return err == nil
+
+	case feed.Model:
+		if _, err := feeds.GetFeed(t.Title()); err == nil {
+			return true
+		}
+
+		_, err := feeds.GetCombination(t.Title())
+		return err == nil
+	}
+
+	return false
+}
+
+// refreshListTab loads the categories or the feeds of the welcome and the category tabs again, their
+// fetchers only read the feeds in memory so the result is handed to the tab right away
+func (m Model) refreshListTab(t tab.Tab) tab.Tab {
+	switch t.(type) {
+	case overview.Model, category.Model:
+		updated, _ := t.Update(t.Init()())
+		return updated.(tab.Tab)
+	}
+
+	return t
+}
@@ -0,0 +1,54 @@
+package browser
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/TypicalAM/goread/internal/ui/popup/lollypops"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// quit saves everything and quits, if saving fails the error is shown in the status bar and the user
+// can try again or quit without saving. Quitting again while being asked doesn't try to save.
+func (m Model) quit() (Model, tea.Cmd) {
+	if _, showing := m.popup.(lollypops.Choice); showing && m.pendingChoice == choiceRetrySave {
+		return m.quitWithoutSaving()
+	}
+
+	if err := m.backend.Close(m.backend.URLsReadOnly); err != nil {
+		log.Println("Failed to save before quitting:", err)
+		msgCmd := m.setMsg(fmt.Sprintf("Failed to save - %s", unwrapErrs(err)))
+		m.pendingChoice = choiceRetrySave
+		m.keymap.SetEnabled(false)
+		question := "Saving failed, try again? No quits without saving"
+		m, popupCmd := m.showPopup(lollypops.NewChoice(m.style.colors, question, true))
+		return m, tea.Batch(msgCmd, popupCmd)
+	}
+
+	m.closed = true
+	m.quitting = true
+	return m, tea.Quit
+}
+
+// retrySave tries saving again after it failed while quitting, giving up quits without saving
+func (m Model) retrySave(retry bool) (Model, tea.Cmd) {
+	if !retry {
+		return m.quitWithoutSaving()
+	}
+
+	return m.quit()
+}
+
+// quitWithoutSaving quits after the user chose to lose the unsaved changes
+func (m Model) quitWithoutSaving() (Model, tea.Cmd) {
+	log.Println("Quitting without saving")
+	m.closed = true
+	m.quitting = true
+	return m, tea.Quit
+}
+
+// Closed returns true if the browser already took care of closing the backend when quitting, either
+// by saving everything or because the user chose to quit without saving
+func (m Model) Closed() bool {
+	return m.closed
+}
@@ -1,6 +1,8 @@
 package browser
 
 import (
+	"fmt"
+
 	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/TypicalAM/goread/internal/ui/tab"
 	"github.com/charmbracelet/lipgloss"
@@ -8,16 +10,18 @@ import (
 
 // style is the internal style of the browser
 type style struct {
-	colors               *theme.Colors
-	errMsg               lipgloss.Style
-	activeTab            lipgloss.Style
-	activeTabIcon        lipgloss.Style
-	tab                  lipgloss.Style
-	tabIcon              lipgloss.Style
-	tabGap               lipgloss.Style
-	statusBarGap         lipgloss.Style
-	statusBarCell        lipgloss.Style
-	offlineStatusBarCell lipgloss.Style
+	colors                 *theme.Colors
+	errMsg                 lipgloss.Style
+	alertMsg               lipgloss.Style
+	activeTab              lipgloss.Style
+	activeTabIcon          lipgloss.Style
+	tab                    lipgloss.Style
+	tabIcon                lipgloss.Style
+	tabGap                 lipgloss.Style
+	statusBarGap           lipgloss.Style
+	statusBarCell          lipgloss.Style
+	offlineStatusBarCell   lipgloss.Style
+	incognitoStatusBarCell lipgloss.Style
 }
 
 // newStyle creates a new style
@@ -59,21 +63,25 @@ func newStyle(colors *theme.Colors) style {
 		Foreground(colors.BgDark)
 
 	return style{
-		colors:               colors,
-		errMsg:               errMsg,
-		activeTab:            activeTab,
-		activeTabIcon:        activeTabIcon,
-		tab:                  tabStyle,
-		tabIcon:              tabIcon,
-		tabGap:               tabGap,
-		statusBarGap:         statusBarGap,
-		statusBarCell:        statusBarCell,
-		offlineStatusBarCell: statusBarCell.Copy().Background(colors.TextDark),
+		colors:                 colors,
+		errMsg:                 errMsg,
+		alertMsg:               lipgloss.NewStyle().Foreground(colors.Color2).Bold(true),
+		activeTab:              activeTab,
+		activeTabIcon:          activeTabIcon,
+		tab:                    tabStyle,
+		tabIcon:                tabIcon,
+		tabGap:                 tabGap,
+		statusBarGap:           statusBarGap,
+		statusBarCell:          statusBarCell,
+		offlineStatusBarCell:   statusBarCell.Copy().Background(colors.TextDark),
+		incognitoStatusBarCell: statusBarCell.Copy().Background(colors.Color5),
 	}
 }
 
-// attachIcon attaches an icon based on the tab type
-func (s style) attachIcon(tabToStyle tab.Tab, title string, active bool) string {
+// attachIcon attaches an icon based on the tab type, the title is truncated to maxWidth cells, a non-negative
+// number is shown before the title and a non-negative count after it. The count is highlighted if it's
+// above the threshold and dimmed if it's zero.
+func (s style) attachIcon(tabToStyle tab.Tab, title string, maxWidth, number, count, threshold int, active bool) string {
 	var iconStyle, textStyle lipgloss.Style
 	if active {
 		iconStyle, textStyle = s.activeTabIcon, s.activeTab
@@ -81,18 +89,47 @@ func (s style) attachIcon(tabToStyle tab.Tab, title string, active bool) string
 		iconStyle, textStyle = s.tabIcon, s.tab
 	}
 
-	if len(title) > 12 {
-		title = title[:12] + ""
+	title = theme.Truncate(title, maxWidth)
+	if number >= 0 {
+		title = fmt.Sprintf("%d %s", number, title)
 	}
 
 	tabStyle := tabToStyle.Style()
+	if count < 0 {
+		return lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			iconStyle.Foreground(tabStyle.Color).Render(tabStyle.Icon),
+			textStyle.Render(title),
+		)
+	}
+
+	// The count is rendered separately so that it can have its own color
+	countStyle := textStyle.Copy().PaddingLeft(0)
+	if color, ok := s.countColor(count, threshold); ok {
+		countStyle = countStyle.Foreground(color)
+	}
+
 	return lipgloss.JoinHorizontal(
 		lipgloss.Left,
 		iconStyle.Foreground(tabStyle.Color).Render(tabStyle.Icon),
-		textStyle.Render(title),
+		textStyle.Copy().PaddingRight(0).Render(title+" "),
+		countStyle.Render(fmt.Sprintf("(%d)", count)),
 	)
 }
 
+// countColor returns the color of an unread count, counts between zero and the threshold keep the color
+// of the tab title
+func (s style) countColor(count, threshold int) (lipgloss.Color, bool) {
+	switch {
+	case count == 0:
+		return s.colors.UnreadNone, true
+	case threshold > 0 && count > threshold:
+		return s.colors.UnreadMany, true
+	default:
+		return "", false
+	}
+}
+
 // styleStatusBarCell styles the status bar cell based on the tab type
 func (s style) styleStatusBarCell(tabToStyle tab.Tab, offline bool) string {
 	tabStyle := tabToStyle.Style()
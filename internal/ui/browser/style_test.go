@@ -0,0 +1,29 @@
+package browser
+
+import (
+	"testing"
+
+	"github.com/TypicalAM/goread/internal/theme"
+)
+
+// TestBrowserCountColor if we get an error then the unread counts aren't colored by the threshold
+func TestBrowserCountColor(t *testing.T) {
+	colors := theme.Default
+	s := newStyle(&colors)
+
+	if color, ok := s.countColor(0, 50); !ok || color != colors.UnreadNone {
+		t.Errorf("expected a zero count to be dimmed, got %q", color)
+	}
+
+	if _, ok := s.countColor(10, 50); ok {
+		t.Error("expected a count below the threshold to keep the title color")
+	}
+
+	if color, ok := s.countColor(51, 50); !ok || color != colors.UnreadMany {
+		t.Errorf("expected a count above the threshold to be highlighted, got %q", color)
+	}
+
+	if _, ok := s.countColor(1000, 0); ok {
+		t.Error("expected a zero threshold to disable the highlight")
+	}
+}
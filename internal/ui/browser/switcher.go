@@ -0,0 +1,221 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// switcherResultMsg is the message sent when a feed is chosen in the switcher
+type switcherResultMsg struct{ feed, category string }
+
+// switcherEntry is a feed which can be chosen in the switcher
+type switcherEntry struct {
+	feed     string
+	category string
+}
+
+// switcherEntries fulfills the fuzzy.Source interface
+type switcherEntries []switcherEntry
+
+// String returns the text which is matched against the query
+func (e switcherEntries) String(i int) string {
+	return e[i].feed + " " + e[i].category
+}
+
+// Len returns the number of entries
+func (e switcherEntries) Len() int {
+	return len(e)
+}
+
+// Switcher is a popup which lets the user fuzzy find a feed across all the categories and open it.
+type Switcher struct {
+	border    popup.TitleBorder
+	style     switcherStyle
+	input     textinput.Model
+	entries   switcherEntries
+	matches   []int
+	selected  int
+	width     int
+	height    int
+	shownRows int
+}
+
+// switcherStyle is the style of the switcher
+type switcherStyle struct {
+	box      lipgloss.Style
+	entry    lipgloss.Style
+	selected lipgloss.Style
+	category lipgloss.Style
+	noItems  lipgloss.Style
+}
+
+// allFeeds returns the switcher entries of the feeds from all the categories
+func allFeeds(categories []rss.Category) switcherEntries {
+	var entries switcherEntries
+	for _, cat := range categories {
+		for _, feed := range cat.Subscriptions {
+			entries = append(entries, switcherEntry{feed.Name, cat.Name})
+		}
+	}
+
+	return entries
+}
+
+// recentFeeds returns the switcher entries of the recently opened feeds in the order of the history,
+// the feeds which no longer exist are skipped
+func recentFeeds(categories []rss.Category, history []string) switcherEntries {
+	categoryOf := make(map[string]string)
+	for _, entry := range allFeeds(categories) {
+		if _, ok := categoryOf[entry.feed]; !ok {
+			categoryOf[entry.feed] = entry.category
+		}
+	}
+
+	var entries switcherEntries
+	for _, name := range history {
+		if category, ok := categoryOf[name]; ok {
+			entries = append(entries, switcherEntry{name, category})
+		}
+	}
+
+	return entries
+}
+
+// newSwitcher returns a new Switcher popup with the given feeds.
+func newSwitcher(colors *theme.Colors, title string, entries switcherEntries) Switcher {
+	width := 60
+	height := 18
+
+	input := textinput.New()
+	input.Prompt = "Feed: "
+	input.Width = width - 16
+	input.Focus()
+
+	style := switcherStyle{
+		box:      lipgloss.NewStyle().Margin(1, 2),
+		entry:    lipgloss.NewStyle().Foreground(colors.Text).PaddingLeft(2),
+		selected: lipgloss.NewStyle().Foreground(colors.Color3).Bold(true).PaddingLeft(1),
+		category: lipgloss.NewStyle().Foreground(colors.TextDark),
+		noItems:  lipgloss.NewStyle().Foreground(colors.TextDark).Italic(true).PaddingLeft(2),
+	}
+
+	s := Switcher{
+		border:    popup.NewTitleBorder(title, width, height, colors.Color1, lipgloss.NormalBorder()),
+		style:     style,
+		input:     input,
+		entries:   entries,
+		width:     width,
+		height:    height,
+		shownRows: height - 6,
+	}
+
+	s.filter()
+	return s
+}
+
+// GetSize returns the size of the popup.
+func (s Switcher) GetSize() (width, height int) {
+	return s.width, s.height
+}
+
+// Init initializes the popup.
+func (s Switcher) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles the query input and the selection.
+func (s Switcher) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			if len(s.matches) == 0 {
+				return s, nil
+			}
+
+			entry := s.entries[s.matches[s.selected]]
+			return s, func() tea.Msg { return switcherResultMsg{entry.feed, entry.category} }
+
+		case "up", "ctrl+k":
+			if s.selected > 0 {
+				s.selected--
+			}
+
+			return s, nil
+
+		case "down", "ctrl+j":
+			if s.selected < len(s.matches)-1 {
+				s.selected++
+			}
+
+			return s, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	query := s.input.Value()
+	s.input, cmd = s.input.Update(msg)
+	if s.input.Value() != query {
+		s.filter()
+	}
+
+	return s, cmd
+}
+
+// View renders the popup.
+func (s Switcher) View() string {
+	var b strings.Builder
+	b.WriteString(s.input.View())
+	b.WriteString("\n\n")
+
+	if len(s.matches) == 0 {
+		b.WriteString(s.style.noItems.Render("<no feeds>"))
+	}
+
+	// Scroll the list so that the selection is always visible
+	start := 0
+	if s.selected >= s.shownRows {
+		start = s.selected - s.shownRows + 1
+	}
+
+	for i := start; i < len(s.matches) && i < start+s.shownRows; i++ {
+		entry := s.entries[s.matches[i]]
+		category := s.style.category.Render(fmt.Sprintf(" (%s)", entry.category))
+		if i == s.selected {
+			b.WriteString(s.style.selected.Render("> "+entry.feed) + category)
+		} else {
+			b.WriteString(s.style.entry.Render(entry.feed) + category)
+		}
+
+		b.WriteRune('\n')
+	}
+
+	return s.border.Render(s.style.box.Render(b.String()))
+}
+
+// filter updates the matches using the current query, an empty query matches every feed
+func (s *Switcher) filter() {
+	s.selected = 0
+	query := s.input.Value()
+	if query == "" {
+		s.matches = make([]int, len(s.entries))
+		for i := range s.entries {
+			s.matches[i] = i
+		}
+
+		return
+	}
+
+	found := fuzzy.FindFrom(query, s.entries)
+	s.matches = make([]int, len(found))
+	for i, match := range found {
+		s.matches[i] = match.Index
+	}
+}
@@ -0,0 +1,82 @@
+package browser
+
+import (
+	"fmt"
+
+	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/TypicalAM/goread/internal/ui/tab/overview"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// touchActiveTab marks the active tab as the most recently used one, the tabs which are no longer
+// open are forgotten
+func (m Model) touchActiveTab(prev Model) Model {
+	if len(m.tabs) == 0 || m.options.MaxTabs == 0 {
+		return m
+	}
+
+	// Only recompute the order when the active tab changes, this runs after every message
+	active := m.tabs[m.activeTab]
+	if len(m.recent) > 0 && len(prev.tabs) > 0 && m.activeTab == prev.activeTab && len(m.tabs) == len(prev.tabs) &&
+		active.Title() == prev.tabs[prev.activeTab].Title() {
+		return m
+	}
+
+	open := make(map[string]bool, len(m.tabs))
+	for _, t := range m.tabs {
+		open[m.tabKey(t)] = true
+	}
+
+	activeKey := m.tabKey(active)
+	recent := make([]string, 0, len(m.tabs))
+	for _, key := range m.recent {
+		if open[key] && key != activeKey {
+			recent = append(recent, key)
+		}
+	}
+
+	m.recent = append(recent, activeKey)
+	return m
+}
+
+// evictTab makes room for a new tab if the limit of open tabs is reached, depending on the options
+// the least recently used tab is closed or no tab is closed and false is returned
+func (m Model) evictTab() (Model, tea.Cmd, bool) {
+	if m.options.MaxTabs == 0 || len(m.tabs) < m.options.MaxTabs {
+		return m, nil, true
+	}
+
+	refusal := m.setMsg(fmt.Sprintf("Can't open more than %d tabs, close one first", m.options.MaxTabs))
+	if m.options.TabOverflow == OverflowRefuse {
+		return m, refusal, false
+	}
+
+	used := make(map[string]int, len(m.recent))
+	for i, key := range m.recent {
+		used[key] = i + 1
+	}
+
+	// The welcome tab is kept so that the user can still navigate
+	oldest := -1
+	for i, t := range m.tabs {
+		if _, ok := t.(overview.Model); (ok && i == 0) || i == m.activeTab {
+			continue
+		}
+
+		if oldest == -1 || used[m.tabKey(t)] < used[m.tabKey(m.tabs[oldest])] {
+			oldest = i
+		}
+	}
+
+	if oldest == -1 {
+		return m, refusal, false
+	}
+
+	closed := m.tabs[oldest].Title()
+	m.tabs = append(append([]tab.Tab{}, m.tabs[:oldest]...), m.tabs[oldest+1:]...)
+	if oldest < m.activeTab {
+		m.activeTab--
+	}
+
+	return m, m.setMsg(fmt.Sprintf("Closed tab - %s", closed)), true
+}
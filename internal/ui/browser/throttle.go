@@ -0,0 +1,63 @@
+package browser
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// frameInterval is the shortest time between two renders of the browser
+const frameInterval = time.Second / 60
+
+// redrawMsg is sent when the view skipped by the throttle has to be rendered
+type redrawMsg struct{}
+
+// throttle caches the rendered view so that a flood of messages doesn't render it after every single
+// one, it is shared between the copies of the model
+type throttle struct {
+	interval  time.Duration
+	now       func() time.Time
+	view      string
+	rendered  time.Time
+	valid     bool
+	scheduled bool
+}
+
+// newThrottle creates a throttle which renders at most once per interval
+func newThrottle(interval time.Duration) *throttle {
+	return &throttle{interval: interval, now: time.Now}
+}
+
+// render returns the cached view if it was rendered less than an interval ago and renders it otherwise
+func (t *throttle) render(view func() string) string {
+	now := t.now()
+	if t.valid && now.Sub(t.rendered) < t.interval {
+		return t.view
+	}
+
+	t.view = view()
+	t.rendered = now
+	t.valid = true
+	return t.view
+}
+
+// schedule returns a command which redraws the view once the interval passes, only if the next render
+// is going to be skipped and there isn't a redraw pending already
+func (t *throttle) schedule() tea.Cmd {
+	if t.scheduled || !t.valid || t.now().Sub(t.rendered) >= t.interval {
+		return nil
+	}
+
+	t.scheduled = true
+	return tea.Tick(t.interval, func(time.Time) tea.Msg { return redrawMsg{} })
+}
+
+// redrawn marks the pending redraw as done
+func (t *throttle) redrawn() {
+	t.scheduled = false
+}
+
+// invalidate makes the next render skip the cache, used when a stale frame would look broken
+func (t *throttle) invalidate() {
+	t.valid = false
+}
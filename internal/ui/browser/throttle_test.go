@@ -0,0 +1,77 @@
+package browser
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock which only moves when told to
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+// TestBrowserThrottle if we get an error then the view is rendered too often or a change is never shown
+func TestBrowserThrottle(t *testing.T) {
+	clock := &fakeClock{time.Now()}
+	th := newThrottle(frameInterval)
+	th.now = clock.Now
+
+	renders := 0
+	view := func() string {
+		renders++
+		return "view"
+	}
+
+	th.render(view)
+	clock.now = clock.now.Add(frameInterval / 4)
+	if cmd := th.schedule(); cmd == nil {
+		t.Fatal("expected a redraw to be scheduled for the skipped render")
+	}
+
+	if cmd := th.schedule(); cmd != nil {
+		t.Error("expected only one redraw to be pending")
+	}
+
+	th.render(view)
+	if renders != 1 {
+		t.Errorf("expected the render within the frame to be skipped, rendered %d times", renders)
+	}
+
+	clock.now = clock.now.Add(frameInterval)
+	th.redrawn()
+	th.render(view)
+	if renders != 2 {
+		t.Errorf("expected the view to be rendered after the frame, rendered %d times", renders)
+	}
+
+	th.invalidate()
+	th.render(view)
+	if renders != 3 {
+		t.Errorf("expected the invalidated view to be rendered, rendered %d times", renders)
+	}
+}
+
+// BenchmarkBrowserThrottle renders the view after each message of a flood of 1000 messages arriving
+// within a second, like the bubbletea program does
+func BenchmarkBrowserThrottle(b *testing.B) {
+	const messages = 1000
+	clock := &fakeClock{time.Now()}
+	renders := 0
+	view := func() string {
+		renders++
+		return "view"
+	}
+
+	for i := 0; i < b.N; i++ {
+		th := newThrottle(frameInterval)
+		th.now = clock.Now
+		for j := 0; j < messages; j++ {
+			clock.now = clock.now.Add(time.Millisecond)
+			th.schedule()
+			th.render(view)
+		}
+	}
+
+	b.ReportMetric(float64(renders)/float64(b.N), "renders/op")
+	b.ReportMetric(messages, "messages/op")
+}
@@ -0,0 +1,52 @@
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/TypicalAM/goread/internal/backend/rss"
+	"github.com/TypicalAM/goread/internal/ui/popup/lollypops"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// askUndo asks before undoing the last destructive operation, naming it and when it happened since
+// undoing brings back the feeds as they were at that time
+func (m Model) askUndo() (tea.Model, tea.Cmd) {
+	if !m.backend.Capabilities().EditFeeds {
+		return m, nil
+	}
+
+	operation, at, ok := m.backend.Rss.LastCheckpoint()
+	if !ok {
+		return m, m.setMsg("Nothing to undo")
+	}
+
+	m.keymap.SetEnabled(false)
+	m.pendingChoice = choiceUndo
+	question := fmt.Sprintf("Undo \"%s\" from %s?", operation, at.Format("15:04"))
+	return m.showPopup(lollypops.NewChoice(m.style.colors, question, false))
+}
+
+// undo restores the feeds from before the last destructive operation and refreshes the welcome and the
+// category tabs
+func (m Model) undo() (tea.Model, tea.Cmd) {
+	operation, err := m.backend.Rss.Undo()
+	if errors.Is(err, rss.ErrNothingToUndo) {
+		return m, m.setMsg("Nothing to undo")
+	}
+
+	if err != nil {
+		errMsg := fmt.Sprintf("Error undoing the last operation: %s", unwrapErrs(err))
+		return m.showPopup(lollypops.NewError(m.style.colors, errMsg))
+	}
+
+	for i, t := range m.tabs {
+		m.tabs[i] = m.refreshListTab(t)
+	}
+
+	m.updateCounts()
+	text := fmt.Sprintf("Undid: %s", operation)
+	log.Println(text)
+	return m, m.setMsg(text)
+}
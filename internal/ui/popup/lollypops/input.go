@@ -0,0 +1,78 @@
+package lollypops
+
+import (
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// InputResultMsg is the message sent when the input is confirmed.
+type InputResultMsg struct {
+	Value string
+}
+
+// Input is a popup that asks the user for a single line of text.
+type Input struct {
+	style  inputStyle
+	input  textinput.Model
+	width  int
+	height int
+}
+
+// NewInput creates a new Input popup.
+func NewInput(colors *theme.Colors, title, prompt string) Input {
+	width := 50
+	height := 7
+
+	input := textinput.New()
+	input.Prompt = prompt
+	input.Width = width - len(prompt) - 8
+	input.Focus()
+
+	return Input{
+		style:  newInputStyle(colors, width, height, title),
+		input:  input,
+		width:  width,
+		height: height,
+	}
+}
+
+// WithValue fills the input with a value which can be edited.
+func (i Input) WithValue(value string) Input {
+	i.input.SetValue(value)
+	return i
+}
+
+// Init initializes the popup.
+func (i Input) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages.
+func (i Input) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "enter" {
+		return i, i.confirm()
+	}
+
+	var cmd tea.Cmd
+	i.input, cmd = i.input.Update(msg)
+	return i, cmd
+}
+
+// View renders the popup.
+func (i Input) View() string {
+	dialog := lipgloss.Place(i.width-2, i.height-2, lipgloss.Center, lipgloss.Center, i.style.input.Render(i.input.View()))
+	return i.style.border.Render(dialog)
+}
+
+// GetSize returns the size of the popup.
+func (i Input) GetSize() (width, height int) {
+	return i.width, i.height
+}
+
+// confirm returns a tea.Cmd that tells the parent model about the input.
+func (i Input) confirm() tea.Cmd {
+	value := i.input.Value()
+	return func() tea.Msg { return InputResultMsg{value} }
+}
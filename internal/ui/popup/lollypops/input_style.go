@@ -0,0 +1,25 @@
+package lollypops
+
+import (
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/TypicalAM/goread/internal/ui/popup"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// inputStyle is the style of the input popup
+type inputStyle struct {
+	border popup.TitleBorder
+	input  lipgloss.Style
+}
+
+// newInputStyle creates a new style for the input popup
+func newInputStyle(colors *theme.Colors, width, height int, title string) inputStyle {
+	input := lipgloss.NewStyle().
+		Width(width - 6).
+		Foreground(colors.Text)
+
+	return inputStyle{
+		border: popup.NewTitleBorder(title, width, height, colors.Color1, lipgloss.NormalBorder()),
+		input:  input,
+	}
+}
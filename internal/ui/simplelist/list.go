@@ -13,8 +13,11 @@ import (
 
 // Item is an item in the list
 type Item struct {
-	title string
-	desc  string
+	title  string
+	desc   string
+	color  lipgloss.Color
+	dimmed bool
+	pinned bool
 }
 
 // NewItem creates a new item
@@ -25,6 +28,25 @@ func NewItem(title, desc string) Item {
 	}
 }
 
+// Dim returns a copy of the item which is rendered dimmed
+func (i Item) Dim() Item {
+	i.dimmed = true
+	return i
+}
+
+// Pin returns a copy of the item which is rendered with a pin icon
+func (i Item) Pin() Item {
+	i.pinned = true
+	return i
+}
+
+// WithColor returns a copy of the item which is rendered with a marker in the given color, an empty
+// color leaves the item without a marker
+func (i Item) WithColor(color string) Item {
+	i.color = lipgloss.Color(color)
+	return i
+}
+
 // Title returns the title of the item
 func (i Item) Title() string {
 	return i.title
@@ -48,7 +70,7 @@ type Model struct {
 	title        string
 	items        []list.Item
 	height       int
-	page         int
+	offset       int
 	itemsPerPage int
 	selected     int
 	showDesc     bool
@@ -88,33 +110,22 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.selected--
 			if m.selected < 0 {
 				m.selected = len(m.items) - 1
-				m.page = len(m.items) / m.itemsPerPage
-			}
-
-			// Check if the page needs to be changed
-			if m.selected < m.page*m.itemsPerPage {
-				m.page--
 			}
 
 		case key.Matches(msg, m.Keymap.Down):
 			m.selected++
 			if m.selected >= len(m.items) {
 				m.selected = 0
-				m.page = 0
-			}
-
-			if m.selected >= (m.page+1)*m.itemsPerPage {
-				m.page++
 			}
 
 		case key.Matches(msg, m.Keymap.PageUp):
 			m.selected = 0
-			m.page = 0
 
 		case key.Matches(msg, m.Keymap.PageDown):
 			m.selected = len(m.items) - 1
-			m.page = len(m.items) / m.itemsPerPage
 		}
+
+		m.scroll()
 	}
 
 	return m, nil
@@ -134,12 +145,30 @@ func (m Model) View() string {
 		return b.String()
 	}
 
-	for i := m.itemsPerPage * m.page; i < m.itemsPerPage*(m.page+1); i++ {
-		if i >= len(m.items) {
-			break
+	// Only the visible rows are rendered, a collection can have hundreds of feeds
+	end := m.offset + m.rows()
+	if end > len(m.items) {
+		end = len(m.items)
+	}
+
+	for i := m.offset; i < end; i++ {
+		itemStyle := m.style.itemStyle
+		item, isItem := m.items[i].(Item)
+		if isItem && item.dimmed {
+			itemStyle = m.style.dimmedItemStyle
+		}
+
+		b.WriteString(m.style.styleIndex(i, i == m.selected))
+		if isItem && item.color != "" {
+			b.WriteString(m.style.markerStyle.Copy().Foreground(item.color).String())
+			itemStyle = itemStyle.Copy().MarginLeft(1)
+		}
+
+		b.WriteString(itemStyle.Render(m.items[i].FilterValue()))
+		if isItem && item.pinned {
+			b.WriteString(m.style.pinStyle.String())
 		}
 
-		b.WriteString(m.style.styleIndex(i, i == m.selected) + m.style.itemStyle.Render(m.items[i].FilterValue()))
 		b.WriteRune('\n')
 
 		if m.showDesc {
@@ -166,6 +195,41 @@ func (m *Model) SetHeight(height int) {
 	}
 
 	m.height = height
+	m.scroll()
+}
+
+// rows returns the number of items which fit in the list, there is room for at least one
+func (m Model) rows() int {
+	if m.itemsPerPage < 1 {
+		return 1
+	}
+
+	return m.itemsPerPage
+}
+
+// scroll moves the window of the visible items as little as possible to show the selected item, the
+// window is kept full if the list got shorter
+func (m *Model) scroll() {
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+
+	if m.selected >= m.offset+m.rows() {
+		m.offset = m.selected - m.rows() + 1
+	}
+
+	if last := len(m.items) - m.rows(); m.offset > last {
+		m.offset = last
+	}
+
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+// Restyle rebuilds the style of the list from its colors
+func (m *Model) Restyle() {
+	m.style = newListStyle(m.colors)
 }
 
 // Items returns the items in the list
@@ -173,9 +237,18 @@ func (m Model) Items() []list.Item {
 	return m.items
 }
 
-// SetItems sets the items in the list
+// SetItems sets the items in the list, the selection is moved up if the list got shorter than it
 func (m *Model) SetItems(items []list.Item) {
 	m.items = items
+	if m.selected >= len(items) {
+		m.selected = len(items) - 1
+	}
+
+	if m.selected < 0 {
+		m.selected = 0
+	}
+
+	m.scroll()
 }
 
 // IsEmpty checks if the list is empty
@@ -210,6 +283,7 @@ func (m Model) Index() int {
 // SetIndex sets the index of the selected item
 func (m *Model) SetIndex(index int) {
 	m.selected = index
+	m.scroll()
 }
 
 // ShortHelp returns the short help for the list
@@ -0,0 +1,76 @@
+package simplelist
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/TypicalAM/goread/internal/theme"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestList creates a list of count feeds which shows rows items at a time
+func newTestList(count, rows int) Model {
+	colors := theme.Default
+	m := New(&colors, "Feeds", rows+2, false)
+	items := make([]list.Item, count)
+	for i := range items {
+		items[i] = NewItem(fmt.Sprintf("Feed %04d", i), "https://example.com")
+	}
+
+	m.SetItems(items)
+	return m
+}
+
+// TestSimplelistScroll if we get an error then the selected item isn't visible or more than the visible
+// items are rendered
+func TestSimplelistScroll(t *testing.T) {
+	m := newTestList(1000, 10)
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	for i := 0; i < 15; i++ {
+		m, _ = m.Update(down)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "Feed 0015") || !strings.Contains(view, "Feed 0006") {
+		t.Errorf("expected the window to end at the selected item, got %q", view)
+	}
+
+	if strings.Contains(view, "Feed 0005") || strings.Contains(view, "Feed 0016") {
+		t.Errorf("expected only ten items to be rendered, got %q", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if view = m.View(); !strings.Contains(view, "Feed 0006") {
+		t.Errorf("expected the window to stay put while the selection is in it, got %q", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("J")})
+	if view = m.View(); m.Index() != 999 || !strings.Contains(view, "Feed 0999") {
+		t.Errorf("expected the last item to be selected and shown, got %d", m.Index())
+	}
+
+	m, _ = m.Update(down)
+	if view = m.View(); m.Index() != 0 || !strings.Contains(view, "Feed 0000") {
+		t.Errorf("expected the selection to wrap around to the first item, got %d", m.Index())
+	}
+
+	m.SetIndex(500)
+	m.SetItems(m.Items()[:20])
+	if view = m.View(); m.Index() != 19 || !strings.Contains(view, "Feed 0010") {
+		t.Errorf("expected a full window at the end of the shorter list, got %q", view)
+	}
+}
+
+// BenchmarkSimplelistView renders a collection of 1000 feeds while moving through it
+func BenchmarkSimplelistView(b *testing.B) {
+	m := newTestList(1000, 40)
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, _ = m.Update(down)
+		_ = m.View()
+	}
+}
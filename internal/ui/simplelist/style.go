@@ -10,13 +10,19 @@ import (
 
 // listStyle is the style of the list.
 type listStyle struct {
-	colors       *theme.Colors
-	titleStyle   lipgloss.Style
-	noItemsStyle lipgloss.Style
-	itemStyle    lipgloss.Style
-
-	bracketStyle lipgloss.Style
-	numberStyle  lipgloss.Style
+	colors          *theme.Colors
+	titleStyle      lipgloss.Style
+	noItemsStyle    lipgloss.Style
+	itemStyle       lipgloss.Style
+	dimmedItemStyle lipgloss.Style
+	pinStyle        lipgloss.Style
+	markerStyle     lipgloss.Style
+
+	bracketStyle        lipgloss.Style
+	numberStyle         lipgloss.Style
+	selectedNumberStyle lipgloss.Style
+	arrowStyle          lipgloss.Style
+	descStyle           lipgloss.Style
 }
 
 // newListStyle creates a new listStyle
@@ -35,35 +41,49 @@ func newListStyle(colors *theme.Colors) listStyle {
 		MarginLeft(3).
 		Foreground(colors.Color2)
 
+	pinStyle := lipgloss.NewStyle().
+		MarginLeft(1).
+		Foreground(colors.Color6).
+		SetString("")
+
+	markerStyle := lipgloss.NewStyle().
+		MarginLeft(3).
+		SetString("●")
+
 	bracketStyle := lipgloss.NewStyle().
 		Foreground(colors.Color7)
 
 	numberStyle := lipgloss.NewStyle().
 		Foreground(colors.Color6)
 
+	arrowStyle := lipgloss.NewStyle().
+		MarginLeft(10).
+		Foreground(colors.Color3)
+
+	descStyle := lipgloss.NewStyle().
+		MarginLeft(1).
+		Foreground(colors.Color3)
+
 	return listStyle{
-		colors:       colors,
-		titleStyle:   titleStyle,
-		noItemsStyle: noItemsStyle,
-		itemStyle:    itemStyle,
-		bracketStyle: bracketStyle,
-		numberStyle:  numberStyle,
+		colors:          colors,
+		titleStyle:      titleStyle,
+		noItemsStyle:    noItemsStyle,
+		itemStyle:       itemStyle,
+		dimmedItemStyle: itemStyle.Copy().Foreground(colors.TextDark),
+		pinStyle:        pinStyle,
+		markerStyle:     markerStyle,
+		bracketStyle:    bracketStyle,
+		numberStyle:     numberStyle,
+
+		selectedNumberStyle: numberStyle.Copy().Background(colors.Text),
+		arrowStyle:          arrowStyle,
+		descStyle:           descStyle,
 	}
 }
 
 // styleDescription will style the description of the item
 func (s listStyle) styleDescription(description string) string {
-	// Create the arrow style
-	arrowStyle := lipgloss.NewStyle().
-		MarginLeft(10).
-		Foreground(s.colors.Color3)
-
-	// Create the text style
-	textStyle := lipgloss.NewStyle().
-		MarginLeft(1).
-		Foreground(s.colors.Color3)
-
-	return arrowStyle.Render("⮡") + textStyle.Render(description)
+	return s.arrowStyle.Render("⮡") + s.descStyle.Render(description)
 }
 
 // styleIndex will style the index of the item
@@ -73,9 +93,9 @@ func (s listStyle) styleIndex(index int, isSelected bool) string {
 	b.WriteString(s.bracketStyle.Render("["))
 
 	// If the index is the active index render it differently
-	style := s.numberStyle.Copy()
+	style := s.numberStyle
 	if isSelected {
-		style = style.Background(s.colors.Text)
+		style = s.selectedNumberStyle
 	}
 
 	// Check if the index is a digit
@@ -14,14 +14,15 @@ import (
 
 // Model contains the state of this tab
 type Model struct {
-	colors *theme.Colors
-	reader backend.Fetcher
-	title  string
-	keymap Keymap
-	list   simplelist.Model
-	width  int
-	height int
-	loaded bool
+	colors   *theme.Colors
+	reader   backend.Fetcher
+	title    string
+	keymap   Keymap
+	list     simplelist.Model
+	width    int
+	height   int
+	loaded   bool
+	readOnly bool
 }
 
 // New creates a new category tab with sensible defaults
@@ -64,6 +65,15 @@ func (m Model) SetSize(width, height int) tab.Tab {
 	return m
 }
 
+// Restyle rebuilds the styles of the tab from its colors
+func (m Model) Restyle() tab.Tab {
+	if m.loaded {
+		m.list.Restyle()
+	}
+
+	return m
+}
+
 // Init initializes the tab
 func (m Model) Init() tea.Cmd {
 	return m.reader(m.title)
@@ -83,6 +93,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case backend.SetEnableKeybindMsg:
 		m.keymap.SetEnabled(bool(msg))
+		if m.readOnly {
+			m.keymap.disableEditing()
+		}
+
 		return m, nil
 
 	case lollypops.ChoiceResultMsg:
@@ -90,17 +104,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		delItemName := m.list.SelectedItem().FilterValue()
-		itemCount := len(m.list.Items())
-
-		// Move the selection to the next item
-		if itemCount == 1 {
-			m.list.SetIndex(0)
-		} else {
-			m.list.SetIndex(m.list.Index() % (itemCount - 1))
+		if m.list.IsEmpty() {
+			return m, nil
 		}
 
-		return m, backend.DeleteItem(m, delItemName)
+		// The selection stays in place so that it lands on the next feed, the list moves it up
+		// if the last feed was removed
+		return m, backend.DeleteItem(m, m.list.SelectedItem().FilterValue())
 
 	case tea.KeyMsg:
 		if !m.loaded {
@@ -109,7 +119,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch {
 		case msg.String() == "esc":
-			return m, backend.StartQuitting()
+			return m, backend.Escape()
 
 		case key.Matches(msg, m.list.Keymap.Open):
 			if !m.list.IsEmpty() {
@@ -136,6 +146,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, backend.MakeChoice("Delete this feed?", true)
 			}
 
+		case key.Matches(msg, m.keymap.RenameFeed):
+			if !m.list.IsEmpty() {
+				return m, backend.RenameFeed(m.list.SelectedItem().FilterValue())
+			}
+
+		case key.Matches(msg, m.keymap.ToggleMute):
+			if !m.list.IsEmpty() {
+				return m, backend.ToggleMute(m.list.SelectedItem().FilterValue())
+			}
+
+		case key.Matches(msg, m.keymap.FeedInfo):
+			if !m.list.IsEmpty() {
+				return m, backend.ShowFeedInfo(m.list.SelectedItem().FilterValue())
+			}
+
 		default:
 			if item, ok := m.list.GetItem(msg.String()); ok {
 				return m, tab.NewTab(m, item.FilterValue())
@@ -159,10 +184,31 @@ func (m Model) View() string {
 
 // ShortHelp returns the short help for this tab
 func (m Model) ShortHelp() []key.Binding {
-	return []key.Binding{m.keymap.NewFeed, m.keymap.EditFeed, m.keymap.DeleteFeed}
+	return []key.Binding{m.keymap.NewFeed, m.keymap.EditFeed, m.keymap.DeleteFeed, m.keymap.RenameFeed, m.keymap.ToggleMute, m.keymap.FeedInfo}
 }
 
 // FullHelp returns the full help for this tab
 func (m Model) FullHelp() [][]key.Binding {
 	return [][]key.Binding{m.ShortHelp(), m.list.ShortHelp()}
 }
+
+// Rename shows the category under its new name after it was renamed, the feeds stay listed until the tab
+// is refreshed
+func (m Model) Rename(name string) Model {
+	m.title = name
+	if m.loaded {
+		items, index := m.list.Items(), m.list.Index()
+		m.list = simplelist.New(m.colors, m.title, m.height, false)
+		m.list.SetItems(items)
+		m.list.SetIndex(index)
+	}
+
+	return m
+}
+
+// DisableEditing hides the actions which change the feeds, for the backends which can't save them
+func (m Model) DisableEditing() Model {
+	m.readOnly = true
+	m.keymap.disableEditing()
+	return m
+}
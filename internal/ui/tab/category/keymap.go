@@ -7,6 +7,9 @@ type Keymap struct {
 	NewFeed    key.Binding
 	EditFeed   key.Binding
 	DeleteFeed key.Binding
+	RenameFeed key.Binding
+	ToggleMute key.Binding
+	FeedInfo   key.Binding
 }
 
 // DefaultKeymap contains the default key bindings for this tab
@@ -23,6 +26,18 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("d", "ctrl+d"),
 		key.WithHelp("d/ctrl+d", "Delete"),
 	),
+	RenameFeed: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "Rename"),
+	),
+	ToggleMute: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "Mute"),
+	),
+	FeedInfo: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "Info"),
+	),
 }
 
 // SetEnabled allows to disable/enable shortcuts
@@ -30,4 +45,16 @@ func (m *Keymap) SetEnabled(enabled bool) {
 	m.NewFeed.SetEnabled(enabled)
 	m.EditFeed.SetEnabled(enabled)
 	m.DeleteFeed.SetEnabled(enabled)
+	m.RenameFeed.SetEnabled(enabled)
+	m.ToggleMute.SetEnabled(enabled)
+	m.FeedInfo.SetEnabled(enabled)
+}
+
+// disableEditing disables the shortcuts which change the feeds
+func (m *Keymap) disableEditing() {
+	m.NewFeed.SetEnabled(false)
+	m.EditFeed.SetEnabled(false)
+	m.DeleteFeed.SetEnabled(false)
+	m.RenameFeed.SetEnabled(false)
+	m.ToggleMute.SetEnabled(false)
 }
@@ -13,6 +13,7 @@ type ChosenFeedMsg struct {
 	URL     string
 	OldName string
 	Parent  string
+	Color   string
 	IsEdit  bool
 }
 
@@ -22,26 +23,28 @@ type focusedField int
 const (
 	nameField focusedField = iota
 	urlField
+	colorField
 )
 
 // Popup is the feed popup where a user can create/edit a feed.
 type Popup struct {
-	nameInput textinput.Model
-	urlInput  textinput.Model
-	style     popupStyle
-	oldName   string
-	oldURL    string
-	parent    string
-	focused   focusedField
-	editing   bool
-	width     int
-	height    int
+	nameInput  textinput.Model
+	urlInput   textinput.Model
+	colorInput textinput.Model
+	style      popupStyle
+	oldName    string
+	oldURL     string
+	parent     string
+	focused    focusedField
+	editing    bool
+	width      int
+	height     int
 }
 
 // NewPopup returns a new feed popup.
 func NewPopup(colors *theme.Colors, oldName, oldURL, parent string) Popup {
 	width := 40
-	height := 7
+	height := 8
 
 	editing := oldName != "" || oldURL != ""
 
@@ -53,6 +56,11 @@ func NewPopup(colors *theme.Colors, oldName, oldURL, parent string) Popup {
 	urlInput.CharLimit = 150
 	urlInput.Width = width - 20
 	urlInput.Prompt = "URL: "
+	colorInput := textinput.New()
+	colorInput.CharLimit = 7
+	colorInput.Width = width - 20
+	colorInput.Prompt = "Color: "
+	colorInput.Placeholder = "#rrggbb"
 
 	var style popupStyle
 	if editing {
@@ -69,15 +77,16 @@ func NewPopup(colors *theme.Colors, oldName, oldURL, parent string) Popup {
 	nameInput.Focus()
 
 	return Popup{
-		style:     style,
-		nameInput: nameInput,
-		urlInput:  urlInput,
-		oldName:   oldName,
-		oldURL:    oldURL,
-		parent:    parent,
-		editing:   editing,
-		width:     width,
-		height:    height,
+		style:      style,
+		nameInput:  nameInput,
+		urlInput:   urlInput,
+		colorInput: colorInput,
+		oldName:    oldName,
+		oldURL:     oldURL,
+		parent:     parent,
+		editing:    editing,
+		width:      width,
+		height:     height,
 	}
 }
 
@@ -92,18 +101,11 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	if msg, ok := msg.(tea.KeyMsg); ok {
 		switch msg.String() {
-		case "down", "up", "tab":
-			switch p.focused {
-			case nameField:
-				p.focused = urlField
-				p.nameInput.Blur()
-				cmds = append(cmds, p.urlInput.Focus())
-
-			case urlField:
-				p.focused = nameField
-				p.urlInput.Blur()
-				cmds = append(cmds, p.nameInput.Focus())
-			}
+		case "down", "tab":
+			cmds = append(cmds, p.focus((p.focused+1)%3))
+
+		case "up":
+			cmds = append(cmds, p.focus((p.focused+2)%3))
 
 		case "enter":
 			return p, confirm(
@@ -111,6 +113,7 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				p.urlInput.Value(),
 				p.oldName,
 				p.parent,
+				p.colorInput.Value(),
 				p.oldName != "",
 			)
 		}
@@ -128,6 +131,12 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
+	if p.colorInput.Focused() {
+		var cmd tea.Cmd
+		p.colorInput, cmd = p.colorInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	return p, tea.Batch(cmds...)
 }
 
@@ -143,16 +152,34 @@ func (p Popup) View() string {
 	itemTitle := p.style.itemTitle.Render(itemText)
 	name := p.style.itemField.Render(p.nameInput.View())
 	url := p.style.itemField.Render(p.urlInput.View())
-	listItem := p.style.listItem.Render(lipgloss.JoinVertical(lipgloss.Left, itemTitle, name, url))
+	color := p.style.itemField.Render(p.colorInput.View())
+	listItem := p.style.listItem.Render(lipgloss.JoinVertical(lipgloss.Left, itemTitle, name, url, color))
 	return p.style.border.Render(listItem)
 }
 
+// WithColor returns a copy of the popup with the color of the edited feed filled in.
+func (p Popup) WithColor(color string) Popup {
+	p.colorInput.SetValue(color)
+	return p
+}
+
+// focus moves the focus to the given field.
+func (p *Popup) focus(field focusedField) tea.Cmd {
+	p.focused = field
+	inputs := []*textinput.Model{&p.nameInput, &p.urlInput, &p.colorInput}
+	for _, input := range inputs {
+		input.Blur()
+	}
+
+	return inputs[field].Focus()
+}
+
 // GetSize returns the size of the popup.
 func (p Popup) GetSize() (width, height int) {
 	return p.width, p.height
 }
 
 // confirm creates a message that confirms the user's choice.
-func confirm(name, url, oldName, parent string, edit bool) tea.Cmd {
-	return func() tea.Msg { return ChosenFeedMsg{name, url, oldName, parent, edit} }
+func confirm(name, url, oldName, parent, color string, edit bool) tea.Cmd {
+	return func() tea.Msg { return ChosenFeedMsg{name, url, oldName, parent, color, edit} }
 }
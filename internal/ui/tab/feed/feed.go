@@ -6,11 +6,14 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/TypicalAM/goread/internal/backend/rss"
 	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/TypicalAM/goread/internal/ui/popup/lollypops"
 	"github.com/TypicalAM/goread/internal/ui/tab"
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -21,25 +24,46 @@ import (
 	"github.com/muesli/reflow/wrap"
 )
 
+// sessionModes contains the render modes switched to in the tabs, keyed by the feed name. It is shared
+// between the tabs so that the choice is remembered for the whole session.
+var sessionModes = make(map[string]rss.RenderMode)
+
 // Model contains the state of this tab
 type Model struct {
 	list            list.Model
 	fetcher         backend.ArticleFetcher
+	rawFetcher      backend.Fetcher
+	olderFetcher    backend.Fetcher
 	colorTr         *glamour.TermRenderer
 	noColorTr       *glamour.TermRenderer
 	colors          *theme.Colors
 	selector        *selector
 	title           string
+	description     string
+	link            string
+	stats           string
+	siblings        []string
+	accents         map[string]lipgloss.Color
+	renderModes     map[string]rss.RenderMode
+	collapsed       []list.Item
+	positionGUID    string
 	viewport        viewport.Model
 	keymap          Keymap
+	options         Options
 	spinner         spinner.Model
 	style           style
 	height          int
 	width           int
 	errShown        bool
 	loaded          bool
+	older           bool
+	dateGroups      bool
+	showAuthors     bool
+	multiAuthor     bool
 	viewportOpen    bool
 	viewportFocused bool
+	split           bool
+	readOnly        bool
 	lastFilterState list.FilterState
 }
 
@@ -52,15 +76,19 @@ func New(colors *theme.Colors, width, height int, title string, fetcher backend.
 
 	// Create the model
 	return Model{
-		colors:   colors,
-		style:    newStyle(colors, width, height),
-		width:    width,
-		height:   height,
-		selector: newSelector(colors),
-		spinner:  spin,
-		title:    title,
-		fetcher:  fetcher,
-		keymap:   DefaultKeymap,
+		colors:      colors,
+		style:       newStyle(colors, width, height, true),
+		width:       width,
+		height:      height,
+		selector:    newSelector(colors),
+		spinner:     spin,
+		title:       title,
+		fetcher:     fetcher,
+		keymap:      DefaultKeymap,
+		options:     DefaultOptions,
+		split:       true,
+		dateGroups:  DefaultOptions.GroupByDate,
+		showAuthors: DefaultOptions.ShowAuthors,
 	}
 }
 
@@ -71,8 +99,13 @@ func (m Model) Title() string {
 
 // Style returns the style of the tab
 func (m Model) Style() tab.Style {
+	color := m.colors.Color3
+	if accent, ok := m.accents[m.title]; ok {
+		color = accent
+	}
+
 	return tab.Style{
-		Color: m.colors.Color3,
+		Color: color,
 		Icon:  "",
 		Name:  "FEED",
 	}
@@ -84,22 +117,44 @@ func (m Model) SetSize(width, height int) tab.Tab {
 		return m
 	}
 
-	m.style = m.style.setSize(width, height)
-	m.list.SetSize(m.style.listWidth, height)
-	m.viewport.Width = m.style.viewportWidth
-	m.viewport.Height = height
+	m.style = m.style.setSize(width, height, m.split)
+	m.list.SetSize(m.style.listWidth-m.scrollbarWidth(), height-m.headerHeight())
+	m.viewport.Width = m.style.viewportWidth - m.scrollbarWidth()
+	m.viewport.Height = height - 1
 	m.width = width
 	m.height = height
+
+	// The article needs to be re-rendered to fit the new width
+	if err := m.newRenderers(); err != nil {
+		m.errShown = true
+		m.loaded = false
+		return m
+	}
+
 	newTab, _ := m.updateViewport()
 
 	// Re-Wrap the descs
-	items := m.list.Items()
-	for i := range items {
-		item := items[i].(backend.ArticleItem)
-		item.Desc = wrap.String(item.RawDesc, m.style.listWidth-4)
-		items[i] = item
+	newModel := newTab.(Model)
+	newModel.wrapDescs(newModel.list.Items())
+	newModel.wrapDescs(newModel.collapsed)
+	return newModel
+}
+
+// Restyle rebuilds the styles of the tab from its colors, the article is rendered again
+func (m Model) Restyle() tab.Tab {
+	m.style = newStyle(m.colors, m.style.width, m.style.height, m.split)
+	if !m.loaded {
+		return m
+	}
+
+	m.list.SetDelegate(m.newDelegate())
+	if err := m.newRenderers(); err != nil {
+		m.errShown = true
+		m.loaded = false
+		return m
 	}
 
+	newTab, _ := m.updateViewport()
 	return newTab
 }
 
@@ -110,10 +165,10 @@ func (m Model) Init() tea.Cmd {
 
 // Update the variables of the tab
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// Allow quitting when fetching failed
+	// Allow backing out when fetching failed
 	if m.errShown {
 		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "esc" {
-			return m, backend.StartQuitting()
+			return m, backend.Escape()
 		}
 	}
 
@@ -123,10 +178,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case backend.FetchArticleSuccessMsg:
-		return m.loadTab(msg.Items), nil
+		m.description = strings.Join(strings.Fields(msg.Description), " ")
+		m.link = msg.Link
+		m.older = msg.Older
+
+		// Keep the cursor where the older articles start instead of jumping back to the top
+		_, loadedOlder := m.list.SelectedItem().(olderItem)
+		index := m.list.Index()
+		loaded := m.loadTab(msg.Items).(Model)
+		if loadedOlder {
+			loaded.list.Select(index)
+			loaded.skipHeader(index)
+		}
+
+		return loaded, nil
+
+	case backend.FetchRawSuccessMsg:
+		m.stats = ""
+		m.viewportOpen = true
+		m.viewportFocused = true
+		m.positionGUID = ""
+		m.viewport.SetContent(msg.Content)
+		m.viewport.SetYOffset(0)
+		return m, nil
 
 	case backend.SetEnableKeybindMsg:
 		m.keymap.SetEnabled(bool(msg))
+		if m.readOnly {
+			m.keymap.disableEditing()
+		}
+
 		return m, nil
 
 	case lollypops.ChoiceResultMsg:
@@ -134,8 +215,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		_ = m.selector.open()
-		return m, nil
+		return m, m.selector.open(m.options.OpenCommand)
 
 	case tea.KeyMsg:
 		if !m.loaded {
@@ -145,7 +225,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch {
 		case msg.String() == "esc":
 			if m.list.FilterState() == list.Unfiltered {
-				return m, backend.StartQuitting()
+				return m, backend.Escape()
 			}
 
 			// There is no way to call `list.resetFiltering` since it's not exported
@@ -156,7 +236,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.list.KeyMap.CursorUp), key.Matches(msg, m.list.KeyMap.CursorDown):
 			if !m.viewportFocused {
 				var cmd tea.Cmd
+				index := m.list.Index()
 				m.list, cmd = m.list.Update(msg)
+				m.skipHeader(index)
 				if !m.viewportOpen {
 					m.viewportOpen = true
 				}
@@ -167,10 +249,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, m.keymap.Open):
 			if m.viewportFocused && m.selector.active {
+				if m.options.OpenCommand != "" {
+					return m, backend.MakeChoice("Open the link?", true)
+				}
+
 				return m, backend.MakeChoice("Open in browser?", true)
 			}
 
-			if m.list.SelectedItem() == nil {
+			if _, ok := m.list.SelectedItem().(readGroup); ok {
+				return m.expandRead()
+			}
+
+			if _, ok := m.list.SelectedItem().(olderItem); ok {
+				return m, m.olderFetcher(m.title)
+			}
+
+			if _, ok := m.selectedArticle(); !ok {
 				return m, nil
 			}
 
@@ -178,6 +272,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.viewportOpen = true
 			}
 
+			// Without the split layout the article would be hidden behind the list
+			if !m.isSplit() {
+				m.viewportFocused = true
+			}
+
 			m, cmd := m.updateViewport()
 			m, cmd2 := m.(Model).markAsRead()
 			return m, tea.Batch(cmd, cmd2)
@@ -190,6 +289,63 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewportFocused = !m.viewportFocused
 			return m, nil
 
+		case key.Matches(msg, m.keymap.ToggleLayout):
+			m.split = !m.split
+			return m.SetSize(m.width, m.height), nil
+
+		case key.Matches(msg, m.keymap.TogglePlainText):
+			current := m.renderMode()
+			next := rss.RenderModes[0]
+			for i, mode := range rss.RenderModes {
+				if mode == current {
+					next = rss.RenderModes[(i+1)%len(rss.RenderModes)]
+				}
+			}
+
+			sessionModes[m.title] = next
+			newTab, cmd := m.updateViewport()
+			return newTab, tea.Batch(cmd, backend.SetStatus(fmt.Sprintf("Showing the articles as %s", next)))
+
+		case key.Matches(msg, m.keymap.RememberRenderMode):
+			return m, backend.SetRenderMode(m.title, m.renderMode())
+
+		case key.Matches(msg, m.keymap.ToggleSortOrder):
+			return m, backend.ToggleSortOrder(m.title)
+
+		case key.Matches(msg, m.keymap.CopyTitle):
+			if item, ok := m.selectedArticle(); ok {
+				return m, copyTitle(item.ArtTitle)
+			}
+
+		case key.Matches(msg, m.keymap.ToggleDateGroups):
+			return m.toggleDateGroups()
+
+		case key.Matches(msg, m.keymap.ToggleAuthors):
+			return m.toggleAuthors()
+
+		case key.Matches(msg, m.keymap.PrevArticle):
+			if m.viewportFocused {
+				return m.moveArticle(-1)
+			}
+
+		case key.Matches(msg, m.keymap.NextArticle):
+			if m.viewportFocused {
+				return m.moveArticle(1)
+			}
+
+		case key.Matches(msg, m.keymap.PrevFeed):
+			return m.switchFeed(-1)
+
+		case key.Matches(msg, m.keymap.NextFeed):
+			return m.switchFeed(1)
+
+		case key.Matches(msg, m.keymap.ShowRawFeed):
+			if !m.rawViewEnabled() {
+				return m, nil
+			}
+
+			return m, m.rawFetcher(m.title)
+
 		case key.Matches(msg, m.keymap.RefreshArticles):
 			m.viewportOpen = false
 			m.loaded = false
@@ -198,17 +354,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(m.spinner.Tick, m.fetcher(m.title, true))
 
 		case key.Matches(msg, m.keymap.OpenInPager):
-			if m.list.SelectedItem() == nil {
+			selectedItem, ok := m.selectedArticle()
+			if !ok {
 				return m, nil
 			}
 
-			selectedItem := m.list.SelectedItem().(backend.ArticleItem)
 			styledText, err := m.colorTr.Render(selectedItem.MarkdownContent)
 			if err != nil {
 				m.viewport.SetContent(fmt.Sprintf("We have encountered an error styling the content: %s", err))
 				return m, nil
 			}
 
+			switch m.renderMode() {
+			case rss.RenderPlain:
+				styledText = selectedItem.PlainContent
+			case rss.RenderRaw:
+				styledText = selectedItem.RawContent
+			}
+
 			pager := os.Getenv("PAGER")
 			if pager == "" {
 				pager = "less -r"
@@ -228,7 +391,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case key.Matches(msg, m.keymap.SaveArticle):
-			if m.list.SelectedItem() == nil {
+			if _, ok := m.selectedArticle(); !ok {
 				return m, nil
 			}
 
@@ -240,13 +403,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m, cmd2 := m.(Model).markAsSaved()
 			return m, tea.Batch(cmd, cmd2)
 
+		case key.Matches(msg, m.keymap.ReadLater):
+			if item, ok := m.selectedArticle(); ok {
+				return m, backend.ReadLaterItem(m.title, item.Index)
+			}
+
+		case key.Matches(msg, m.keymap.DownloadEnclosure):
+			if item, ok := m.selectedArticle(); ok {
+				return m, backend.DownloadEnclosure(m.title, item.Index)
+			}
+
 		case key.Matches(msg, m.keymap.DeleteFromSaved):
-			if item := m.list.SelectedItem(); item != nil {
-				return m, backend.DeleteItem(m, fmt.Sprintf("%d", absListIndex(&m.list, item.FilterValue())))
+			if item, ok := m.selectedArticle(); ok {
+				return m, backend.DeleteItem(m, fmt.Sprintf("%d", item.Index))
 			}
 
 		case key.Matches(msg, m.keymap.MarkAsUnread):
-			selectedItem := m.list.SelectedItem().(backend.ArticleItem)
+			selectedItem, ok := m.selectedArticle()
+			if !ok {
+				return m, nil
+			}
+
 			if !strings.HasPrefix(selectedItem.ArtTitle, "✓ ") || strings.HasPrefix(selectedItem.ArtTitle, "↓ ") {
 				// This item has not been read, no need to unread what is unread
 				return m, nil
@@ -255,7 +432,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			index := absListIndex(&m.list, selectedItem.FilterValue())
 			selectedItem.ArtTitle = strings.Join(strings.Split(selectedItem.ArtTitle, " ")[1:], " ")
 			cmd := m.list.SetItem(index, selectedItem)
-			return m, tea.Batch(cmd, backend.MarkAsUnread(selectedItem.FeedURL))
+			return m, tea.Batch(cmd, backend.MarkAsUnread(selectedItem.GUID))
 
 		case key.Matches(msg, m.keymap.CycleSelection):
 			if !m.viewportFocused {
@@ -280,11 +457,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	if m.viewportFocused {
+		offset := m.viewport.YOffset
 		m.viewport, cmd = m.viewport.Update(msg)
-		return m, cmd
+		if m.viewport.YOffset == offset {
+			return m, cmd
+		}
+
+		return m.savePosition(cmd)
 	}
 
+	index := m.list.Index()
 	m.list, cmd = m.list.Update(msg)
+	m.skipHeader(index)
 	if m.list.FilterState() == m.lastFilterState {
 		return m, cmd
 	}
@@ -296,19 +480,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // loadTab is fired when the items are retrieved from the backend
 func (m Model) loadTab(items []list.Item) tab.Tab {
-	itemDelegate := list.NewDefaultDelegate()
-	itemDelegate.ShowDescription = true
-	itemDelegate.Styles = m.style.listItems
-	itemDelegate.SetHeight(3)
-
 	// Wrap the descs, it's better to do it upfront then to rely on the list pagination
-	for i := range items {
-		item := items[i].(backend.ArticleItem)
-		item.Desc = wrap.String(item.RawDesc, m.style.listWidth-4)
-		items[i] = item
+	m.multiAuthor = multiAuthor(items)
+	m.wrapDescs(items)
+
+	m.collapsed = nil
+	if m.options.CollapseRead {
+		items, m.collapsed = collapseRead(items)
+	}
+
+	if m.dateGroups {
+		items = groupByDate(items, time.Now())
+	}
+
+	if m.older && m.olderFetcher != nil {
+		items = append(items, olderItem{})
 	}
 
-	m.list = list.New(items, itemDelegate, m.style.listWidth, m.height)
+	m.list = list.New(items, m.newDelegate(), m.style.listWidth-m.scrollbarWidth(), m.height-m.headerHeight())
 
 	m.list.SetShowHelp(false)
 	m.list.SetShowTitle(false)
@@ -317,36 +506,108 @@ func (m Model) loadTab(items []list.Item) tab.Tab {
 	m.list.KeyMap.NextPage.SetEnabled(false)
 	m.list.KeyMap.PrevPage.SetEnabled(false)
 	m.list.KeyMap.CloseFullHelp.SetEnabled(false)
+	m.skipHeader(0)
+
+	m.viewport = viewport.New(m.style.viewportWidth-m.scrollbarWidth(), m.height-1)
+	if err := m.newRenderers(); err != nil {
+		m.errShown = true
+		m.loaded = false
+		return m
+	}
+
+	// Locked and loaded
+	m.loaded = true
+	return m
+}
+
+// newDelegate creates the delegate which renders the articles in the list
+func (m Model) newDelegate() list.DefaultDelegate {
+	itemDelegate := list.NewDefaultDelegate()
+	itemDelegate.ShowDescription = true
+	itemDelegate.Styles = m.style.listItems
+	itemDelegate.SetHeight(3)
+	if m.options.InlineLines > 0 {
+		itemDelegate.SetHeight(m.options.InlineLines + 1)
+	}
 
-	m.viewport = viewport.New(m.style.viewportWidth, m.height)
+	return itemDelegate
+}
 
+// newRenderers creates the markdown renderers for the current article width
+func (m *Model) newRenderers() error {
+	width, _ := m.articleLayout()
 	colorTr, err := glamour.NewTermRenderer(
 		glamour.WithStyles(m.colors.MarkdownStyle),
-		glamour.WithWordWrap(m.style.viewportWidth-2),
+		glamour.WithWordWrap(width),
 	)
-
 	if err != nil {
-		m.errShown = true
-		m.loaded = false
-		return m
+		return err
 	}
 
 	noColorTr, err := glamour.NewTermRenderer(
 		glamour.WithStyles(glamour.NoTTYStyleConfig),
-		glamour.WithWordWrap(m.style.viewportWidth-2),
+		glamour.WithWordWrap(width),
 	)
-
 	if err != nil {
-		m.errShown = true
-		m.loaded = false
-		return m
+		return err
 	}
 
-	// Locked and loaded
 	m.colorTr = colorTr
 	m.noColorTr = noColorTr
-	m.loaded = true
-	return m
+	return nil
+}
+
+// minArticleWidth is the narrowest the margins can make the article
+const minArticleWidth = 20
+
+// articleLayout returns the width the article is wrapped to and how far it's indented, which come from
+// the margin, the maximum width and the centering options
+func (m Model) articleLayout() (width, indent int) {
+	available := m.style.viewportWidth - 2
+	width = available - 2*m.options.ArticleMargin
+	if m.options.ArticleMaxWidth > 0 && width > m.options.ArticleMaxWidth {
+		width = m.options.ArticleMaxWidth
+	}
+
+	// The margins give way on narrow terminals
+	if width < minArticleWidth {
+		width = minArticleWidth
+		if width > available {
+			width = available
+		}
+	}
+
+	indent = m.options.ArticleMargin
+	if free := (available - width) / 2; m.options.CenterArticle || indent > free {
+		indent = free
+	}
+
+	if indent < 0 {
+		indent = 0
+	}
+
+	return width, indent
+}
+
+// indentArticle moves every line of the article to the right by the indent of the layout
+func (m Model) indentArticle(text string) string {
+	_, indent := m.articleLayout()
+	if indent == 0 {
+		return text
+	}
+
+	padding := strings.Repeat(" ", indent)
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		lines[i] = padding + lines[i]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// isSplit returns true if the list and the article are shown side by side
+func (m Model) isSplit() bool {
+	return m.split && m.width >= minSplitWidth
 }
 
 // updateViewport displays the viewport content
@@ -355,11 +616,29 @@ func (m Model) updateViewport() (tab.Tab, tea.Cmd) {
 		return m, nil
 	}
 
-	if m.list.SelectedItem() == nil {
+	selectedItem, ok := m.selectedArticle()
+	if !ok {
+		return m, nil
+	}
+
+	m.stats = fmt.Sprintf("%d words, %d characters", selectedItem.Words, selectedItem.Chars)
+	m.positionGUID = selectedItem.GUID
+
+	if mode := m.renderMode(); mode != rss.RenderMarkdown {
+		text := selectedItem.PlainContent
+		if mode == rss.RenderRaw {
+			text = selectedItem.RawContent
+		}
+
+		width, _ := m.articleLayout()
+		wrapped := m.indentArticle(theme.Wrap(text, width, m.options.PreserveURLs))
+		m.selector.newArticle(&text, &wrapped)
+		m.viewport.SetContent(wrapped)
+		m.viewport.SetYOffset(selectedItem.Position)
 		return m, nil
 	}
 
-	rawText := m.list.SelectedItem().(backend.ArticleItem).MarkdownContent
+	rawText := selectedItem.MarkdownContent
 	styledText, err := m.colorTr.Render(rawText)
 	if err != nil {
 		m.viewport.SetContent(fmt.Sprintf("We have encountered an error styling the content: %s", err))
@@ -372,15 +651,73 @@ func (m Model) updateViewport() (tab.Tab, tea.Cmd) {
 		return m, nil
 	}
 
+	styledText, noColorText = m.indentArticle(styledText), m.indentArticle(noColorText)
 	m.selector.newArticle(&rawText, &noColorText)
 	m.viewport.SetContent(styledText)
-	m.viewport.SetYOffset(0)
+	m.viewport.SetYOffset(selectedItem.Position)
 	return m, nil
 }
 
+// moveArticle opens the article which is offset articles away from the selected one without going back
+// to the list, the date headers are skipped and the read group and the older articles item count as the
+// end of the list
+func (m Model) moveArticle(offset int) (tab.Tab, tea.Cmd) {
+	var articles []int
+	current := -1
+	for i, item := range m.list.VisibleItems() {
+		if _, ok := item.(backend.ArticleItem); ok {
+			if i == m.list.Index() {
+				current = len(articles)
+			}
+
+			articles = append(articles, i)
+		}
+	}
+
+	// The raw feed view doesn't show an article, there is nothing to move from
+	if current == -1 || m.positionGUID == "" {
+		return m, nil
+	}
+
+	index := current + offset
+	if index < 0 || index >= len(articles) {
+		if !m.options.WrapArticles {
+			if offset > 0 {
+				return m, backend.SetStatus("This is the last article")
+			}
+
+			return m, backend.SetStatus("This is the first article")
+		}
+
+		index = (index + len(articles)) % len(articles)
+	}
+
+	m.list.Select(articles[index])
+	newTab, cmd := m.updateViewport()
+	newTab, cmd2 := newTab.(Model).markAsRead()
+	return newTab, tea.Batch(cmd, cmd2)
+}
+
+// savePosition remembers the scroll offset of the article shown in the viewport, the raw feed view
+// doesn't belong to any article so its offset isn't saved
+func (m Model) savePosition(cmd tea.Cmd) (tab.Tab, tea.Cmd) {
+	selectedItem, ok := m.selectedArticle()
+	if !ok || m.positionGUID == "" || selectedItem.GUID != m.positionGUID {
+		return m, cmd
+	}
+
+	selectedItem.Position = m.viewport.YOffset
+	setCmd := m.list.SetItem(absListIndex(&m.list, selectedItem.FilterValue()), selectedItem)
+	return m, tea.Batch(cmd, setCmd, backend.SavePosition(selectedItem.GUID, selectedItem.Position))
+}
+
 // markAsRead sets the selected article as read.
 func (m Model) markAsRead() (tab.Tab, tea.Cmd) {
-	selectedItem := m.list.SelectedItem().(backend.ArticleItem)
+	selectedItem, ok := m.selectedArticle()
+	if !ok {
+		return m, nil
+	}
+
 	if strings.HasPrefix(selectedItem.Title(), "✓ ") || strings.HasPrefix(selectedItem.Title(), "↓ ") {
 		// This item has been read
 		return m, nil
@@ -389,12 +726,16 @@ func (m Model) markAsRead() (tab.Tab, tea.Cmd) {
 	index := absListIndex(&m.list, selectedItem.FilterValue())
 	selectedItem.ArtTitle = "✓ " + selectedItem.ArtTitle
 	cmd := m.list.SetItem(index, selectedItem)
-	return m, tea.Batch(cmd, backend.MarkAsRead(selectedItem.FeedURL))
+	return m, tea.Batch(cmd, backend.MarkAsRead(selectedItem.GUID))
 }
 
 // markAsSaved sets the selected article as saved.
 func (m Model) markAsSaved() (tab.Tab, tea.Cmd) {
-	selectedItem := m.list.SelectedItem().(backend.ArticleItem)
+	selectedItem, ok := m.selectedArticle()
+	if !ok {
+		return m, nil
+	}
+
 	if strings.HasPrefix(selectedItem.Title(), "↓ ") {
 		// This item has been already saved
 		return m, nil
@@ -408,7 +749,185 @@ func (m Model) markAsSaved() (tab.Tab, tea.Cmd) {
 
 	index := absListIndex(&m.list, selectedItem.FilterValue())
 	cmd := m.list.SetItem(index, selectedItem)
-	return m, tea.Batch(cmd, backend.DownloadItem(m.title, index))
+	return m, tea.Batch(cmd, backend.DownloadItem(m.title, selectedItem.Index))
+}
+
+// copyTitle copies the title of an article to the clipboard without the read and the saved marks
+func copyTitle(title string) tea.Cmd {
+	return func() tea.Msg {
+		title = strings.TrimPrefix(strings.TrimPrefix(title, "✓ "), "↓ ")
+		title = strings.TrimSpace(title)
+		if err := clipboard.WriteAll(title); err != nil {
+			return backend.SetStatusMsg(fmt.Sprintf("Error copying the title: %s", err))
+		}
+
+		return backend.SetStatusMsg("Copied the title to the clipboard")
+	}
+}
+
+// selectedArticle returns the selected article, there is none if the list is empty or the read group is selected
+func (m Model) selectedArticle() (backend.ArticleItem, bool) {
+	item, ok := m.list.SelectedItem().(backend.ArticleItem)
+	return item, ok
+}
+
+// wrapDescs wraps the descriptions of the articles to the width of the list, if the articles are shown
+// inline the description is the start of the rendered article instead
+func (m Model) wrapDescs(items []list.Item) {
+	var inlineTr *glamour.TermRenderer
+	if m.options.InlineLines > 0 {
+		var err error
+		inlineTr, err = glamour.NewTermRenderer(
+			glamour.WithStyles(glamour.NoTTYStyleConfig),
+			glamour.WithWordWrap(m.style.listWidth-4),
+		)
+		if err != nil {
+			log.Println("Couldn't create the renderer for the inline articles", err)
+		}
+	}
+
+	for i := range items {
+		if item, ok := items[i].(backend.ArticleItem); ok {
+			desc := item.RawDesc
+			if m.showAuthors && m.multiAuthor && item.Author != "" {
+				desc = item.Author + " · " + desc
+			}
+
+			item.Desc = wrap.String(desc, m.style.listWidth-4)
+			if inlineTr != nil {
+				if rendered, err := inlineTr.Render(item.MarkdownContent); err == nil {
+					item.Desc = inlineText(rendered, m.style.listWidth-4)
+				}
+			}
+
+			items[i] = item
+		}
+	}
+}
+
+// multiAuthor checks if the articles were written by more than one author, the author is only worth
+// the room in the list then
+func multiAuthor(items []list.Item) bool {
+	first := ""
+	for _, item := range items {
+		article, ok := item.(backend.ArticleItem)
+		if !ok || article.Author == "" {
+			continue
+		}
+
+		if first == "" {
+			first = article.Author
+		} else if article.Author != first {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inlineText tidies up a rendered article for the list, the margin and the runs of blank lines which
+// would waste the few lines of the item are removed
+func inlineText(rendered string, width int) string {
+	lines := strings.Split(rendered, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(strings.TrimPrefix(line, "  "), " ")
+		if line == "" && (len(kept) == 0 || kept[len(kept)-1] == "") {
+			continue
+		}
+
+		kept = append(kept, line)
+	}
+
+	return wrap.String(strings.TrimSpace(strings.Join(kept, "\n")), width)
+}
+
+// expandRead replaces the read group with the read articles it stands in for, the older articles
+// item stays at the end
+func (m Model) expandRead() (tab.Tab, tea.Cmd) {
+	items := make([]list.Item, 0, len(m.list.Items())+len(m.collapsed))
+	var older []list.Item
+	for _, item := range m.list.Items() {
+		switch item.(type) {
+		case readGroup:
+		case olderItem:
+			older = append(older, item)
+		default:
+			items = append(items, item)
+		}
+	}
+
+	items = append(items, m.collapsed...)
+	if m.dateGroups {
+		items = groupByDate(ungroup(items), time.Now())
+	}
+
+	m.collapsed = nil
+	cmd := m.list.SetItems(append(items, older...))
+	m.skipHeader(m.list.Index())
+	return m, cmd
+}
+
+// toggleDateGroups shows or hides the date headers, the selected article stays selected
+func (m Model) toggleDateGroups() (tab.Tab, tea.Cmd) {
+	m.dateGroups = !m.dateGroups
+	selected, selectedOk := m.selectedArticle()
+
+	items := ungroup(m.list.Items())
+	if m.dateGroups {
+		items = groupByDate(items, time.Now())
+	}
+
+	cmd := m.list.SetItems(items)
+	if selectedOk && m.list.FilterState() == list.Unfiltered {
+		for i, item := range items {
+			if article, ok := item.(backend.ArticleItem); ok && article.Index == selected.Index {
+				m.list.Select(i)
+			}
+		}
+	}
+
+	m.skipHeader(0)
+	status := "Showing the articles without the dates"
+	if m.dateGroups {
+		status = "Grouping the articles by date"
+	}
+
+	return m, tea.Batch(cmd, backend.SetStatus(status))
+}
+
+// toggleAuthors shows or hides the authors in front of the descriptions
+func (m Model) toggleAuthors() (tab.Tab, tea.Cmd) {
+	m.showAuthors = !m.showAuthors
+	m.wrapDescs(m.list.Items())
+	m.wrapDescs(m.collapsed)
+
+	status := "Hiding the authors"
+	switch {
+	case m.showAuthors && m.multiAuthor:
+		status = "Showing the authors"
+
+	case m.showAuthors:
+		status = "Showing the authors, this feed has just one"
+	}
+
+	return m, backend.SetStatus(status)
+}
+
+// skipHeader moves the cursor off a date header, in the direction it moved from the previous index or
+// down if the header is at the top of the list
+func (m *Model) skipHeader(previous int) {
+	if _, ok := m.list.SelectedItem().(dateHeader); !ok {
+		return
+	}
+
+	if m.list.Index() < previous {
+		m.list.CursorUp()
+	}
+
+	if _, ok := m.list.SelectedItem().(dateHeader); ok {
+		m.list.CursorDown()
+	}
 }
 
 // View the tab
@@ -418,24 +937,99 @@ func (m Model) View() string {
 	}
 
 	if !m.viewportOpen {
-		return m.style.focusedList.Render(m.list.View())
+		return m.style.focusedList.Render(m.listView())
+	}
+
+	// Show only the focused pane if there isn't enough room for both
+	if !m.isSplit() {
+		if m.viewportFocused {
+			return m.style.focusedViewport.Render(m.viewportView())
+		}
+
+		return m.style.focusedList.Render(m.listView())
 	}
 
 	if m.viewportFocused {
 		return lipgloss.JoinHorizontal(
 			lipgloss.Left,
-			m.style.idleList.Render(m.list.View()),
-			m.style.focusedViewport.Render(m.viewport.View()),
+			m.style.idleList.Render(m.listView()),
+			m.style.focusedViewport.Render(m.viewportView()),
 		)
 	}
 
 	return lipgloss.JoinHorizontal(
 		lipgloss.Left,
-		m.style.focusedList.Render(m.list.View()),
-		m.style.idleViewport.Render(m.viewport.View()),
+		m.style.focusedList.Render(m.listView()),
+		m.style.idleViewport.Render(m.viewportView()),
 	)
 }
 
+// viewportView renders the article along with its word and character counts
+func (m Model) viewportView() string {
+	content := m.viewport.View()
+	if m.options.ShowScrollbar {
+		scrollbar := m.style.scrollbar(m.viewport.Height, m.viewport.TotalLineCount(), m.viewport.Height, m.viewport.YOffset)
+		content = lipgloss.JoinHorizontal(lipgloss.Top, content, scrollbar)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, content, m.style.stats.Render(m.stats))
+}
+
+// listView renders the article list along with the feed header
+func (m Model) listView() string {
+	content := m.list.View()
+	if m.options.ShowScrollbar {
+		perPage := m.list.Paginator.PerPage
+		total := len(m.list.VisibleItems())
+		scrollbar := m.style.scrollbar(m.list.Height(), total, perPage, m.list.Paginator.Page*perPage)
+		content = lipgloss.JoinHorizontal(lipgloss.Top, content, scrollbar)
+	}
+
+	header := m.headerView()
+	if header == "" {
+		return content
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, content)
+}
+
+// scrollbarWidth returns the width taken up by the scrollbar next to the list and the article
+func (m Model) scrollbarWidth() int {
+	if m.options.ShowScrollbar {
+		return 1
+	}
+
+	return 0
+}
+
+// headerView renders the description and the homepage link of the feed, feeds which omit both don't get a header
+func (m Model) headerView() string {
+	if m.description == "" && m.link == "" {
+		return ""
+	}
+
+	var lines []string
+	if m.description != "" {
+		lines = append(lines, m.style.headerDesc.Render(theme.Truncate(m.description, m.style.listWidth-2)))
+	}
+
+	if m.link != "" {
+		lines = append(lines, m.style.headerLink.Render(theme.Truncate(m.link, m.style.listWidth-2)))
+	}
+
+	return m.style.header.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// headerHeight returns the number of lines taken up by the feed header
+func (m Model) headerHeight() int {
+	header := m.headerView()
+	if header == "" {
+		return 0
+	}
+
+	return lipgloss.Height(header)
+}
+
 // DisableSaving disables the saving of the article
 func (m Model) DisableSaving() Model {
 	m.keymap.SaveArticle.SetEnabled(false)
@@ -448,13 +1042,154 @@ func (m Model) DisableDeleting() Model {
 	return m
 }
 
+// DisableEditing hides the actions which remember the settings of the feed, for the backends which
+// can't save them
+func (m Model) DisableEditing() Model {
+	m.readOnly = true
+	m.keymap.disableEditing()
+	return m
+}
+
+// EnableRawView allows showing the raw feed body, it only works in debug mode
+func (m Model) EnableRawView(rawFetcher backend.Fetcher) Model {
+	m.rawFetcher = rawFetcher
+	return m
+}
+
+// EnableOlder allows loading the older articles of a paged feed from the end of the list
+func (m Model) EnableOlder(olderFetcher backend.Fetcher) Model {
+	m.olderFetcher = olderFetcher
+	return m
+}
+
+// WithSiblings sets the feeds of the category the feed belongs to, in the order they are listed, which
+// allows switching to the previous and the next feed in place
+func (m Model) WithSiblings(siblings []string) Model {
+	m.siblings = siblings
+	return m
+}
+
+// RenameFeed updates the tab after a feed of its category was renamed, the shown feed and the siblings
+// are switched to the new name
+func (m Model) RenameFeed(oldName, newName string) Model {
+	if m.title == oldName {
+		m.title = newName
+	}
+
+	siblings := make([]string, len(m.siblings))
+	for i, name := range m.siblings {
+		if name == oldName {
+			name = newName
+		}
+
+		siblings[i] = name
+	}
+
+	m.siblings = siblings
+	if accent, ok := m.accents[oldName]; ok {
+		accents := make(map[string]lipgloss.Color, len(m.accents))
+		for name, color := range m.accents {
+			accents[name] = color
+		}
+
+		delete(accents, oldName)
+		accents[newName] = accent
+		m.accents = accents
+	}
+
+	if mode, ok := m.renderModes[oldName]; ok {
+		modes := make(map[string]rss.RenderMode, len(m.renderModes))
+		for name, other := range m.renderModes {
+			modes[name] = other
+		}
+
+		delete(modes, oldName)
+		modes[newName] = mode
+		m.renderModes = modes
+	}
+
+	return m
+}
+
+// WithAccents sets the colors of the feeds which have one, the tab is tinted with the color of the
+// shown feed instead of the colorscheme
+func (m Model) WithAccents(accents map[string]lipgloss.Color) Model {
+	m.accents = accents
+	return m
+}
+
+// WithRenderModes sets the render modes of the feeds which have one, they are used instead of the
+// default render mode unless the mode was switched during the session
+func (m Model) WithRenderModes(modes map[string]rss.RenderMode) Model {
+	m.renderModes = modes
+	return m
+}
+
+// renderMode returns how the articles of the shown feed are rendered
+func (m Model) renderMode() rss.RenderMode {
+	if mode, ok := sessionModes[m.title]; ok {
+		return mode
+	}
+
+	if mode, ok := m.renderModes[m.title]; ok {
+		return mode
+	}
+
+	return m.options.RenderMode
+}
+
+// switchFeed loads the feed which is offset places away from the current one in the category, wrapping
+// around at the ends
+func (m Model) switchFeed(offset int) (tab.Tab, tea.Cmd) {
+	current := -1
+	for i, name := range m.siblings {
+		if name == m.title {
+			current = i
+		}
+	}
+
+	if current == -1 || len(m.siblings) < 2 {
+		return m, nil
+	}
+
+	n := len(m.siblings)
+	m.title = m.siblings[((current+offset)%n+n)%n]
+	m.loaded = false
+	m.errShown = false
+	m.viewportOpen = false
+	m.viewportFocused = false
+	m.description = ""
+	m.link = ""
+	return m, tea.Batch(m.spinner.Tick, m.fetcher(m.title, false))
+}
+
+// rawViewEnabled returns true if the raw feed body can be shown
+func (m Model) rawViewEnabled() bool {
+	return m.options.DebugMode && m.rawFetcher != nil
+}
+
 // ShortHelp returns the short help for the tab
 func (m Model) ShortHelp() []key.Binding {
-	return []key.Binding{
+	binds := []key.Binding{
 		m.keymap.Open, m.keymap.ToggleFocus, m.keymap.RefreshArticles, m.keymap.OpenInPager,
-		m.keymap.SaveArticle, m.keymap.DeleteFromSaved, m.keymap.CycleSelection,
-		m.keymap.MarkAsUnread,
+		m.keymap.SaveArticle, m.keymap.ReadLater, m.keymap.DownloadEnclosure, m.keymap.DeleteFromSaved,
+		m.keymap.CycleSelection, m.keymap.MarkAsUnread, m.keymap.ToggleLayout, m.keymap.TogglePlainText, m.keymap.RememberRenderMode,
+		m.keymap.ToggleDateGroups, m.keymap.CopyTitle, m.keymap.ToggleSortOrder, m.keymap.ToggleAuthors,
 	}
+
+	if m.viewportFocused {
+		binds = append(binds, m.keymap.PrevArticle, m.keymap.NextArticle)
+	}
+
+	if len(m.siblings) > 1 {
+		binds = append(binds, m.keymap.PrevFeed, m.keymap.NextFeed)
+	}
+
+	if m.rawViewEnabled() {
+		binds = append(binds, m.keymap.ShowRawFeed)
+	}
+
+	return binds
 }
 
 // FullHelp returns the full help for the tab
@@ -0,0 +1,139 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TypicalAM/goread/internal/backend"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// readGroup is the list item which stands in for the collapsed read articles
+type readGroup struct{ count int }
+
+// FilterValue fulfills the list.Item interface, the group is never matched by the filter
+func (g readGroup) FilterValue() string {
+	return ""
+}
+
+// Title fulfills the list.DefaultItem interface
+func (g readGroup) Title() string {
+	if g.count == 1 {
+		return "▸ 1 read article"
+	}
+
+	return fmt.Sprintf("▸ %d read articles", g.count)
+}
+
+// Description fulfills the list.DefaultItem interface
+func (g readGroup) Description() string {
+	return "Press enter to expand"
+}
+
+// collapseRead keeps the unread articles in their order and replaces the read ones with a group at the end
+func collapseRead(items []list.Item) (shown, collapsed []list.Item) {
+	for _, item := range items {
+		if article, ok := item.(backend.ArticleItem); ok && strings.HasPrefix(article.ArtTitle, "✓ ") {
+			collapsed = append(collapsed, item)
+		} else {
+			shown = append(shown, item)
+		}
+	}
+
+	if len(collapsed) > 0 {
+		shown = append(shown, readGroup{len(collapsed)})
+	}
+
+	return shown, collapsed
+}
+
+// olderItem is the list item at the end of a paged feed which loads its older articles
+type olderItem struct{}
+
+// FilterValue fulfills the list.Item interface, the item is never matched by the filter
+func (o olderItem) FilterValue() string {
+	return ""
+}
+
+// Title fulfills the list.DefaultItem interface
+func (o olderItem) Title() string {
+	return "▾ Load older articles"
+}
+
+// Description fulfills the list.DefaultItem interface
+func (o olderItem) Description() string {
+	return "Press enter to load the next page of the feed"
+}
+
+// dateHeader is the list item above the articles published on the same day, it can't be selected
+type dateHeader struct{ label string }
+
+// FilterValue fulfills the list.Item interface, the header is never matched by the filter
+func (h dateHeader) FilterValue() string {
+	return ""
+}
+
+// Title fulfills the list.DefaultItem interface
+func (h dateHeader) Title() string {
+	return "── " + h.label + " ──"
+}
+
+// Description fulfills the list.DefaultItem interface
+func (h dateHeader) Description() string {
+	return ""
+}
+
+// groupByDate puts a header before the articles of every day, a new header starts whenever the day changes
+// so the feeds which aren't sorted by date get more than one header for a day
+func groupByDate(items []list.Item, now time.Time) []list.Item {
+	grouped := make([]list.Item, 0, len(items))
+	last := ""
+	for _, item := range items {
+		if article, ok := item.(backend.ArticleItem); ok {
+			if label := dateLabel(article.Published, now); label != last {
+				grouped = append(grouped, dateHeader{label})
+				last = label
+			}
+		}
+
+		grouped = append(grouped, item)
+	}
+
+	return grouped
+}
+
+// ungroup removes the date headers from the items
+func ungroup(items []list.Item) []list.Item {
+	result := make([]list.Item, 0, len(items))
+	for _, item := range items {
+		if _, ok := item.(dateHeader); !ok {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// dateLabel returns the label of the day the article was published on, like "Today", "Yesterday" or "Mar 3"
+func dateLabel(published, now time.Time) string {
+	if published.IsZero() {
+		return "Undated"
+	}
+
+	published = published.In(now.Location())
+	day := time.Date(published.Year(), published.Month(), published.Day(), 0, 0, 0, 0, now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case day.Equal(today):
+		return "Today"
+
+	case day.Equal(today.AddDate(0, 0, -1)):
+		return "Yesterday"
+
+	case day.Year() == today.Year():
+		return day.Format("Jan 2")
+	}
+
+	return day.Format("Jan 2, 2006")
+}
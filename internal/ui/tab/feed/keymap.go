@@ -4,14 +4,28 @@ import "github.com/charmbracelet/bubbles/key"
 
 // Keymap contains the key bindings for this tab
 type Keymap struct {
-	Open            key.Binding
-	ToggleFocus     key.Binding
-	RefreshArticles key.Binding
-	OpenInPager     key.Binding
-	SaveArticle     key.Binding
-	DeleteFromSaved key.Binding
-	CycleSelection  key.Binding
-	MarkAsUnread    key.Binding
+	Open               key.Binding
+	ToggleFocus        key.Binding
+	RefreshArticles    key.Binding
+	OpenInPager        key.Binding
+	SaveArticle        key.Binding
+	ReadLater          key.Binding
+	DownloadEnclosure  key.Binding
+	DeleteFromSaved    key.Binding
+	CycleSelection     key.Binding
+	MarkAsUnread       key.Binding
+	ToggleLayout       key.Binding
+	ShowRawFeed        key.Binding
+	TogglePlainText    key.Binding
+	RememberRenderMode key.Binding
+	PrevFeed           key.Binding
+	NextFeed           key.Binding
+	PrevArticle        key.Binding
+	NextArticle        key.Binding
+	ToggleDateGroups   key.Binding
+	CopyTitle          key.Binding
+	ToggleSortOrder    key.Binding
+	ToggleAuthors      key.Binding
 }
 
 // DefaultKeymap contains the default key bindings for this tab
@@ -36,6 +50,14 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("s", "ctrl+s"),
 		key.WithHelp("s/ctrl+s", "Save"),
 	),
+	ReadLater: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "Read later"),
+	),
+	DownloadEnclosure: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "Download enclosure"),
+	),
 	DeleteFromSaved: key.NewBinding(
 		key.WithKeys("d", "ctrl+d"),
 		key.WithHelp("d/ctrl+d", "Delete from saved"),
@@ -48,6 +70,54 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("u"),
 		key.WithHelp("u", "Mark as unread"),
 	),
+	ToggleLayout: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "Toggle split view"),
+	),
+	ShowRawFeed: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "Show raw feed"),
+	),
+	TogglePlainText: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "Switch render mode"),
+	),
+	RememberRenderMode: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "Remember render mode"),
+	),
+	PrevFeed: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "Previous feed"),
+	),
+	NextFeed: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "Next feed"),
+	),
+	PrevArticle: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "Previous article"),
+	),
+	NextArticle: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "Next article"),
+	),
+	ToggleDateGroups: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "Group by date"),
+	),
+	CopyTitle: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "Copy title"),
+	),
+	ToggleSortOrder: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "Oldest/newest first"),
+	),
+	ToggleAuthors: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "Show/hide authors"),
+	),
 }
 
 // SetEnabled allows to disable/enable shortcuts
@@ -56,7 +126,27 @@ func (m *Keymap) SetEnabled(enabled bool) {
 	m.ToggleFocus.SetEnabled(enabled)
 	m.RefreshArticles.SetEnabled(enabled)
 	m.SaveArticle.SetEnabled(enabled)
+	m.ReadLater.SetEnabled(enabled)
+	m.DownloadEnclosure.SetEnabled(enabled)
 	m.DeleteFromSaved.SetEnabled(enabled)
 	m.CycleSelection.SetEnabled(enabled)
 	m.MarkAsUnread.SetEnabled(enabled)
+	m.ToggleLayout.SetEnabled(enabled)
+	m.ShowRawFeed.SetEnabled(enabled)
+	m.TogglePlainText.SetEnabled(enabled)
+	m.RememberRenderMode.SetEnabled(enabled)
+	m.PrevFeed.SetEnabled(enabled)
+	m.NextFeed.SetEnabled(enabled)
+	m.PrevArticle.SetEnabled(enabled)
+	m.NextArticle.SetEnabled(enabled)
+	m.ToggleDateGroups.SetEnabled(enabled)
+	m.CopyTitle.SetEnabled(enabled)
+	m.ToggleSortOrder.SetEnabled(enabled)
+	m.ToggleAuthors.SetEnabled(enabled)
+}
+
+// disableEditing disables the shortcuts which remember the settings of the feed in the urls file
+func (m *Keymap) disableEditing() {
+	m.RememberRenderMode.SetEnabled(false)
+	m.ToggleSortOrder.SetEnabled(false)
 }
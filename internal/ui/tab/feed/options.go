@@ -0,0 +1,54 @@
+package feed
+
+import "github.com/TypicalAM/goread/internal/backend/rss"
+
+// Options contains the behaviour settings for this tab
+type Options struct {
+	DebugMode bool `yaml:"debug_mode"`
+	// OpenCommand is the command which opens the selected links, {url} is replaced with the link.
+	// The links are opened in the browser if it's empty.
+	OpenCommand string `yaml:"open_command"`
+	// CollapseRead moves the read articles into a group at the bottom of the list, which expands when selected
+	CollapseRead bool `yaml:"collapse_read"`
+	// WrapArticles makes the next and previous article keys wrap around at the ends of the list
+	WrapArticles bool `yaml:"wrap_articles"`
+	// ShowScrollbar adds a scrollbar to the right of the article list and the article
+	ShowScrollbar bool `yaml:"show_scrollbar"`
+	// RenderMode is how the articles are shown unless their feed sets its own render mode
+	RenderMode rss.RenderMode `yaml:"render_mode"`
+	// PreserveURLs keeps the links whole when wrapping the plain and the raw articles, a link is only
+	// split if it doesn't fit on a line of its own
+	PreserveURLs bool `yaml:"preserve_urls"`
+	// InlineLines is the number of lines of each article shown right in the list, 0 shows the short
+	// description instead
+	InlineLines int `yaml:"inline_lines"`
+	// GroupByDate shows the articles under a header for each day they were published on
+	GroupByDate bool `yaml:"group_by_date"`
+	// ShowAuthors shows the author in front of the description of each article if the feed has more
+	// than one author
+	ShowAuthors bool `yaml:"show_authors"`
+	// ArticleMargin is the number of empty columns on both sides of the article
+	ArticleMargin int `yaml:"article_margin"`
+	// ArticleMaxWidth is the widest the article text gets, 0 uses the whole width of the viewport
+	ArticleMaxWidth int `yaml:"article_max_width"`
+	// CenterArticle puts the article in the middle of the viewport instead of after the left margin
+	CenterArticle bool `yaml:"center_article"`
+}
+
+// DefaultOptions contains the default settings for this tab
+var DefaultOptions = Options{
+	DebugMode:     false,
+	OpenCommand:   "",
+	CollapseRead:  false,
+	WrapArticles:  false,
+	ShowScrollbar: false,
+	RenderMode:    rss.RenderMarkdown,
+	PreserveURLs:  true,
+	InlineLines:   0,
+	GroupByDate:   false,
+	ShowAuthors:   true,
+	// The article fills the viewport unless it's narrowed down
+	ArticleMargin:   0,
+	ArticleMaxWidth: 0,
+	CenterArticle:   false,
+}
@@ -2,11 +2,14 @@ package feed
 
 import (
 	"errors"
+	"fmt"
 	"os/exec"
 	"runtime"
 	"strings"
 
+	"github.com/TypicalAM/goread/internal/backend"
 	"github.com/TypicalAM/goread/internal/theme"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"mvdan.cc/xurls/v2"
 )
@@ -120,16 +123,64 @@ func (s *selector) cycle() string {
 	return b.String()
 }
 
-// open opens the URL in the browser
-func (s *selector) open() error {
+// open opens the selected URL with the command template, or in the browser if the template is empty.
+// Failures are reported in the status bar.
+func (s *selector) open(template string) tea.Cmd {
+	url := s.urls[s.selection]
+	return func() tea.Msg {
+		if template == "" {
+			if err := openInBrowser(url); err != nil {
+				return backend.SetStatusMsg(fmt.Sprintf("Error opening the link: %s", err))
+			}
+
+			return nil
+		}
+
+		args := strings.Fields(template)
+		for i := range args {
+			args[i] = strings.ReplaceAll(args[i], "{url}", url)
+		}
+
+		output, err := exec.Command(args[0], args[1:]...).CombinedOutput() //nolint:gosec
+		if err != nil {
+			if lines := strings.TrimSpace(string(output)); lines != "" {
+				err = fmt.Errorf("%w: %s", err, strings.Split(lines, "\n")[0])
+			}
+
+			return backend.SetStatusMsg(fmt.Sprintf("Error running %s: %s", args[0], err))
+		}
+
+		return nil
+	}
+}
+
+// openInBrowser opens the URL with the default opener of the OS
+func openInBrowser(url string) error {
 	switch runtime.GOOS {
 	case "linux":
-		return exec.Command("xdg-open", s.urls[s.selection]).Start() //nolint:gosec
+		return exec.Command("xdg-open", url).Start() //nolint:gosec
 	case "windows":
-		return exec.Command("rundll32", "url.dll,FileProtocolHandler", s.urls[s.selection]).Start() //nolint:gosec
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start() //nolint:gosec
 	case "darwin":
-		return exec.Command("open", s.urls[s.selection]).Start() //nolint:gosec
+		return exec.Command("open", url).Start() //nolint:gosec
 	default:
 		return errors.New("unsupported platform")
 	}
 }
+
+// ValidateOpenCommand checks if the open command template can be used to open links
+func ValidateOpenCommand(template string) error {
+	if template == "" {
+		return nil
+	}
+
+	if !strings.Contains(template, "{url}") {
+		return errors.New("the open command has to contain the {url} placeholder")
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(template), "{url}") {
+		return errors.New("the open command has to start with a program")
+	}
+
+	return nil
+}
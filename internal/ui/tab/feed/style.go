@@ -1,6 +1,8 @@
 package feed
 
 import (
+	"strings"
+
 	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
@@ -10,6 +12,12 @@ import (
 type style struct {
 	listItems       list.DefaultItemStyles
 	link            lipgloss.Style
+	header          lipgloss.Style
+	headerDesc      lipgloss.Style
+	headerLink      lipgloss.Style
+	stats           lipgloss.Style
+	scrollTrack     lipgloss.Style
+	scrollThumb     lipgloss.Style
 	loadingMsg      lipgloss.Style
 	idleList        lipgloss.Style
 	focusedList     lipgloss.Style
@@ -22,15 +30,41 @@ type style struct {
 	viewportWidth   int
 }
 
+// minSplitWidth is the minimum terminal width for which the list and the article are shown side by side.
+const minSplitWidth = 80
+
 // newStyle creates a new style for the feed tab.
-func newStyle(colors *theme.Colors, width, height int) style {
-	listWidth := width/4 - 2
-	viewportWidth := width - listWidth - 4
+func newStyle(colors *theme.Colors, width, height int, split bool) style {
+	listWidth, viewportWidth := paneWidths(width, split)
 
 	link := lipgloss.NewStyle().
 		Background(colors.Color1).
 		Underline(true)
 
+	header := lipgloss.NewStyle().
+		PaddingLeft(1).
+		MarginBottom(1)
+
+	headerDesc := lipgloss.NewStyle().
+		Foreground(colors.TextDark).
+		Italic(true)
+
+	headerLink := lipgloss.NewStyle().
+		Foreground(colors.Color2).
+		Underline(true)
+
+	stats := lipgloss.NewStyle().
+		Foreground(colors.TextDark).
+		PaddingLeft(1)
+
+	scrollTrack := lipgloss.NewStyle().
+		Foreground(colors.TextDark).
+		SetString("│")
+
+	scrollThumb := lipgloss.NewStyle().
+		Foreground(colors.Color3).
+		SetString("┃")
+
 	loadingMsg := lipgloss.NewStyle().
 		MarginLeft(3).
 		MarginTop(1)
@@ -80,6 +114,12 @@ func newStyle(colors *theme.Colors, width, height int) style {
 		listWidth:       listWidth,
 		viewportWidth:   viewportWidth,
 		link:            link,
+		header:          header,
+		headerDesc:      headerDesc,
+		headerLink:      headerLink,
+		stats:           stats,
+		scrollTrack:     scrollTrack,
+		scrollThumb:     scrollThumb,
 		loadingMsg:      loadingMsg,
 		errIcon:         errIconStyle.String(),
 		idleList:        idleList,
@@ -91,14 +131,59 @@ func newStyle(colors *theme.Colors, width, height int) style {
 }
 
 // setSize sets the size of the style.
-func (s style) setSize(width, height int) style {
+func (s style) setSize(width, height int, split bool) style {
 	s.width = width
 	s.height = height
-	s.listWidth = width/4 - 2
-	s.viewportWidth = width - s.listWidth - 4
+	s.listWidth, s.viewportWidth = paneWidths(width, split)
 	s.idleList = s.idleList.Width(s.listWidth).Height(height)
 	s.focusedList = s.focusedList.Width(s.listWidth).Height(height)
 	s.idleViewport = s.idleViewport.Width(s.viewportWidth).Height(height)
 	s.focusedViewport = s.focusedViewport.Width(s.viewportWidth).Height(height)
 	return s
 }
+
+// scrollbar renders a column of the given height, the thumb shows which part of the content is visible
+func (s style) scrollbar(height, total, visible, offset int) string {
+	if height <= 0 {
+		return ""
+	}
+
+	thumbSize, thumbStart := height, 0
+	if total > visible && visible > 0 {
+		thumbSize = height * visible / total
+		if thumbSize < 1 {
+			thumbSize = 1
+		}
+
+		thumbStart = (height - thumbSize) * offset / (total - visible)
+		if thumbStart > height-thumbSize {
+			thumbStart = height - thumbSize
+		}
+
+		if thumbStart < 0 {
+			thumbStart = 0
+		}
+	}
+
+	lines := make([]string, height)
+	for i := range lines {
+		if i >= thumbStart && i < thumbStart+thumbSize {
+			lines[i] = s.scrollThumb.String()
+		} else {
+			lines[i] = s.scrollTrack.String()
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// paneWidths calculates the widths of the list and the viewport, if the terminal is too narrow for
+// the split layout both of the panes take up the whole width.
+func paneWidths(width int, split bool) (listWidth, viewportWidth int) {
+	if !split || width < minSplitWidth {
+		return width - 2, width - 2
+	}
+
+	listWidth = width/4 - 2
+	return listWidth, width - listWidth - 4
+}
@@ -7,6 +7,8 @@ type Keymap struct {
 	NewCategory    key.Binding
 	EditCategory   key.Binding
 	DeleteCategory key.Binding
+	ExportCategory key.Binding
+	TogglePin      key.Binding
 }
 
 // DefaultKeymap contains the default key bindings for this tab
@@ -23,6 +25,14 @@ var DefaultKeymap = Keymap{
 		key.WithKeys("d", "ctrl+d"),
 		key.WithHelp("d/ctrl+d", "Delete"),
 	),
+	ExportCategory: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "Export"),
+	),
+	TogglePin: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "Pin"),
+	),
 }
 
 // SetEnabled allows to disable/enable shortcuts
@@ -30,4 +40,14 @@ func (m *Keymap) SetEnabled(enabled bool) {
 	m.NewCategory.SetEnabled(enabled)
 	m.EditCategory.SetEnabled(enabled)
 	m.DeleteCategory.SetEnabled(enabled)
+	m.ExportCategory.SetEnabled(enabled)
+	m.TogglePin.SetEnabled(enabled)
+}
+
+// disableEditing disables the shortcuts which change the categories, exporting them still works
+func (m *Keymap) disableEditing() {
+	m.NewCategory.SetEnabled(false)
+	m.EditCategory.SetEnabled(false)
+	m.DeleteCategory.SetEnabled(false)
+	m.TogglePin.SetEnabled(false)
 }
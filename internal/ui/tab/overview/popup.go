@@ -1,6 +1,8 @@
 package overview
 
 import (
+	"fmt"
+
 	"github.com/TypicalAM/goread/internal/backend/rss"
 	"github.com/TypicalAM/goread/internal/theme"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -22,6 +24,8 @@ type focusedField int
 const (
 	allField focusedField = iota
 	downloadedField
+	todayField
+	readLaterField
 	nameField
 	descField
 )
@@ -42,10 +46,10 @@ type Popup struct {
 // NewPopup creates a new popup window in which the user can choose a new category.
 func NewPopup(colors *theme.Colors, oldName, oldDesc string) Popup {
 	width := 46
-	height := 14
+	height := 20
 
 	editing := oldName != "" || oldDesc != ""
-	reserved := oldName == rss.AllFeedsName || oldName == rss.DownloadedFeedsName
+	reserved := rss.IsReservedName(oldName)
 
 	nameInput := textinput.New()
 	nameInput.CharLimit = 30
@@ -57,8 +61,13 @@ func NewPopup(colors *theme.Colors, oldName, oldDesc string) Popup {
 	descInput.Prompt = "Description: "
 
 	focused := allField
-	if oldName == rss.DownloadedFeedsName {
+	switch oldName {
+	case rss.DownloadedFeedsName:
 		focused = downloadedField
+	case rss.TodayFeedsName:
+		focused = todayField
+	case rss.ReadLaterFeedsName:
+		focused = readLaterField
 	}
 
 	var style popupStyle
@@ -108,6 +117,10 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case allField:
 				p.focused = downloadedField
 			case downloadedField:
+				p.focused = todayField
+			case todayField:
+				p.focused = readLaterField
+			case readLaterField:
 				p.focused = nameField
 				cmds = append(cmds, p.nameInput.Focus())
 			case nameField:
@@ -130,12 +143,16 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, p.descInput.Focus())
 			case downloadedField:
 				p.focused = allField
+			case todayField:
+				p.focused = downloadedField
+			case readLaterField:
+				p.focused = todayField
 			case nameField:
 				if p.editing {
 					return p, nil
 				}
 
-				p.focused = downloadedField
+				p.focused = readLaterField
 				p.nameInput.Blur()
 			case descField:
 				p.focused = nameField
@@ -143,6 +160,17 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, p.nameInput.Focus())
 			}
 
+		case "1", "2", "3", "4", "5":
+			// The digits are typed into the name and the description as usual
+			if p.editing || p.focused == nameField || p.focused == descField {
+				break
+			}
+
+			p.focused = focusedField(msg.String()[0] - '1')
+			if p.focused == nameField {
+				cmds = append(cmds, p.nameInput.Focus())
+			}
+
 		case "enter":
 			switch p.focused {
 			case allField:
@@ -151,6 +179,12 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case downloadedField:
 				return p, confirm(rss.DownloadedFeedsName, "", "", false)
 
+			case todayField:
+				return p, confirm(rss.TodayFeedsName, "", "", false)
+
+			case readLaterField:
+				return p, confirm(rss.ReadLaterFeedsName, "", "", false)
+
 			case nameField, descField:
 				return p, confirm(p.nameInput.Value(), p.descInput.Value(), p.oldName, p.oldName != "")
 			}
@@ -174,10 +208,14 @@ func (p Popup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the popup window.
 func (p Popup) View() string {
-	renderedChoices := make([]string, 3)
-
-	titles := []string{rss.AllFeedsName, rss.DownloadedFeedsName, "New category"}
-	descs := []string{"All available articles", "Downloaded articles", p.nameInput.View() + "\n" + p.descInput.View()}
+	titles := []string{
+		rss.AllFeedsName, rss.DownloadedFeedsName, rss.TodayFeedsName, rss.ReadLaterFeedsName, "New category",
+	}
+	descs := []string{
+		"All available articles", "Downloaded articles", "Articles published today", "Articles queued for later",
+		p.nameInput.View() + "\n" + p.descInput.View(),
+	}
+	renderedChoices := make([]string, len(titles))
 
 	var focused int
 	switch p.focused {
@@ -185,11 +223,20 @@ func (p Popup) View() string {
 		focused = 0
 	case downloadedField:
 		focused = 1
-	case nameField, descField:
+	case todayField:
 		focused = 2
+	case readLaterField:
+		focused = 3
+	case nameField, descField:
+		focused = 4
 	}
 
-	for i := 0; i < 3; i++ {
+	for i := range titles {
+		// The choices can be selected with the number keys
+		if !p.editing {
+			titles[i] = fmt.Sprintf("%d. %s", i+1, titles[i])
+		}
+
 		if i == focused {
 			renderedChoices[i] = p.style.selectedChoice.Render(lipgloss.JoinVertical(
 				lipgloss.Top,
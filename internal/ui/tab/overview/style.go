@@ -25,7 +25,7 @@ func newPopupStyle(colors *theme.Colors, width, height int, headingText string)
 	list := lipgloss.NewStyle().
 		Margin(1, 4).
 		Width(width - 2).
-		Height(10)
+		Height(height - 4)
 
 	choice := lipgloss.NewStyle().
 		PaddingLeft(1).
@@ -14,14 +14,15 @@ import (
 
 // Model contains the state of this tab
 type Model struct {
-	colors  *theme.Colors
-	fetcher backend.Fetcher
-	title   string
-	keymap  Keymap
-	list    simplelist.Model
-	width   int
-	height  int
-	loaded  bool
+	colors   *theme.Colors
+	fetcher  backend.Fetcher
+	title    string
+	keymap   Keymap
+	list     simplelist.Model
+	width    int
+	height   int
+	loaded   bool
+	readOnly bool
 }
 
 // New creates a new welcome tab with sensible defaults
@@ -64,6 +65,15 @@ func (m Model) SetSize(width, height int) tab.Tab {
 	return m
 }
 
+// Restyle rebuilds the styles of the tab from its colors
+func (m Model) Restyle() tab.Tab {
+	if m.loaded {
+		m.list.Restyle()
+	}
+
+	return m
+}
+
 // Init initializes the tab
 func (m Model) Init() tea.Cmd {
 	return m.fetcher("")
@@ -87,6 +97,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case backend.SetEnableKeybindMsg:
 		m.keymap.SetEnabled(bool(msg))
+		if m.readOnly {
+			m.keymap.disableEditing()
+		}
+
 		return m, nil
 
 	case lollypops.ChoiceResultMsg:
@@ -113,7 +127,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch {
 		case msg.String() == "esc":
-			return m, backend.StartQuitting()
+			return m, backend.Escape()
 
 		case key.Matches(msg, m.list.Keymap.Open):
 			if !m.list.IsEmpty() {
@@ -137,6 +151,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, backend.MakeChoice("Delete category?", true)
 			}
 
+		case key.Matches(msg, m.keymap.TogglePin):
+			if !m.list.IsEmpty() {
+				return m, backend.TogglePin(m.list.SelectedItem().FilterValue())
+			}
+
+		case key.Matches(msg, m.keymap.ExportCategory):
+			if !m.list.IsEmpty() {
+				return m, backend.ExportCategory(m.list.SelectedItem().FilterValue())
+			}
+
 		default:
 			// Check if we need to open a new category
 			if item, ok := m.list.GetItem(msg.String()); ok {
@@ -161,10 +185,17 @@ func (m Model) View() string {
 
 // ShortHelp returns the short help for this tab
 func (m Model) ShortHelp() []key.Binding {
-	return []key.Binding{m.keymap.NewCategory, m.keymap.EditCategory, m.keymap.DeleteCategory}
+	return []key.Binding{m.keymap.NewCategory, m.keymap.EditCategory, m.keymap.DeleteCategory, m.keymap.ExportCategory, m.keymap.TogglePin}
 }
 
 // FullHelp returns the full help for this tab
 func (m Model) FullHelp() [][]key.Binding {
 	return [][]key.Binding{m.ShortHelp(), m.list.ShortHelp()}
 }
+
+// DisableEditing hides the actions which change the categories, for the backends which can't save them
+func (m Model) DisableEditing() Model {
+	m.readOnly = true
+	m.keymap.disableEditing()
+	return m
+}
@@ -21,4 +21,6 @@ type Tab interface {
 	Title() string
 	Style() Style
 	SetSize(width, height int) Tab
+	// Restyle rebuilds the styles of the tab from its colors, they change when the colorscheme is reloaded
+	Restyle() Tab
 }